@@ -20,6 +20,7 @@ import (
 	"github.com/spidernet-io/bmc/pkg/agent/hostendpoint"
 	"github.com/spidernet-io/bmc/pkg/agent/hostoperation"
 	"github.com/spidernet-io/bmc/pkg/agent/hoststatus"
+	"github.com/spidernet-io/bmc/pkg/agent/hostworkflow"
 	secretcontroller "github.com/spidernet-io/bmc/pkg/agent/secret"
 	"github.com/spidernet-io/bmc/pkg/dhcpserver"
 	bmcv1beta1 "github.com/spidernet-io/bmc/pkg/k8s/apis/bmc.spidernet.io/v1beta1"
@@ -127,6 +128,18 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Initialize hostworkflow controller
+	hostWorkflowCtrl, err := hostworkflow.NewHostWorkflowController(mgr, agentConfig)
+	if err != nil {
+		log.Logger.Errorf("Failed to create hostworkflow controller: %v", err)
+		os.Exit(1)
+	}
+
+	if err = hostWorkflowCtrl.SetupWithManager(mgr); err != nil {
+		log.Logger.Errorf("Unable to create hostworkflow controller: %v", err)
+		os.Exit(1)
+	}
+
 	// Get DHCP event channels for hoststatus
 	addChan, deleteChan := hostStatusCtrl.GetDHCPEventChan()
 
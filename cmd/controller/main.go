@@ -2,11 +2,15 @@ package main
 
 import (
 	"flag"
+	"fmt"
 	"os"
+	"strconv"
+	"strings"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
@@ -17,12 +21,23 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	controller "github.com/spidernet-io/bmc/pkg/controller/clusteragent"
+	hostoperationcontroller "github.com/spidernet-io/bmc/pkg/controller/hostoperation"
+	hostoperationschedulecontroller "github.com/spidernet-io/bmc/pkg/controller/hostoperationschedule"
+	hostoperationsetcontroller "github.com/spidernet-io/bmc/pkg/controller/hostoperationset"
+	hostpoolcontroller "github.com/spidernet-io/bmc/pkg/controller/hostpool"
+	nodemappingcontroller "github.com/spidernet-io/bmc/pkg/controller/nodemapping"
+	remediationcontroller "github.com/spidernet-io/bmc/pkg/controller/remediation"
 	bmcv1beta1 "github.com/spidernet-io/bmc/pkg/k8s/apis/bmc.spidernet.io/v1beta1"
 	versioned "github.com/spidernet-io/bmc/pkg/k8s/client/clientset/versioned"
 	"github.com/spidernet-io/bmc/pkg/log"
 	clusteragentwebhook "github.com/spidernet-io/bmc/pkg/webhook/clusteragent"
 	hostendpointwebhook "github.com/spidernet-io/bmc/pkg/webhook/hostendpoint"
 	hostoperationwebhook "github.com/spidernet-io/bmc/pkg/webhook/hostoperation"
+	hostoperationschedulewebhook "github.com/spidernet-io/bmc/pkg/webhook/hostoperationschedule"
+	hostoperationsetwebhook "github.com/spidernet-io/bmc/pkg/webhook/hostoperationset"
+	hostpolicywebhook "github.com/spidernet-io/bmc/pkg/webhook/hostpolicy"
+	hostpoolwebhook "github.com/spidernet-io/bmc/pkg/webhook/hostpool"
+	hostworkflowwebhook "github.com/spidernet-io/bmc/pkg/webhook/hostworkflow"
 )
 
 var (
@@ -62,6 +77,12 @@ func main() {
 		os.Exit(1)
 	}
 
+	// the objects created by the controller and the agents on behalf of their owners keep the requester of the owners
+	trustedUsers := hostoperationwebhook.TrustedUsers{
+		Namespace:          namespace,
+		ServiceAccountName: os.Getenv("SERVICE_ACCOUNT_NAME"),
+	}
+
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
 		Scheme: scheme,
 		Metrics: metricsserver.Options{
@@ -96,6 +117,88 @@ func main() {
 		os.Exit(1)
 	}
 
+	ttlSecondsAfterFinished, err := getIntEnv("HOSTOPERATION_TTL_SECONDS_AFTER_FINISHED", -1)
+	if err != nil {
+		log.Logger.Error(err.Error())
+		os.Exit(1)
+	}
+	if err = (&hostoperationcontroller.HostOperationGCReconciler{
+		Client:                         mgr.GetClient(),
+		Scheme:                         mgr.GetScheme(),
+		DefaultTTLSecondsAfterFinished: ttlSecondsAfterFinished,
+	}).SetupWithManager(mgr); err != nil {
+		log.Logger.Errorf("unable to create controller %s: %v", "HostOperationGC", err)
+		os.Exit(1)
+	}
+
+	if err = (&hostoperationsetcontroller.HostOperationSetReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		log.Logger.Errorf("unable to create controller %s: %v", "HostOperationSet", err)
+		os.Exit(1)
+	}
+
+	if err = (&hostoperationschedulecontroller.HostOperationScheduleReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		log.Logger.Errorf("unable to create controller %s: %v", "HostOperationSchedule", err)
+		os.Exit(1)
+	}
+
+	nodeConfig := nodemappingcontroller.NodeConfig{
+		LabelPrefix: getStringEnv("NODE_LABEL_PREFIX", bmcv1beta1.GroupName+"/"),
+		Labels:      getListEnv("NODE_LABELS", nodemappingcontroller.KnownFacts),
+		Taints:      getListEnv("NODE_TAINTS", nodemappingcontroller.KnownTaints),
+		TaintEffect: corev1.TaintEffect(getStringEnv("NODE_TAINT_EFFECT", string(corev1.TaintEffectNoSchedule))),
+	}
+	if err := nodeConfig.Validate(); err != nil {
+		log.Logger.Error(err.Error())
+		os.Exit(1)
+	}
+	if err = (&nodemappingcontroller.NodeMappingReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+		Config: nodeConfig,
+	}).SetupWithManager(mgr); err != nil {
+		log.Logger.Errorf("unable to create controller %s: %v", "NodeMapping", err)
+		os.Exit(1)
+	}
+
+	maxConcurrent, err := getIntEnv("REMEDIATION_MAX_CONCURRENT", 1)
+	if err != nil {
+		log.Logger.Error(err.Error())
+		os.Exit(1)
+	}
+	maxNotReadyPercent, err := getIntEnv("REMEDIATION_MAX_NOT_READY_PERCENT", 50)
+	if err != nil {
+		log.Logger.Error(err.Error())
+		os.Exit(1)
+	}
+	if err = (&remediationcontroller.RemediationReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+		Config: remediationcontroller.Config{
+			MaxConcurrent:      maxConcurrent,
+			MaxNotReadyPercent: maxNotReadyPercent,
+		},
+		Recorder:  mgr.GetEventRecorderFor("bmc-controller"),
+		Namespace: namespace,
+	}).SetupWithManager(mgr); err != nil {
+		log.Logger.Errorf("unable to create controller %s: %v", "Remediation", err)
+		os.Exit(1)
+	}
+
+	if err = (&hostpoolcontroller.HostPoolReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("bmc-controller"),
+	}).SetupWithManager(mgr); err != nil {
+		log.Logger.Errorf("unable to create controller %s: %v", "HostPool", err)
+		os.Exit(1)
+	}
+
 	// Setup webhook
 	if err = (&clusteragentwebhook.ClusterAgentWebhook{}).SetupWebhookWithManager(mgr); err != nil {
 		log.Logger.Errorf("unable to create webhook %s: %v", "ClusterAgent", err)
@@ -109,11 +212,41 @@ func main() {
 	}
 
 	// Setup HostOperation webhook
-	if err = (&hostoperationwebhook.HostOperationWebhook{}).SetupWebhookWithManager(mgr); err != nil {
+	if err = (&hostoperationwebhook.HostOperationWebhook{TrustedUsers: trustedUsers}).SetupWebhookWithManager(mgr); err != nil {
 		log.Logger.Errorf("unable to create webhook %s: %v", "HostOperation", err)
 		os.Exit(1)
 	}
 
+	// Setup HostOperationSet webhook
+	if err = (&hostoperationsetwebhook.HostOperationSetWebhook{TrustedUsers: trustedUsers}).SetupWebhookWithManager(mgr); err != nil {
+		log.Logger.Errorf("unable to create webhook %s: %v", "HostOperationSet", err)
+		os.Exit(1)
+	}
+
+	// Setup HostOperationSchedule webhook
+	if err = (&hostoperationschedulewebhook.HostOperationScheduleWebhook{TrustedUsers: trustedUsers}).SetupWebhookWithManager(mgr); err != nil {
+		log.Logger.Errorf("unable to create webhook %s: %v", "HostOperationSchedule", err)
+		os.Exit(1)
+	}
+
+	// Setup HostPolicy webhook
+	if err = (&hostpolicywebhook.HostPolicyWebhook{}).SetupWebhookWithManager(mgr); err != nil {
+		log.Logger.Errorf("unable to create webhook %s: %v", "HostPolicy", err)
+		os.Exit(1)
+	}
+
+	// Setup HostWorkflow webhook
+	if err = (&hostworkflowwebhook.HostWorkflowWebhook{TrustedUsers: trustedUsers}).SetupWebhookWithManager(mgr); err != nil {
+		log.Logger.Errorf("unable to create webhook %s: %v", "HostWorkflow", err)
+		os.Exit(1)
+	}
+
+	// Setup HostPool webhook
+	if err = (&hostpoolwebhook.HostPoolWebhook{}).SetupWebhookWithManager(mgr); err != nil {
+		log.Logger.Errorf("unable to create webhook %s: %v", "HostPool", err)
+		os.Exit(1)
+	}
+
 	//+kubebuilder:scaffold:builder
 
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
@@ -131,3 +264,40 @@ func main() {
 		os.Exit(1)
 	}
 }
+
+// getIntEnv returns the integer value of the environment variable, or the default value when it is not set
+func getIntEnv(name string, defaultValue int) (int, error) {
+	v := os.Getenv(name)
+	if v == "" {
+		return defaultValue, nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, fmt.Errorf("%s environment variable %s is not a valid integer: %v", name, v, err)
+	}
+	return n, nil
+}
+
+// getStringEnv returns the value of the environment variable, or the default value when it is not set
+func getStringEnv(name string, defaultValue string) string {
+	if v, ok := os.LookupEnv(name); ok {
+		return strings.TrimSpace(v)
+	}
+	return defaultValue
+}
+
+// getListEnv returns the comma-separated items of the environment variable, or the default value when it is not set,
+// an empty value means no item
+func getListEnv(name string, defaultValue []string) []string {
+	v, ok := os.LookupEnv(name)
+	if !ok {
+		return defaultValue
+	}
+	result := []string{}
+	for _, item := range strings.Split(v, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			result = append(result, item)
+		}
+	}
+	return result
+}
@@ -29,8 +29,32 @@ type AgentConfig struct {
 	Password string
 	// 主机状态更新间隔（秒）
 	HostStatusUpdateInterval int
+	// BMC 连续访问失败时，轮询间隔指数退避的上限（秒）
+	HostStatusMaxBackoffInterval int
+	// 主机硬件清单的采集间隔（秒），电源状态和健康状态仍按 HostStatusUpdateInterval 采集
+	HostStatusInventoryInterval int
+	// hoststatus 中保留的硬件变更记录的数量
+	HardwareChangeHistoryLimit int
+	// hoststatus 中保留的 HostOperation 操作记录的数量
+	OperationHistoryLimit int
 	// pod namespace
 	PodNamespace string
+	// BMC 重启后，预期 BMC 离线的时长（秒），期间主机不会被标记为不健康
+	BmcResetGracePeriod int
+	// BMC 异步任务的轮询间隔（秒）
+	TaskPollInterval int
+	// BMC 异步任务的超时时间（秒）
+	TaskTimeout int
+	// On、ForceOn 操作后，等待主机开机的超时时间（秒）
+	PowerOnTimeout int
+	// ForceOff 操作后，等待主机关机的超时时间（秒）
+	ForceOffTimeout int
+	// GracefulShutdown 操作后，等待主机关机的超时时间（秒）
+	GracefulShutdownTimeout int
+	// ForceRestart、GracefulRestart、PxeReboot 操作后，等待主机重启完成的超时时间（秒）
+	RestartTimeout int
+	// BMC 不报告 BootProgress 时，重启操作的观察时长（秒），期间没有观察到主机下电，操作以未验证的结果结束
+	UnverifiedRestartWindow int
 }
 
 // ValidateEndpointConfig validates the endpoint configuration
@@ -162,6 +186,18 @@ func (c *AgentConfig) GetDetailString() string {
 
 	// Add HostStatusUpdateInterval to details
 	details.WriteString(fmt.Sprintf("  HostStatusUpdateInterval: %d seconds\n", c.HostStatusUpdateInterval))
+	details.WriteString(fmt.Sprintf("  HostStatusMaxBackoffInterval: %d seconds\n", c.HostStatusMaxBackoffInterval))
+	details.WriteString(fmt.Sprintf("  HostStatusInventoryInterval: %d seconds\n", c.HostStatusInventoryInterval))
+	details.WriteString(fmt.Sprintf("  HardwareChangeHistoryLimit: %d\n", c.HardwareChangeHistoryLimit))
+	details.WriteString(fmt.Sprintf("  OperationHistoryLimit: %d\n", c.OperationHistoryLimit))
+	details.WriteString(fmt.Sprintf("  BmcResetGracePeriod: %d seconds\n", c.BmcResetGracePeriod))
+	details.WriteString(fmt.Sprintf("  TaskPollInterval: %d seconds\n", c.TaskPollInterval))
+	details.WriteString(fmt.Sprintf("  TaskTimeout: %d seconds\n", c.TaskTimeout))
+	details.WriteString(fmt.Sprintf("  PowerOnTimeout: %d seconds\n", c.PowerOnTimeout))
+	details.WriteString(fmt.Sprintf("  ForceOffTimeout: %d seconds\n", c.ForceOffTimeout))
+	details.WriteString(fmt.Sprintf("  GracefulShutdownTimeout: %d seconds\n", c.GracefulShutdownTimeout))
+	details.WriteString(fmt.Sprintf("  RestartTimeout: %d seconds\n", c.RestartTimeout))
+	details.WriteString(fmt.Sprintf("  UnverifiedRestartWindow: %d seconds\n", c.UnverifiedRestartWindow))
 
 	return details.String()
 }
@@ -170,6 +206,15 @@ func (c *AgentConfig) GetDetailString() string {
 // environment variable:
 // CLUSTERAGENT_NAME: the name of the ClusterAgent
 // HOST_STATUS_UPDATE_INTERVAL: the interval of updating host status, default is 60 seconds
+// HOSTSTATUS_OPERATION_HISTORY_LIMIT: the number of the finished HostOperations kept in the history of each hoststatus, default is 20
+// BMC_RESET_GRACE_PERIOD: the time the BMC is expected to be offline after a BMC reset, default is 600 seconds
+// TASK_POLL_INTERVAL: the interval of polling the asynchronous task of the BMC, default is 10 seconds
+// TASK_TIMEOUT: the timeout of the asynchronous task of the BMC, default is 3600 seconds
+// POWER_ON_TIMEOUT: the time to wait for the host powering on after On and ForceOn, default is 300 seconds
+// FORCE_OFF_TIMEOUT: the time to wait for the host powering off after ForceOff, default is 120 seconds
+// GRACEFUL_SHUTDOWN_TIMEOUT: the time to wait for the host powering off after GracefulShutdown, default is 600 seconds
+// RESTART_TIMEOUT: the time to wait for the host restarting after ForceRestart, GracefulRestart and PxeReboot, default is 900 seconds
+// UNVERIFIED_RESTART_WINDOW: the time to observe the restart on a BMC reporting no boot progress before it finishes unverified, default is 120 seconds
 func LoadAgentConfig(k8sClient *kubernetes.Clientset) (*AgentConfig, error) {
 	// Get agent name from environment
 	agentName := os.Getenv("CLUSTERAGENT_NAME")
@@ -191,6 +236,59 @@ func LoadAgentConfig(k8sClient *kubernetes.Clientset) (*AgentConfig, error) {
 		}
 	}
 
+	maxBackoffInterval, err := getOptionalIntEnv("HOST_STATUS_MAX_BACKOFF_INTERVAL", 1800)
+	if err != nil {
+		return nil, err
+	}
+
+	inventoryInterval, err := getOptionalIntEnv("HOST_STATUS_INVENTORY_INTERVAL", 3600)
+	if err != nil {
+		return nil, err
+	}
+
+	hardwareChangeHistoryLimit, err := getOptionalIntEnv("HARDWARE_CHANGE_HISTORY_LIMIT", 20)
+	if err != nil {
+		return nil, err
+	}
+
+	operationHistoryLimit, err := getOptionalIntEnv("HOSTSTATUS_OPERATION_HISTORY_LIMIT", 20)
+	if err != nil {
+		return nil, err
+	}
+
+	bmcResetGracePeriod, err := getOptionalIntEnv("BMC_RESET_GRACE_PERIOD", 600)
+	if err != nil {
+		return nil, err
+	}
+	taskPollInterval, err := getOptionalIntEnv("TASK_POLL_INTERVAL", 10)
+	if err != nil {
+		return nil, err
+	}
+	if taskPollInterval <= 0 {
+		return nil, fmt.Errorf("TASK_POLL_INTERVAL environment variable must be positive: %d", taskPollInterval)
+	}
+	taskTimeout, err := getOptionalIntEnv("TASK_TIMEOUT", 3600)
+	if err != nil {
+		return nil, err
+	}
+	powerTimeouts := map[string]int{
+		"POWER_ON_TIMEOUT":          300,
+		"FORCE_OFF_TIMEOUT":         120,
+		"GRACEFUL_SHUTDOWN_TIMEOUT": 600,
+		"RESTART_TIMEOUT":           900,
+		"UNVERIFIED_RESTART_WINDOW": 120,
+	}
+	for name, defaultValue := range powerTimeouts {
+		n, err := getOptionalIntEnv(name, defaultValue)
+		if err != nil {
+			return nil, err
+		}
+		if n <= 0 {
+			return nil, fmt.Errorf("%s environment variable must be positive: %d", name, n)
+		}
+		powerTimeouts[name] = n
+	}
+
 	// Create bmc client config
 	restConfig, err := rest.InClusterConfig()
 	if err != nil {
@@ -226,10 +324,22 @@ func LoadAgentConfig(k8sClient *kubernetes.Clientset) (*AgentConfig, error) {
 
 	// Create agent config
 	agentConfig := &AgentConfig{
-		ClusterAgentName:         agentName,
-		AgentObjSpec:             clusterAgent.Spec,
-		HostStatusUpdateInterval: updateInterval,
-		PodNamespace:             ns,
+		ClusterAgentName:             agentName,
+		AgentObjSpec:                 clusterAgent.Spec,
+		HostStatusUpdateInterval:     updateInterval,
+		HostStatusMaxBackoffInterval: maxBackoffInterval,
+		HostStatusInventoryInterval:  inventoryInterval,
+		HardwareChangeHistoryLimit:   hardwareChangeHistoryLimit,
+		OperationHistoryLimit:        operationHistoryLimit,
+		PodNamespace:                 ns,
+		BmcResetGracePeriod:          bmcResetGracePeriod,
+		TaskPollInterval:             taskPollInterval,
+		TaskTimeout:                  taskTimeout,
+		PowerOnTimeout:               powerTimeouts["POWER_ON_TIMEOUT"],
+		ForceOffTimeout:              powerTimeouts["FORCE_OFF_TIMEOUT"],
+		GracefulShutdownTimeout:      powerTimeouts["GRACEFUL_SHUTDOWN_TIMEOUT"],
+		RestartTimeout:               powerTimeouts["RESTART_TIMEOUT"],
+		UnverifiedRestartWindow:      powerTimeouts["UNVERIFIED_RESTART_WINDOW"],
 	}
 
 	// Validate endpoint configuration
@@ -245,3 +355,16 @@ func LoadAgentConfig(k8sClient *kubernetes.Clientset) (*AgentConfig, error) {
 	log.Logger.Debugf("Agent configuration loaded successfully: %+v", agentConfig)
 	return agentConfig, nil
 }
+
+// getOptionalIntEnv returns the integer value of the environment variable, or the default value when it is not set
+func getOptionalIntEnv(name string, defaultValue int) (int, error) {
+	v := os.Getenv(name)
+	if v == "" {
+		return defaultValue, nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, fmt.Errorf("%s environment variable %s is not a valid integer: %v", name, v, err)
+	}
+	return n, nil
+}
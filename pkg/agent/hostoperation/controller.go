@@ -3,18 +3,23 @@ package hostoperation
 import (
 	"context"
 	"fmt"
+	"reflect"
+	"strings"
 	"time"
 
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 
 	"github.com/spidernet-io/bmc/pkg/agent/config"
 	"github.com/spidernet-io/bmc/pkg/agent/hoststatus/data"
 	bmcv1beta1 "github.com/spidernet-io/bmc/pkg/k8s/apis/bmc.spidernet.io/v1beta1"
 	"github.com/spidernet-io/bmc/pkg/log"
 	"github.com/spidernet-io/bmc/pkg/redfish"
+	gofishredfish "github.com/stmcginnis/gofish/redfish"
 	"go.uber.org/zap"
 )
 
@@ -23,6 +28,9 @@ type HostOperationController struct {
 	client.Client
 	Scheme      *runtime.Scheme
 	agentConfig *config.AgentConfig
+	recorder    record.EventRecorder
+	// the nodes and pods are read from the API server, instead of caching them of the whole cluster
+	apiReader client.Reader
 }
 
 func NewHostOperationController(mgr ctrl.Manager, agentConfig *config.AgentConfig) (*HostOperationController, error) {
@@ -30,6 +38,8 @@ func NewHostOperationController(mgr ctrl.Manager, agentConfig *config.AgentConfi
 		Client:      mgr.GetClient(),
 		Scheme:      mgr.GetScheme(),
 		agentConfig: agentConfig,
+		recorder:    mgr.GetEventRecorderFor("bmc-agent"),
+		apiReader:   mgr.GetAPIReader(),
 	}, nil
 }
 
@@ -50,6 +60,30 @@ func (r *HostOperationController) Reconcile(ctx context.Context, req ctrl.Reques
 		return ctrl.Result{}, err
 	}
 
+	// 处理删除
+	if hostOp.DeletionTimestamp != nil {
+		return r.processDeletion(ctx, hostOp, logger)
+	}
+
+	previous := hostOp.Status.Status
+	result, err := r.process(ctx, hostOp, logger)
+	if err == nil && hostOp.Status.Status != previous {
+		r.recordTransition(hostOp)
+	}
+	if err == nil && !isActive(hostOp) && hostOp.Status.ClusterAgent == r.agentConfig.ClusterAgentName {
+		if err := r.afterAction(ctx, hostOp, logger); err != nil {
+			logger.Errorf("%v", err)
+			return ctrl.Result{}, err
+		}
+		if waitingForNode(hostOp) && result.RequeueAfter == 0 {
+			result.RequeueAfter = drainPollInterval
+		}
+	}
+	return result, err
+}
+
+// process executes the operation and tracks its result
+func (r *HostOperationController) process(ctx context.Context, hostOp *bmcv1beta1.HostOperation, logger *zap.SugaredLogger) (ctrl.Result, error) {
 	// 获取关联的 HostStatus
 	hostStatus := &bmcv1beta1.HostStatus{}
 	if err := r.Get(ctx, client.ObjectKey{Name: hostOp.Spec.HostStatusName}, hostStatus); err != nil {
@@ -63,16 +97,70 @@ func (r *HostOperationController) Reconcile(ctx context.Context, req ctrl.Reques
 		return ctrl.Result{}, nil
 	}
 
+	// 取消或者超时
+	if isActive(hostOp) {
+		if hostOp.Spec.Cancel {
+			return r.terminate(ctx, hostOp, bmcv1beta1.HostOperationStatusCancelled, "cancelled by spec.cancel", logger)
+		}
+		if timedOut(hostOp) {
+			return r.terminate(ctx, hostOp, bmcv1beta1.HostOperationStatusFailed,
+				fmt.Sprintf("the operation timed out after %d seconds", *hostOp.Spec.TimeoutSeconds), logger)
+		}
+	}
+
 	// 检查状态是否为空
 	if hostOp.Status.Status == "" || hostOp.Status.Status == bmcv1beta1.HostOperationStatusPending {
+		if wait := retryWait(hostOp); wait > 0 {
+			return ctrl.Result{RequeueAfter: wait}, nil
+		}
+		if hostOp.Status.StartTime == "" {
+			if wait, result, err := r.waitInQueue(ctx, hostOp, logger); wait {
+				return result, err
+			}
+		}
 		logger.Infof("Processing HostOperation %s : %+v", hostOp.Name, hostOp.Spec)
 
+		// the policies are checked again before every attempt, the operation may start outside the maintenance window
+		if reasons, err := r.checkPolicies(ctx, hostOp, hostStatus, time.Now()); err != nil {
+			logger.Errorf("%v", err)
+			return ctrl.Result{}, err
+		} else if len(reasons) > 0 {
+			return r.terminate(ctx, hostOp, bmcv1beta1.HostOperationStatusFailed,
+				fmt.Sprintf("action %s is denied when it starts: %s", hostOp.Spec.Action, strings.Join(reasons, "; ")), logger)
+		}
+
+		if err := r.addFinalizer(ctx, hostOp); err != nil {
+			logger.Errorf("%v", err)
+			return ctrl.Result{}, err
+		}
+
 		// 更新状态
 		hostOp.Status.Status = bmcv1beta1.HostOperationStatusPending
 		hostOp.Status.LastUpdateTime = time.Now().UTC().Format(time.RFC3339)
+		if hostOp.Status.StartTime == "" {
+			hostOp.Status.StartTime = hostOp.Status.LastUpdateTime
+		}
 		hostOp.Status.ClusterAgent = r.agentConfig.ClusterAgentName
 		hostOp.Status.IpAddr = hostStatus.Status.Basic.IpAddr
 
+		// drain the node of the host before the action
+		if needsDrain(hostOp) {
+			ready, err := r.drainNode(ctx, hostOp, hostStatus, logger)
+			if err != nil {
+				logger.Errorf("Failed to drain the node of %s: %v", hostOp.Spec.HostStatusName, err)
+				hostOp.Status.Status = bmcv1beta1.HostOperationStatusFailed
+				hostOp.Status.Message = err.Error()
+				return ctrl.Result{}, r.updateStatus(ctx, hostOp, logger)
+			}
+			if !ready {
+				hostOp.Status.Message = drainWaitMessage(hostOp, hostStatus)
+				if err := r.updateStatus(ctx, hostOp, logger); err != nil {
+					return ctrl.Result{}, err
+				}
+				return ctrl.Result{RequeueAfter: drainPollInterval}, nil
+			}
+		}
+
 		// 调用 redfish 接口 完成操作
 		// get connect config from cache
 		d := data.HostCacheDatabase.Get(hostOp.Spec.HostStatusName)
@@ -84,6 +172,7 @@ func (r *HostOperationController) Reconcile(ctx context.Context, req ctrl.Reques
 		logger.Debugf("get connect config %s from cache: %+v", hostOp.Spec.HostStatusName, d)
 
 		var err error
+		taskMonitor := ""
 		c, terr := redfish.NewClient(*d, logger)
 		if terr != nil {
 			err = terr
@@ -91,43 +180,131 @@ func (r *HostOperationController) Reconcile(ctx context.Context, req ctrl.Reques
 			hostOp.Status.Status = bmcv1beta1.HostOperationStatusFailed
 			hostOp.Status.Message = err.Error()
 		} else {
+			if _, _, ok := powerTarget(hostOp.Spec.Action); ok {
+				// record the power state before the action to verify the result
+				initial, err := c.GetPowerState()
+				if err != nil {
+					logger.Warnf("Failed to get power state of %s before the action: %v", hostOp.Spec.HostStatusName, err)
+				}
+				hostOp.Status.PowerVerification = newPowerVerification(hostOp.Spec.Action, initial, r.powerVerifyTimeout(hostOp.Spec.Action),
+					time.Duration(r.agentConfig.UnverifiedRestartWindow)*time.Second)
+			}
+
 			switch hostOp.Spec.Action {
 			case bmcv1beta1.BootCmdOn:
-				err = c.Power(hostOp.Spec.Action)
+				taskMonitor, err = c.Power(hostOp.Spec.Action)
 			case bmcv1beta1.BootCmdForceOn:
-				err = c.Power(hostOp.Spec.Action)
+				taskMonitor, err = c.Power(hostOp.Spec.Action)
 			case bmcv1beta1.BootCmdForceOff:
-				err = c.Power(hostOp.Spec.Action)
+				taskMonitor, err = c.Power(hostOp.Spec.Action)
 			case bmcv1beta1.BootCmdGracefulShutdown:
-				err = c.Power(hostOp.Spec.Action)
+				taskMonitor, err = c.Power(hostOp.Spec.Action)
 			case bmcv1beta1.BootCmdForceRestart:
-				err = c.Power(hostOp.Spec.Action)
+				taskMonitor, err = c.Power(hostOp.Spec.Action)
 			case bmcv1beta1.BootCmdGracefulRestart:
-				err = c.Power(hostOp.Spec.Action)
+				taskMonitor, err = c.Power(hostOp.Spec.Action)
 			case bmcv1beta1.BootCmdResetPxeOnce:
-				err = c.Power(hostOp.Spec.Action)
+				taskMonitor, err = c.Power(hostOp.Spec.Action)
+			case bmcv1beta1.ActionSetPowerLimit:
+				if hostOp.Spec.PowerLimit == nil {
+					err = fmt.Errorf("spec.powerLimit is required for action %s", hostOp.Spec.Action)
+				} else {
+					limit := hostOp.Spec.PowerLimit.LimitInWatts
+					err = c.SetPowerLimit(&limit, hostOp.Spec.PowerLimit.LimitException)
+				}
+			case bmcv1beta1.ActionClearPowerLimit:
+				err = c.SetPowerLimit(nil, "")
+			case bmcv1beta1.ActionBmcGracefulRestart, bmcv1beta1.ActionBmcForceRestart:
+				err = c.ResetBmc(hostOp.Spec.Action)
+			case bmcv1beta1.ActionBmcResetToDefaults:
+				err = c.ResetBmcToDefaults(hostOp.Spec.ResetToDefaultsType)
+			case bmcv1beta1.ActionLocateOn, bmcv1beta1.ActionLocateBlink, bmcv1beta1.ActionLocateOff:
+				err = c.SetLocateIndicator(hostOp.Spec.Action)
 			default:
 				err = fmt.Errorf("invalid action %s", hostOp.Spec.Action)
 			}
 		}
 
 		hostOp.Status.LastUpdateTime = time.Now().UTC().Format(time.RFC3339)
+		hostOp.Status.NextRetryTime = ""
 		if err != nil {
+			hostOp.Status.Attempts++
+		}
+		if backoff, ok := retryBackoff(hostOp, err); ok {
+			next := time.Now().Add(backoff).UTC().Format(time.RFC3339)
+			logger.Warnf("attempt %d of %s failed with a transient error, retry at %s: %v", hostOp.Status.Attempts, hostOp.Spec.HostStatusName, next, err)
+			hostOp.Status.Status = bmcv1beta1.HostOperationStatusPending
+			hostOp.Status.Message = fmt.Sprintf("attempt %d failed: %v, retry at %s", hostOp.Status.Attempts, err, next)
+			hostOp.Status.NextRetryTime = next
+			hostOp.Status.PowerVerification = nil
+			if err := r.updateStatus(ctx, hostOp, logger); err != nil {
+				return ctrl.Result{}, err
+			}
+			return ctrl.Result{RequeueAfter: backoff}, nil
+		} else if err != nil {
 			logger.Errorf("Failed to operate %s: %v", hostOp.Spec.HostStatusName, err)
 			hostOp.Status.Status = bmcv1beta1.HostOperationStatusFailed
 			hostOp.Status.Message = err.Error()
+			hostOp.Status.PowerVerification = nil
+		} else if taskMonitor != "" {
+			logger.Infof("the BMC of %s accepted the action, tracking task %s", hostOp.Spec.HostStatusName, taskMonitor)
+			hostOp.Status.Status = bmcv1beta1.HostOperationStatusRunning
+			hostOp.Status.Message = fmt.Sprintf("waiting for task %s", taskMonitor)
+			hostOp.Status.Task = &bmcv1beta1.TaskStatus{
+				MonitorURI: taskMonitor,
+				TaskState:  string(gofishredfish.NewTaskState),
+				StartTime:  hostOp.Status.LastUpdateTime,
+			}
+		} else if hostOp.Status.PowerVerification != nil {
+			logger.Infof("Succeeded to operate %s, verifying the power state", hostOp.Spec.HostStatusName)
+			startPowerVerification(hostOp)
 		} else {
 			logger.Infof("Succeeded to operate %s", hostOp.Spec.HostStatusName)
 			hostOp.Status.Status = bmcv1beta1.HostOperationStatusSuccess
+			if isBmcResetAction(hostOp.Spec.Action) {
+				if err := r.markBmcResetting(ctx, hostOp.Spec.HostStatusName); err != nil {
+					logger.Errorf("Failed to mark the BMC of HostStatus %s as resetting: %v", hostOp.Spec.HostStatusName, err)
+				}
+			}
+			if isTimedLocateAction(hostOp) {
+				offTime := time.Now().Add(time.Duration(*hostOp.Spec.LocateDurationMinutes) * time.Minute).UTC()
+				hostOp.Status.LocateOffTime = offTime.Format(time.RFC3339)
+				hostOp.Status.Message = fmt.Sprintf("the indicator will be turned off at %s", hostOp.Status.LocateOffTime)
+			}
 		}
 
 		// 更新
-		if err := r.Status().Update(ctx, hostOp); err != nil {
+		if err := r.updateStatus(ctx, hostOp, logger); err != nil {
 			logger.Errorf("Action has been done, but failed to update HostOperation status: %v", err)
-			return ctrl.Result{}, fmt.Errorf("failed to update HostOperation status: %v", err)
+			return ctrl.Result{}, err
 		}
 		logger.Debugf("Successfully updated HostOperation %s status", hostOp.Name)
 
+		if hostOp.Status.Status == bmcv1beta1.HostOperationStatusRunning {
+			if hostOp.Status.Task == nil {
+				return ctrl.Result{RequeueAfter: powerVerifyInterval}, nil
+			}
+			return ctrl.Result{RequeueAfter: time.Duration(r.agentConfig.TaskPollInterval) * time.Second}, nil
+		}
+		if hostOp.Status.LocateOffTime != "" {
+			return ctrl.Result{RequeueAfter: time.Duration(*hostOp.Spec.LocateDurationMinutes) * time.Minute}, nil
+		}
+
+	} else if hostOp.Status.Status == bmcv1beta1.HostOperationStatusRunning {
+		if hostOp.Status.Task != nil {
+			if finished, _ := redfish.TaskResult(hostOp.Status.Task); !finished {
+				return r.processTask(ctx, hostOp, logger)
+			}
+		}
+		if hostOp.Status.PowerVerification != nil {
+			return r.processPowerVerification(ctx, hostOp, logger)
+		}
+		return r.processTask(ctx, hostOp, logger)
+	} else if hostOp.Status.Status == bmcv1beta1.HostOperationStatusSuccess && hostOp.Status.LocateOffTime != "" {
+		return r.processLocateOff(ctx, hostOp, logger)
+	} else if hostOp.Status.Status == bmcv1beta1.HostOperationStatusSuccess && hostOp.Status.Drain != nil &&
+		hostOp.Status.Drain.Phase == bmcv1beta1.DrainPhaseWaitingForReady {
+		return r.processNodeReady(ctx, hostOp, logger)
 	} else {
 		logger.Infof("HostOperation %s has been processed", hostOp.Name)
 		return ctrl.Result{}, nil
@@ -136,9 +313,168 @@ func (r *HostOperationController) Reconcile(ctx context.Context, req ctrl.Reques
 	return ctrl.Result{}, nil
 }
 
+func isBmcResetAction(action string) bool {
+	return action == bmcv1beta1.ActionBmcGracefulRestart ||
+		action == bmcv1beta1.ActionBmcForceRestart ||
+		action == bmcv1beta1.ActionBmcResetToDefaults
+}
+
+// markBmcResetting records the time window during which the BMC is expected to be offline,
+// so that the hostStatus is not marked as unhealthy while the BMC restarts
+func (r *HostOperationController) markBmcResetting(ctx context.Context, name string) error {
+	until := time.Now().Add(time.Duration(r.agentConfig.BmcResetGracePeriod) * time.Second).UTC().Format(time.RFC3339)
+	for i := 0; ; i++ {
+		hostStatus := &bmcv1beta1.HostStatus{}
+		if err := r.Get(ctx, client.ObjectKey{Name: name}, hostStatus); err != nil {
+			return err
+		}
+		hostStatus.Status.BmcResettingUntil = until
+		err := r.Status().Update(ctx, hostStatus)
+		if err == nil || !errors.IsConflict(err) || i >= 2 {
+			return err
+		}
+	}
+}
+
+// processTask polls the task of the BMC until it finishes or times out
+func (r *HostOperationController) processTask(ctx context.Context, hostOp *bmcv1beta1.HostOperation, logger *zap.SugaredLogger) (ctrl.Result, error) {
+	pollInterval := time.Duration(r.agentConfig.TaskPollInterval) * time.Second
+	if hostOp.Status.Task == nil || hostOp.Status.Task.MonitorURI == "" {
+		hostOp.Status.Status = bmcv1beta1.HostOperationStatusFailed
+		hostOp.Status.Message = "no task to track"
+		hostOp.Status.LastUpdateTime = time.Now().UTC().Format(time.RFC3339)
+		return ctrl.Result{}, r.updateStatus(ctx, hostOp, logger)
+	}
+	old := hostOp.Status.Task.DeepCopy()
+
+	if start, err := time.Parse(time.RFC3339, old.StartTime); err == nil && r.agentConfig.TaskTimeout > 0 &&
+		time.Since(start) > time.Duration(r.agentConfig.TaskTimeout)*time.Second {
+		logger.Errorf("task %s of %s timed out after %d seconds", old.MonitorURI, hostOp.Spec.HostStatusName, r.agentConfig.TaskTimeout)
+		hostOp.Status.Status = bmcv1beta1.HostOperationStatusFailed
+		hostOp.Status.Message = fmt.Sprintf("task %s timed out after %d seconds", old.MonitorURI, r.agentConfig.TaskTimeout)
+		hostOp.Status.LastUpdateTime = time.Now().UTC().Format(time.RFC3339)
+		return ctrl.Result{}, r.updateStatus(ctx, hostOp, logger)
+	}
+
+	d := data.HostCacheDatabase.Get(hostOp.Spec.HostStatusName)
+	if d == nil {
+		logger.Warnf("Failed to get connect config %s from cache, retry later", hostOp.Spec.HostStatusName)
+		return ctrl.Result{RequeueAfter: pollInterval}, nil
+	}
+	c, err := redfish.NewClient(*d, logger)
+	if err != nil {
+		logger.Errorf("Failed to connect %s, retry later: %v", hostOp.Spec.HostStatusName, err)
+		return ctrl.Result{RequeueAfter: pollInterval}, nil
+	}
+	task, err := c.GetTask(old.MonitorURI)
+	if err != nil {
+		logger.Errorf("Failed to get task %s of %s, retry later: %v", old.MonitorURI, hostOp.Spec.HostStatusName, err)
+		return ctrl.Result{RequeueAfter: pollInterval}, nil
+	}
+	task.StartTime = old.StartTime
+
+	finished, succeeded := redfish.TaskResult(task)
+	if !finished && reflect.DeepEqual(old, task) {
+		return ctrl.Result{RequeueAfter: pollInterval}, nil
+	}
+
+	hostOp.Status.Task = task
+	hostOp.Status.LastUpdateTime = time.Now().UTC().Format(time.RFC3339)
+	switch {
+	case !finished:
+		hostOp.Status.Message = fmt.Sprintf("task %s is %s, %d%% complete", task.MonitorURI, task.TaskState, task.PercentComplete)
+	case succeeded && hostOp.Status.PowerVerification != nil:
+		logger.Infof("task %s of %s completed, verifying the power state", task.MonitorURI, hostOp.Spec.HostStatusName)
+		startPowerVerification(hostOp)
+	case succeeded:
+		logger.Infof("task %s of %s completed", task.MonitorURI, hostOp.Spec.HostStatusName)
+		hostOp.Status.Status = bmcv1beta1.HostOperationStatusSuccess
+		hostOp.Status.Message = fmt.Sprintf("task %s completed", task.MonitorURI)
+	default:
+		logger.Errorf("task %s of %s failed: %s %s %v", task.MonitorURI, hostOp.Spec.HostStatusName, task.TaskState, task.TaskStatus, task.Messages)
+		hostOp.Status.Status = bmcv1beta1.HostOperationStatusFailed
+		hostOp.Status.Message = fmt.Sprintf("task %s finished with state %s and status %s", task.MonitorURI, task.TaskState, task.TaskStatus)
+	}
+	if err := r.updateStatus(ctx, hostOp, logger); err != nil {
+		return ctrl.Result{}, err
+	}
+	if !finished {
+		return ctrl.Result{RequeueAfter: pollInterval}, nil
+	}
+	if hostOp.Status.Status == bmcv1beta1.HostOperationStatusRunning {
+		return ctrl.Result{RequeueAfter: powerVerifyInterval}, nil
+	}
+	return ctrl.Result{}, nil
+}
+
+// updateStatus updates the status, and removes the finalizer once the operation finishes
+func (r *HostOperationController) updateStatus(ctx context.Context, hostOp *bmcv1beta1.HostOperation, logger *zap.SugaredLogger) error {
+	// the history is recorded before the status, the reconcile stops once the status is finished
+	if !isActive(hostOp) {
+		if err := r.recordHistory(ctx, hostOp); err != nil {
+			logger.Errorf("failed to record HostOperation %s in the history of HostStatus %s: %v", hostOp.Name, hostOp.Spec.HostStatusName, err)
+			return err
+		}
+	}
+	if err := r.Status().Update(ctx, hostOp); err != nil {
+		logger.Errorf("failed to update HostOperation status: %v", err)
+		return fmt.Errorf("failed to update HostOperation status: %v", err)
+	}
+	if isFinished(hostOp) {
+		if err := r.removeFinalizer(ctx, hostOp); err != nil {
+			logger.Errorf("%v", err)
+			return err
+		}
+	}
+	return nil
+}
+
+func isTimedLocateAction(hostOp *bmcv1beta1.HostOperation) bool {
+	return (hostOp.Spec.Action == bmcv1beta1.ActionLocateOn || hostOp.Spec.Action == bmcv1beta1.ActionLocateBlink) &&
+		hostOp.Spec.LocateDurationMinutes != nil && *hostOp.Spec.LocateDurationMinutes > 0
+}
+
+// processLocateOff turns off the indicator when the duration of a timed LocateOn or LocateBlink action expires
+func (r *HostOperationController) processLocateOff(ctx context.Context, hostOp *bmcv1beta1.HostOperation, logger *zap.SugaredLogger) (ctrl.Result, error) {
+	offTime, err := time.Parse(time.RFC3339, hostOp.Status.LocateOffTime)
+	if err != nil {
+		logger.Errorf("invalid locateOffTime %s of HostOperation %s: %v", hostOp.Status.LocateOffTime, hostOp.Name, err)
+		offTime = time.Now()
+	}
+	if wait := time.Until(offTime); wait > 0 {
+		logger.Debugf("the indicator of HostOperation %s will be turned off after %v", hostOp.Name, wait)
+		return ctrl.Result{RequeueAfter: wait}, nil
+	}
+
+	d := data.HostCacheDatabase.Get(hostOp.Spec.HostStatusName)
+	if d == nil {
+		logger.Warnf("Failed to get connect config %s from cache, retry later", hostOp.Spec.HostStatusName)
+		return ctrl.Result{RequeueAfter: 2 * time.Second}, nil
+	}
+	c, err := redfish.NewClient(*d, logger)
+	if err == nil {
+		err = c.SetLocateIndicator(bmcv1beta1.ActionLocateOff)
+	}
+	if err != nil {
+		logger.Errorf("Failed to turn off the indicator of %s, retry later: %v", hostOp.Spec.HostStatusName, err)
+		return ctrl.Result{RequeueAfter: 30 * time.Second}, nil
+	}
+
+	logger.Infof("turned off the indicator of %s after %d minutes", hostOp.Spec.HostStatusName, *hostOp.Spec.LocateDurationMinutes)
+	hostOp.Status.LastUpdateTime = time.Now().UTC().Format(time.RFC3339)
+	hostOp.Status.Message = fmt.Sprintf("the indicator was turned off at %s", hostOp.Status.LastUpdateTime)
+	hostOp.Status.LocateOffTime = ""
+	return ctrl.Result{}, r.updateStatus(ctx, hostOp, logger)
+}
+
 // SetupWithManager sets up the controller with the Manager
 func (r *HostOperationController) SetupWithManager(mgr ctrl.Manager) error {
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &bmcv1beta1.HostOperation{},
+		bmcv1beta1.HostOperationHostStatusNameIndex, indexHostStatusName); err != nil {
+		return fmt.Errorf("failed to index HostOperation: %v", err)
+	}
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&bmcv1beta1.HostOperation{}).
+		Watches(&bmcv1beta1.HostOperation{}, handler.EnqueueRequestsFromMapFunc(r.enqueueQueued)).
 		Complete(r)
 }
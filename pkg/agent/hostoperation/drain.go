@@ -0,0 +1,280 @@
+package hostoperation
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	bmcv1beta1 "github.com/spidernet-io/bmc/pkg/k8s/apis/bmc.spidernet.io/v1beta1"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// the interval of evicting the pods again and checking the node
+	drainPollInterval = 10 * time.Second
+	// the maximum number of the pending pods listed in the status
+	maxListedPods = 10
+	// the annotation of the static pods, which could not be evicted
+	mirrorPodAnnotation = "kubernetes.io/config.mirror"
+)
+
+// needsDrain returns whether the node of the host should be drained before the action
+func needsDrain(hostOp *bmcv1beta1.HostOperation) bool {
+	if hostOp.Spec.DrainPolicy == nil {
+		return false
+	}
+	switch hostOp.Spec.Action {
+	case bmcv1beta1.BootCmdGracefulShutdown, bmcv1beta1.BootCmdForceRestart, bmcv1beta1.BootCmdResetPxeOnce:
+		return true
+	}
+	return false
+}
+
+// isDrained returns whether the node has been cordoned by the operation and not uncordoned yet
+func isDrained(hostOp *bmcv1beta1.HostOperation) bool {
+	d := hostOp.Status.Drain
+	return d != nil && (d.Phase == bmcv1beta1.DrainPhaseDraining || d.Phase == bmcv1beta1.DrainPhaseDrained ||
+		d.Phase == bmcv1beta1.DrainPhaseWaitingForReady)
+}
+
+// waitingForNode returns whether the operation waits for the node to rejoin after the action
+func waitingForNode(hostOp *bmcv1beta1.HostOperation) bool {
+	d := hostOp.Status.Drain
+	if d == nil {
+		return false
+	}
+	if d.Phase == bmcv1beta1.DrainPhaseWaitingForReady {
+		return true
+	}
+	// the action succeeds just now, the phase is moved to WaitingForReady after the status is updated
+	return d.Phase == bmcv1beta1.DrainPhaseDrained && hostOp.Status.Status == bmcv1beta1.HostOperationStatusSuccess &&
+		hostOp.Spec.Action != bmcv1beta1.BootCmdGracefulShutdown
+}
+
+// evictable returns whether the pod should be evicted, the pods of DaemonSets, the static pods and the finished pods are left
+func evictable(pod *corev1.Pod) bool {
+	if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+		return false
+	}
+	if _, ok := pod.Annotations[mirrorPodAnnotation]; ok {
+		return false
+	}
+	if owner := metav1.GetControllerOf(pod); owner != nil && owner.Kind == "DaemonSet" {
+		return false
+	}
+	return true
+}
+
+// nodeReady returns whether the Ready condition of the node is true
+func nodeReady(node *corev1.Node) bool {
+	for _, c := range node.Status.Conditions {
+		if c.Type == corev1.NodeReady {
+			return c.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// drainWaitMessage returns why the action waits for the drain, the drain status is not recorded
+// until the node is cordoned, which fails when the API server is not available
+func drainWaitMessage(hostOp *bmcv1beta1.HostOperation, hostStatus *bmcv1beta1.HostStatus) string {
+	if hostOp.Status.Drain == nil {
+		return fmt.Sprintf("failed to cordon node %s, retry later", hostStatus.Status.NodeName)
+	}
+	return hostOp.Status.Drain.Message
+}
+
+// setUnschedulable cordons or uncordons the node
+func (r *HostOperationController) setUnschedulable(ctx context.Context, node *corev1.Node, unschedulable bool) error {
+	if node.Spec.Unschedulable == unschedulable {
+		return nil
+	}
+	patch := client.MergeFrom(node.DeepCopy())
+	node.Spec.Unschedulable = unschedulable
+	return r.Patch(ctx, node, patch)
+}
+
+// drainNode cordons the node of the host and evicts its pods, it returns whether the action could be performed.
+// an error is returned when the pods are not evicted in time and the action should not be forced,
+// the failed requests to the API server are retried until the timeout
+func (r *HostOperationController) drainNode(ctx context.Context, hostOp *bmcv1beta1.HostOperation, hostStatus *bmcv1beta1.HostStatus, logger *zap.SugaredLogger) (bool, error) {
+	policy := hostOp.Spec.DrainPolicy
+	now := time.Now().UTC()
+	if hostOp.Status.Drain == nil {
+		if hostStatus.Status.NodeName == "" {
+			hostOp.Status.Drain = &bmcv1beta1.DrainStatus{
+				Phase:   bmcv1beta1.DrainPhaseSkipped,
+				Message: "the host is not mapped to a node",
+			}
+			return true, nil
+		}
+		node := &corev1.Node{}
+		if err := r.apiReader.Get(ctx, client.ObjectKey{Name: hostStatus.Status.NodeName}, node); err != nil {
+			if errors.IsNotFound(err) {
+				hostOp.Status.Drain = &bmcv1beta1.DrainStatus{
+					Phase:   bmcv1beta1.DrainPhaseSkipped,
+					Message: fmt.Sprintf("node %s is not found", hostStatus.Status.NodeName),
+				}
+				return true, nil
+			}
+			logger.Errorf("failed to get node %s, retry later: %v", hostStatus.Status.NodeName, err)
+			return false, nil
+		}
+		drain := &bmcv1beta1.DrainStatus{
+			Phase:            bmcv1beta1.DrainPhaseDraining,
+			NodeName:         node.Name,
+			StartTime:        now.Format(time.RFC3339),
+			BootID:           node.Status.NodeInfo.BootID,
+			WasUnschedulable: node.Spec.Unschedulable,
+		}
+		if err := r.setUnschedulable(ctx, node, true); err != nil {
+			logger.Errorf("failed to cordon node %s, retry later: %v", node.Name, err)
+			return false, nil
+		}
+		logger.Infof("cordoned node %s of %s before action %s", node.Name, hostOp.Spec.HostStatusName, hostOp.Spec.Action)
+		hostOp.Status.Drain = drain
+	}
+	drain := hostOp.Status.Drain
+	if drain.Phase != bmcv1beta1.DrainPhaseDraining {
+		return true, nil
+	}
+
+	podList := &corev1.PodList{}
+	if err := r.apiReader.List(ctx, podList, client.MatchingFieldsSelector{
+		Selector: fields.OneTermEqualSelector("spec.nodeName", drain.NodeName),
+	}); err != nil {
+		logger.Errorf("failed to list the pods of node %s, retry later: %v", drain.NodeName, err)
+		return false, nil
+	}
+	pending := []string{}
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		if !evictable(pod) {
+			continue
+		}
+		pending = append(pending, pod.Namespace+"/"+pod.Name)
+		if pod.DeletionTimestamp != nil {
+			continue
+		}
+		eviction := &policyv1.Eviction{ObjectMeta: metav1.ObjectMeta{Name: pod.Name, Namespace: pod.Namespace}}
+		if err := r.SubResource("eviction").Create(ctx, pod, eviction); err != nil && !errors.IsNotFound(err) {
+			// TooManyRequests means the eviction is refused by a PodDisruptionBudget for now
+			if !errors.IsTooManyRequests(err) {
+				logger.Warnf("failed to evict pod %s/%s: %v", pod.Namespace, pod.Name, err)
+			}
+		}
+	}
+
+	drain.PendingPods = int32(len(pending))
+	if len(pending) > maxListedPods {
+		pending = pending[:maxListedPods]
+	}
+	drain.Pods = pending
+	if drain.PendingPods == 0 {
+		logger.Infof("drained node %s of %s", drain.NodeName, hostOp.Spec.HostStatusName)
+		drain.Phase = bmcv1beta1.DrainPhaseDrained
+		drain.Message = "all pods are evicted"
+		return true, nil
+	}
+
+	timeout := time.Duration(policy.TimeoutSeconds) * time.Second
+	if timeout <= 0 {
+		timeout = 600 * time.Second
+	}
+	if start, err := time.Parse(time.RFC3339, drain.StartTime); err == nil && now.Sub(start) > timeout {
+		if !policy.Force {
+			return false, fmt.Errorf("%d pods of node %s are not evicted in %v", drain.PendingPods, drain.NodeName, timeout)
+		}
+		logger.Warnf("%d pods of node %s are not evicted in %v, force the action", drain.PendingPods, drain.NodeName, timeout)
+		drain.Phase = bmcv1beta1.DrainPhaseDrained
+		drain.Message = fmt.Sprintf("%d pods are not evicted in %v, the action is forced", drain.PendingPods, timeout)
+		return true, nil
+	}
+	drain.Message = fmt.Sprintf("waiting for %d pods to be evicted", drain.PendingPods)
+	return false, nil
+}
+
+// afterAction decides what to do with the drained node once the operation finishes, it is idempotent
+// the node is uncordoned at once when the action fails, or waited for when the host restarts
+func (r *HostOperationController) afterAction(ctx context.Context, hostOp *bmcv1beta1.HostOperation, logger *zap.SugaredLogger) error {
+	drain := hostOp.Status.Drain
+	if drain == nil || (drain.Phase != bmcv1beta1.DrainPhaseDraining && drain.Phase != bmcv1beta1.DrainPhaseDrained) {
+		return nil
+	}
+	if hostOp.Status.Status == bmcv1beta1.HostOperationStatusSuccess {
+		if hostOp.Spec.Action == bmcv1beta1.BootCmdGracefulShutdown {
+			// the node is left cordoned as the host is off
+			msg := "the host is shut down, the node is left cordoned"
+			if drain.Message == msg {
+				return nil
+			}
+			drain.Message = msg
+			return r.updateStatus(ctx, hostOp, logger)
+		}
+		drain.Phase = bmcv1beta1.DrainPhaseWaitingForReady
+		drain.ActionTime = time.Now().UTC().Format(time.RFC3339)
+		drain.Message = fmt.Sprintf("waiting for node %s to rejoin", drain.NodeName)
+		return r.updateStatus(ctx, hostOp, logger)
+	}
+	return r.uncordon(ctx, hostOp, "the operation is "+hostOp.Status.Status, logger)
+}
+
+// uncordon makes the node schedulable again unless it was cordoned before the operation
+func (r *HostOperationController) uncordon(ctx context.Context, hostOp *bmcv1beta1.HostOperation, reason string, logger *zap.SugaredLogger) error {
+	drain := hostOp.Status.Drain
+	node := &corev1.Node{}
+	if err := r.apiReader.Get(ctx, client.ObjectKey{Name: drain.NodeName}, node); err != nil {
+		if !errors.IsNotFound(err) {
+			return err
+		}
+	} else if !drain.WasUnschedulable {
+		if err := r.setUnschedulable(ctx, node, false); err != nil {
+			return fmt.Errorf("failed to uncordon node %s: %v", drain.NodeName, err)
+		}
+	}
+	logger.Infof("uncordoned node %s of %s: %s", drain.NodeName, hostOp.Spec.HostStatusName, reason)
+	drain.Phase = bmcv1beta1.DrainPhaseUncordoned
+	drain.Message = reason
+	drain.PendingPods = 0
+	drain.Pods = nil
+	return r.updateStatus(ctx, hostOp, logger)
+}
+
+// processNodeReady waits for the node to rejoin with a new boot and become Ready after the action, then uncordons it
+func (r *HostOperationController) processNodeReady(ctx context.Context, hostOp *bmcv1beta1.HostOperation, logger *zap.SugaredLogger) (ctrl.Result, error) {
+	drain := hostOp.Status.Drain
+	node := &corev1.Node{}
+	if err := r.apiReader.Get(ctx, client.ObjectKey{Name: drain.NodeName}, node); err != nil {
+		if errors.IsNotFound(err) {
+			return ctrl.Result{}, r.uncordon(ctx, hostOp, fmt.Sprintf("node %s is deleted", drain.NodeName), logger)
+		}
+		return ctrl.Result{}, err
+	}
+	actionTime, err := time.Parse(time.RFC3339, drain.ActionTime)
+	if err != nil {
+		actionTime = time.Now()
+	}
+	restarted := drain.BootID == "" || node.Status.NodeInfo.BootID != drain.BootID
+	if restarted && nodeReady(node) {
+		return ctrl.Result{}, r.uncordon(ctx, hostOp, "the node rejoins and becomes Ready", logger)
+	}
+
+	timeout := time.Duration(hostOp.Spec.DrainPolicy.ReadyTimeoutSeconds) * time.Second
+	if timeout <= 0 {
+		timeout = 1800 * time.Second
+	}
+	if time.Since(actionTime) > timeout {
+		logger.Warnf("node %s of %s does not become Ready in %v after action %s", drain.NodeName, hostOp.Spec.HostStatusName, timeout, hostOp.Spec.Action)
+		drain.Phase = bmcv1beta1.DrainPhaseTimedOut
+		drain.Message = fmt.Sprintf("the node does not become Ready in %v, it is left cordoned", timeout)
+		return ctrl.Result{}, r.updateStatus(ctx, hostOp, logger)
+	}
+	return ctrl.Result{RequeueAfter: drainPollInterval}, nil
+}
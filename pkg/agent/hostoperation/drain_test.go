@@ -0,0 +1,165 @@
+package hostoperation_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spidernet-io/bmc/pkg/agent/hostoperation"
+	bmcv1beta1 "github.com/spidernet-io/bmc/pkg/k8s/apis/bmc.spidernet.io/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+var _ = Describe("Drain", Label("unitest"), func() {
+	op := func(action string, drain bool) *bmcv1beta1.HostOperation {
+		hostOp := &bmcv1beta1.HostOperation{}
+		hostOp.Spec.Action = action
+		if drain {
+			hostOp.Spec.DrainPolicy = &bmcv1beta1.DrainPolicy{}
+		}
+		return hostOp
+	}
+
+	It("drains the node before the actions taking it down", func() {
+		Expect(hostoperation.NeedsDrain(op(bmcv1beta1.BootCmdGracefulShutdown, true))).To(BeTrue())
+		Expect(hostoperation.NeedsDrain(op(bmcv1beta1.BootCmdForceRestart, true))).To(BeTrue())
+		Expect(hostoperation.NeedsDrain(op(bmcv1beta1.BootCmdResetPxeOnce, true))).To(BeTrue())
+		Expect(hostoperation.NeedsDrain(op(bmcv1beta1.BootCmdForceRestart, false))).To(BeFalse())
+		Expect(hostoperation.NeedsDrain(op(bmcv1beta1.BootCmdOn, true))).To(BeFalse())
+	})
+
+	It("waits for the node to rejoin after the restart but not after the shutdown", func() {
+		restart := op(bmcv1beta1.BootCmdForceRestart, true)
+		restart.Status.Status = bmcv1beta1.HostOperationStatusSuccess
+		restart.Status.Drain = &bmcv1beta1.DrainStatus{Phase: bmcv1beta1.DrainPhaseDrained}
+		Expect(hostoperation.WaitingForNode(restart)).To(BeTrue())
+		restart.Status.Drain.Phase = bmcv1beta1.DrainPhaseWaitingForReady
+		Expect(hostoperation.WaitingForNode(restart)).To(BeTrue())
+		restart.Status.Drain.Phase = bmcv1beta1.DrainPhaseUncordoned
+		Expect(hostoperation.WaitingForNode(restart)).To(BeFalse())
+
+		shutdown := op(bmcv1beta1.BootCmdGracefulShutdown, true)
+		shutdown.Status.Status = bmcv1beta1.HostOperationStatusSuccess
+		shutdown.Status.Drain = &bmcv1beta1.DrainStatus{Phase: bmcv1beta1.DrainPhaseDrained}
+		Expect(hostoperation.WaitingForNode(shutdown)).To(BeFalse())
+	})
+
+	It("leaves the pods of DaemonSets, the static pods and the finished pods", func() {
+		pod := func() *corev1.Pod {
+			return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod"}, Status: corev1.PodStatus{Phase: corev1.PodRunning}}
+		}
+		Expect(hostoperation.Evictable(pod())).To(BeTrue())
+
+		daemon := pod()
+		isController := true
+		daemon.OwnerReferences = []metav1.OwnerReference{{Kind: "DaemonSet", Name: "agent", Controller: &isController}}
+		Expect(hostoperation.Evictable(daemon)).To(BeFalse())
+
+		static := pod()
+		static.Annotations = map[string]string{"kubernetes.io/config.mirror": "hash"}
+		Expect(hostoperation.Evictable(static)).To(BeFalse())
+
+		finished := pod()
+		finished.Status.Phase = corev1.PodSucceeded
+		Expect(hostoperation.Evictable(finished)).To(BeFalse())
+	})
+
+	Context("drainNode", func() {
+		var (
+			ctx        context.Context
+			hostOp     *bmcv1beta1.HostOperation
+			hostStatus *bmcv1beta1.HostStatus
+			node       *corev1.Node
+			funcs      interceptor.Funcs
+			c          client.Client
+		)
+
+		BeforeEach(func() {
+			ctx = context.Background()
+			hostOp = op(bmcv1beta1.BootCmdForceRestart, true)
+			hostStatus = &bmcv1beta1.HostStatus{ObjectMeta: metav1.ObjectMeta{Name: "host1"}}
+			hostStatus.Status.NodeName = "node1"
+			node = &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node1"}}
+			funcs = interceptor.Funcs{}
+		})
+
+		drain := func(objs ...client.Object) (bool, error) {
+			scheme := runtime.NewScheme()
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+			Expect(bmcv1beta1.AddToScheme(scheme)).To(Succeed())
+			c = fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).
+				WithIndex(&corev1.Pod{}, "spec.nodeName", func(obj client.Object) []string {
+					return []string{obj.(*corev1.Pod).Spec.NodeName}
+				}).WithInterceptorFuncs(funcs).Build()
+			return hostoperation.DrainNode(ctx, c, hostOp, hostStatus)
+		}
+		unavailable := apierrors.NewServiceUnavailable("the API server is restarting")
+
+		It("retries when the node could not be read", func() {
+			funcs.Get = func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+				if _, ok := obj.(*corev1.Node); ok {
+					return unavailable
+				}
+				return c.Get(ctx, key, obj, opts...)
+			}
+			ready, err := drain(node)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ready).To(BeFalse())
+			Expect(hostOp.Status.Drain).To(BeNil())
+			Expect(hostoperation.DrainWaitMessage(hostOp, hostStatus)).To(Equal("failed to cordon node node1, retry later"))
+		})
+
+		It("retries when the node could not be cordoned", func() {
+			funcs.Patch = func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+				return unavailable
+			}
+			ready, err := drain(node)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ready).To(BeFalse())
+			Expect(hostOp.Status.Drain).To(BeNil())
+			Expect(hostoperation.DrainWaitMessage(hostOp, hostStatus)).To(Equal("failed to cordon node node1, retry later"))
+
+			// the node is left schedulable, and cordoned at the next attempt
+			Expect(c.Get(ctx, client.ObjectKeyFromObject(node), node)).To(Succeed())
+			Expect(node.Spec.Unschedulable).To(BeFalse())
+		})
+
+		It("retries when the pods could not be listed", func() {
+			funcs.List = func(ctx context.Context, c client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+				return unavailable
+			}
+			ready, err := drain(node)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ready).To(BeFalse())
+			Expect(hostOp.Status.Drain.Phase).To(Equal(bmcv1beta1.DrainPhaseDraining))
+			Expect(c.Get(ctx, client.ObjectKeyFromObject(node), node)).To(Succeed())
+			Expect(node.Spec.Unschedulable).To(BeTrue())
+		})
+
+		It("skips the node which is not found", func() {
+			ready, err := drain()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ready).To(BeTrue())
+			Expect(hostOp.Status.Drain.Phase).To(Equal(bmcv1beta1.DrainPhaseSkipped))
+		})
+
+		It("cordons the node and waits for its pods to be evicted", func() {
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+				Spec:       corev1.PodSpec{NodeName: "node1"},
+			}
+			ready, err := drain(node, pod)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ready).To(BeFalse())
+			Expect(hostOp.Status.Drain.Phase).To(Equal(bmcv1beta1.DrainPhaseDraining))
+			Expect(hostoperation.DrainWaitMessage(hostOp, hostStatus)).To(Equal("waiting for 1 pods to be evicted"))
+		})
+	})
+})
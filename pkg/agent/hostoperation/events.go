@@ -0,0 +1,75 @@
+package hostoperation
+
+import (
+	"fmt"
+	"strings"
+
+	bmcv1beta1 "github.com/spidernet-io/bmc/pkg/k8s/apis/bmc.spidernet.io/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// describeRequester returns who requests the operation and why
+func describeRequester(hostOp *bmcv1beta1.HostOperation) string {
+	var parts []string
+	if hostOp.Spec.Requester != nil {
+		parts = append(parts, fmt.Sprintf("requested by %s", hostOp.Spec.Requester.Username))
+		if len(hostOp.Spec.Requester.Groups) > 0 {
+			parts = append(parts, fmt.Sprintf("groups: %s", strings.Join(hostOp.Spec.Requester.Groups, ",")))
+		}
+	}
+	if hostOp.Spec.Reason != "" {
+		parts = append(parts, fmt.Sprintf("reason: %s", hostOp.Spec.Reason))
+	}
+	if owner := hostOp.Annotations[bmcv1beta1.AnnotationHostOperationSet]; owner != "" {
+		parts = append(parts, fmt.Sprintf("HostOperationSet: %s", owner))
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return " (" + strings.Join(parts, "; ") + ")"
+}
+
+// recordTransition generates the events of the new status on both the HostOperation and the HostStatus,
+// so that the history of the host tells who operates it
+func (r *HostOperationController) recordTransition(hostOp *bmcv1beta1.HostOperation) {
+	var eventType, reason, msg string
+	switch hostOp.Status.Status {
+	case bmcv1beta1.HostOperationStatusRunning:
+		eventType, reason = corev1.EventTypeNormal, "OperationStarted"
+		msg = fmt.Sprintf("action %s of HostOperation %s is accepted by the BMC", hostOp.Spec.Action, hostOp.Name)
+	case bmcv1beta1.HostOperationStatusSuccess:
+		eventType, reason = corev1.EventTypeNormal, "OperationSucceeded"
+		msg = fmt.Sprintf("action %s of HostOperation %s succeeded", hostOp.Spec.Action, hostOp.Name)
+		if v := hostOp.Status.PowerVerification; v != nil && v.Unverified {
+			eventType, reason = corev1.EventTypeWarning, "OperationUnverified"
+			msg = fmt.Sprintf("action %s of HostOperation %s is accepted by the BMC, but it is not verified: %s", hostOp.Spec.Action, hostOp.Name, hostOp.Status.Message)
+		}
+	case bmcv1beta1.HostOperationStatusFailed:
+		eventType, reason = corev1.EventTypeWarning, "OperationFailed"
+		msg = fmt.Sprintf("action %s of HostOperation %s failed: %s", hostOp.Spec.Action, hostOp.Name, hostOp.Status.Message)
+	case bmcv1beta1.HostOperationStatusCancelled:
+		eventType, reason = corev1.EventTypeWarning, "OperationCancelled"
+		msg = fmt.Sprintf("action %s of HostOperation %s is cancelled", hostOp.Spec.Action, hostOp.Name)
+	default:
+		return
+	}
+	msg += describeRequester(hostOp)
+
+	for _, t := range []*corev1.ObjectReference{
+		{
+			Kind:       bmcv1beta1.KindHostOperation,
+			Name:       hostOp.Name,
+			UID:        hostOp.UID,
+			Namespace:  r.agentConfig.PodNamespace,
+			APIVersion: bmcv1beta1.APIVersion,
+		},
+		{
+			Kind:       bmcv1beta1.KindHostStatus,
+			Name:       hostOp.Spec.HostStatusName,
+			Namespace:  r.agentConfig.PodNamespace,
+			APIVersion: bmcv1beta1.APIVersion,
+		},
+	} {
+		r.recorder.Event(t, eventType, reason, msg)
+	}
+}
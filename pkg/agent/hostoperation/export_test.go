@@ -0,0 +1,52 @@
+package hostoperation
+
+import (
+	"context"
+	"time"
+
+	"github.com/spidernet-io/bmc/pkg/agent/config"
+	bmcv1beta1 "github.com/spidernet-io/bmc/pkg/k8s/apis/bmc.spidernet.io/v1beta1"
+	"go.uber.org/zap"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// the decision functions are exported for the tests
+var (
+	PowerTarget          = powerTarget
+	NewPowerVerification = newPowerVerification
+	ObservePowerState    = observePowerState
+	UnverifiedRestart    = unverifiedRestart
+	TimedOut             = timedOut
+	RetryBackoff         = retryBackoff
+	RetryWait            = retryWait
+	IndexHostStatusName  = indexHostStatusName
+	IsQueued             = isQueued
+	NeedsDrain           = needsDrain
+	WaitingForNode       = waitingForNode
+	Evictable            = evictable
+	DrainWaitMessage     = drainWaitMessage
+)
+
+// QueuePosition returns the position of the operation in the queue of the host with a controller working with the client
+func QueuePosition(ctx context.Context, c client.Client, hostOp *bmcv1beta1.HostOperation) (int32, string, error) {
+	r := &HostOperationController{Client: c}
+	return r.queuePosition(ctx, hostOp)
+}
+
+// RecordHistory records the operation in the history of its host with a controller working with the client
+func RecordHistory(ctx context.Context, c client.Client, limit int, hostOp *bmcv1beta1.HostOperation) error {
+	r := &HostOperationController{Client: c, agentConfig: &config.AgentConfig{OperationHistoryLimit: limit}}
+	return r.recordHistory(ctx, hostOp)
+}
+
+// CheckPolicies returns the reasons why the policies deny the operation at the time with a controller working with the client
+func CheckPolicies(ctx context.Context, c client.Client, hostOp *bmcv1beta1.HostOperation, hostStatus *bmcv1beta1.HostStatus, now time.Time) ([]string, error) {
+	r := &HostOperationController{Client: c}
+	return r.checkPolicies(ctx, hostOp, hostStatus, now)
+}
+
+// DrainNode drains the node of the host with a controller reading the nodes and pods with the client
+func DrainNode(ctx context.Context, c client.Client, hostOp *bmcv1beta1.HostOperation, hostStatus *bmcv1beta1.HostStatus) (bool, error) {
+	r := &HostOperationController{Client: c, apiReader: c}
+	return r.drainNode(ctx, hostOp, hostStatus, zap.NewNop().Sugar())
+}
@@ -0,0 +1,73 @@
+package hostoperation
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	bmcv1beta1 "github.com/spidernet-io/bmc/pkg/k8s/apis/bmc.spidernet.io/v1beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// requester returns the user who requests the operation
+func requester(hostOp *bmcv1beta1.HostOperation) string {
+	if hostOp.Spec.Requester != nil {
+		return hostOp.Spec.Requester.Username
+	}
+	return ""
+}
+
+// owner returns the object which creates the operation, in the form of Kind/Name
+func owner(hostOp *bmcv1beta1.HostOperation) string {
+	if owner := metav1.GetControllerOf(hostOp); owner != nil {
+		return fmt.Sprintf("%s/%s", owner.Kind, owner.Name)
+	}
+	return ""
+}
+
+// recordHistory appends the finished operation to the operation history of the HostStatus, so that the host tells
+// who operated it and why, long after the HostOperation is deleted
+func (r *HostOperationController) recordHistory(ctx context.Context, hostOp *bmcv1beta1.HostOperation) error {
+	limit := r.agentConfig.OperationHistoryLimit
+	if limit <= 0 {
+		return nil
+	}
+	record := bmcv1beta1.OperationRecord{
+		Name:         hostOp.Name,
+		Action:       hostOp.Spec.Action,
+		Requester:    requester(hostOp),
+		Reason:       hostOp.Spec.Reason,
+		Owner:        owner(hostOp),
+		Result:       hostOp.Status.Status,
+		Message:      hostOp.Status.Message,
+		CreationTime: hostOp.CreationTimestamp.UTC().Format(time.RFC3339),
+		FinishTime:   hostOp.Status.LastUpdateTime,
+	}
+
+	for i := 0; ; i++ {
+		hostStatus := &bmcv1beta1.HostStatus{}
+		if err := r.Get(ctx, client.ObjectKey{Name: hostOp.Spec.HostStatusName}, hostStatus); err != nil {
+			if errors.IsNotFound(err) {
+				return nil
+			}
+			return err
+		}
+		for _, h := range hostStatus.Status.OperationHistory {
+			// it has been recorded by a previous update
+			if h.Name == record.Name && h.CreationTime == record.CreationTime {
+				return nil
+			}
+		}
+		history := append(hostStatus.Status.OperationHistory, record)
+		if len(history) > limit {
+			history = history[len(history)-limit:]
+		}
+		hostStatus.Status.OperationHistory = history
+		err := r.Status().Update(ctx, hostStatus)
+		if err == nil || !errors.IsConflict(err) || i >= 2 {
+			return err
+		}
+	}
+}
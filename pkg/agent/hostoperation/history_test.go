@@ -0,0 +1,89 @@
+package hostoperation_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spidernet-io/bmc/pkg/agent/hostoperation"
+	bmcv1beta1 "github.com/spidernet-io/bmc/pkg/k8s/apis/bmc.spidernet.io/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("History", Label("unitest"), func() {
+	var (
+		ctx context.Context
+		c   client.Client
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		scheme := runtime.NewScheme()
+		Expect(bmcv1beta1.AddToScheme(scheme)).To(Succeed())
+		c = fake.NewClientBuilder().WithScheme(scheme).
+			WithObjects(&bmcv1beta1.HostStatus{ObjectMeta: metav1.ObjectMeta{Name: "host1"}}).
+			WithStatusSubresource(&bmcv1beta1.HostStatus{}).Build()
+	})
+
+	operation := func(name, status string) *bmcv1beta1.HostOperation {
+		return &bmcv1beta1.HostOperation{
+			ObjectMeta: metav1.ObjectMeta{Name: name, CreationTimestamp: metav1.NewTime(time.Now().Add(-time.Hour))},
+			Spec: bmcv1beta1.HostOperationSpec{
+				HostOperationActionSpec: bmcv1beta1.HostOperationActionSpec{Action: bmcv1beta1.BootCmdGracefulRestart},
+				HostStatusName:          "host1",
+				Requester:               &bmcv1beta1.Requester{Username: "alice"},
+				Reason:                  "apply the new kernel",
+			},
+			Status: bmcv1beta1.HostOperationStatus{Status: status, LastUpdateTime: time.Now().UTC().Format(time.RFC3339)},
+		}
+	}
+	history := func() []bmcv1beta1.OperationRecord {
+		hostStatus := &bmcv1beta1.HostStatus{}
+		Expect(c.Get(ctx, client.ObjectKey{Name: "host1"}, hostStatus)).To(Succeed())
+		return hostStatus.Status.OperationHistory
+	}
+
+	It("records who requests the finished operation, its owner and its result", func() {
+		op := operation("op1", bmcv1beta1.HostOperationStatusSuccess)
+		op.OwnerReferences = []metav1.OwnerReference{{
+			APIVersion: bmcv1beta1.SchemeGroupVersion.String(), Kind: bmcv1beta1.KindHostOperationSet,
+			Name: "rack1", UID: "rack1-uid", Controller: ptr.To(true),
+		}}
+		Expect(hostoperation.RecordHistory(ctx, c, 20, op)).To(Succeed())
+		// the later updates of the finished operation are not recorded again
+		Expect(hostoperation.RecordHistory(ctx, c, 20, op)).To(Succeed())
+
+		records := history()
+		Expect(records).To(HaveLen(1))
+		Expect(records[0].Name).To(Equal("op1"))
+		Expect(records[0].Requester).To(Equal("alice"))
+		Expect(records[0].Reason).To(Equal("apply the new kernel"))
+		Expect(records[0].Owner).To(Equal("HostOperationSet/rack1"))
+		Expect(records[0].Result).To(Equal(bmcv1beta1.HostOperationStatusSuccess))
+	})
+
+	It("keeps the latest records within the limit", func() {
+		for _, op := range []*bmcv1beta1.HostOperation{
+			operation("op1", bmcv1beta1.HostOperationStatusSuccess),
+			operation("op2", bmcv1beta1.HostOperationStatusFailed),
+			operation("op3", bmcv1beta1.HostOperationStatusCancelled),
+		} {
+			Expect(hostoperation.RecordHistory(ctx, c, 2, op)).To(Succeed())
+		}
+		records := history()
+		Expect(records).To(HaveLen(2))
+		Expect(records[0].Name).To(Equal("op2"))
+		Expect(records[0].Result).To(Equal(bmcv1beta1.HostOperationStatusFailed))
+		Expect(records[1].Name).To(Equal("op3"))
+	})
+
+	It("records nothing without a limit", func() {
+		Expect(hostoperation.RecordHistory(ctx, c, 0, operation("op1", bmcv1beta1.HostOperationStatusSuccess))).To(Succeed())
+		Expect(history()).To(BeEmpty())
+	})
+})
@@ -0,0 +1,19 @@
+package hostoperation_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spidernet-io/bmc/pkg/log"
+	"go.uber.org/zap"
+)
+
+func TestHostOperation(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "HostOperation Suite")
+}
+
+var _ = BeforeSuite(func() {
+	log.Logger = zap.NewNop().Sugar()
+})
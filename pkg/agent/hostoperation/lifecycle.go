@@ -0,0 +1,166 @@
+package hostoperation
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spidernet-io/bmc/pkg/agent/hoststatus/data"
+	bmcv1beta1 "github.com/spidernet-io/bmc/pkg/k8s/apis/bmc.spidernet.io/v1beta1"
+	"github.com/spidernet-io/bmc/pkg/redfish"
+	"go.uber.org/zap"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// the maximum delay between the retries
+const maxRetryBackoff = 300 * time.Second
+
+// isActive returns whether the operation is pending or running
+func isActive(hostOp *bmcv1beta1.HostOperation) bool {
+	return hostOp.Status.Status == "" || hostOp.Status.Status == bmcv1beta1.HostOperationStatusPending ||
+		hostOp.Status.Status == bmcv1beta1.HostOperationStatusRunning
+}
+
+// isFinished returns whether the agent has nothing more to do for the operation
+func isFinished(hostOp *bmcv1beta1.HostOperation) bool {
+	return !isActive(hostOp) && hostOp.Status.LocateOffTime == "" && !waitingForNode(hostOp)
+}
+
+// timedOut returns whether the operation exceeds spec.timeoutSeconds
+func timedOut(hostOp *bmcv1beta1.HostOperation) bool {
+	if hostOp.Spec.TimeoutSeconds == nil || hostOp.Status.StartTime == "" {
+		return false
+	}
+	start, err := time.Parse(time.RFC3339, hostOp.Status.StartTime)
+	if err != nil {
+		return false
+	}
+	return time.Since(start) > time.Duration(*hostOp.Spec.TimeoutSeconds)*time.Second
+}
+
+// retryBackoff returns the delay before the next attempt, and false if no more retry is allowed
+func retryBackoff(hostOp *bmcv1beta1.HostOperation, err error) (time.Duration, bool) {
+	retry := hostOp.Spec.Retry
+	if retry == nil || hostOp.Status.Attempts > retry.Limit || !redfish.IsTransientError(err) {
+		return 0, false
+	}
+	backoff := time.Duration(retry.BackoffSeconds) * time.Second
+	if backoff <= 0 {
+		backoff = 10 * time.Second
+	}
+	for i := int32(1); i < hostOp.Status.Attempts && backoff < maxRetryBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > maxRetryBackoff {
+		backoff = maxRetryBackoff
+	}
+	return backoff, true
+}
+
+// retryWait returns the time to wait for the next attempt
+func retryWait(hostOp *bmcv1beta1.HostOperation) time.Duration {
+	if hostOp.Status.NextRetryTime == "" {
+		return 0
+	}
+	t, err := time.Parse(time.RFC3339, hostOp.Status.NextRetryTime)
+	if err != nil {
+		return 0
+	}
+	return time.Until(t)
+}
+
+// addFinalizer makes sure the tracked task can be cancelled when the operation is deleted
+func (r *HostOperationController) addFinalizer(ctx context.Context, hostOp *bmcv1beta1.HostOperation) error {
+	if controllerutil.ContainsFinalizer(hostOp, bmcv1beta1.HostOperationFinalizer) {
+		return nil
+	}
+	status := hostOp.Status.DeepCopy()
+	controllerutil.AddFinalizer(hostOp, bmcv1beta1.HostOperationFinalizer)
+	if err := r.Update(ctx, hostOp); err != nil {
+		return fmt.Errorf("failed to add finalizer: %v", err)
+	}
+	// the update refreshes the object with the status on the server
+	hostOp.Status = *status
+	return nil
+}
+
+func (r *HostOperationController) removeFinalizer(ctx context.Context, hostOp *bmcv1beta1.HostOperation) error {
+	if !controllerutil.ContainsFinalizer(hostOp, bmcv1beta1.HostOperationFinalizer) {
+		return nil
+	}
+	controllerutil.RemoveFinalizer(hostOp, bmcv1beta1.HostOperationFinalizer)
+	if err := r.Update(ctx, hostOp); err != nil {
+		return fmt.Errorf("failed to remove finalizer: %v", err)
+	}
+	return nil
+}
+
+// abortTask cancels the tracked task of the BMC, it is best-effort
+func (r *HostOperationController) abortTask(hostOp *bmcv1beta1.HostOperation, logger *zap.SugaredLogger) {
+	task := hostOp.Status.Task
+	if task == nil || task.MonitorURI == "" {
+		return
+	}
+	if finished, _ := redfish.TaskResult(task); finished {
+		return
+	}
+	d := data.HostCacheDatabase.Get(hostOp.Spec.HostStatusName)
+	if d == nil {
+		logger.Warnf("Failed to get connect config %s from cache, the task %s is not cancelled", hostOp.Spec.HostStatusName, task.MonitorURI)
+		return
+	}
+	c, err := redfish.NewClient(*d, logger)
+	if err == nil {
+		err = c.CancelTask(task.MonitorURI)
+	}
+	if err != nil {
+		logger.Warnf("Failed to cancel task %s of %s, the BMC may not support it: %v", task.MonitorURI, hostOp.Spec.HostStatusName, err)
+		return
+	}
+	task.TaskState = "Cancelled"
+}
+
+// terminate stops the active operation with the status and the reason
+func (r *HostOperationController) terminate(ctx context.Context, hostOp *bmcv1beta1.HostOperation, status, reason string, logger *zap.SugaredLogger) (ctrl.Result, error) {
+	logger.Infof("terminate HostOperation %s: %s", hostOp.Name, reason)
+	r.abortTask(hostOp, logger)
+	hostOp.Status.Status = status
+	hostOp.Status.Message = reason
+	hostOp.Status.NextRetryTime = ""
+	hostOp.Status.LastUpdateTime = time.Now().UTC().Format(time.RFC3339)
+	return ctrl.Result{}, r.updateStatus(ctx, hostOp, logger)
+}
+
+// processDeletion cancels the active operation before the HostOperation is deleted
+func (r *HostOperationController) processDeletion(ctx context.Context, hostOp *bmcv1beta1.HostOperation, logger *zap.SugaredLogger) (ctrl.Result, error) {
+	if !controllerutil.ContainsFinalizer(hostOp, bmcv1beta1.HostOperationFinalizer) {
+		return ctrl.Result{}, nil
+	}
+	if hostOp.Status.ClusterAgent != "" && hostOp.Status.ClusterAgent != r.agentConfig.ClusterAgentName {
+		return ctrl.Result{}, nil
+	}
+	if isActive(hostOp) {
+		logger.Infof("HostOperation %s is deleted before it finishes, cancel it", hostOp.Name)
+		r.abortTask(hostOp, logger)
+		cancelled := hostOp.DeepCopy()
+		cancelled.Status.Status = bmcv1beta1.HostOperationStatusCancelled
+		cancelled.Status.Message = "the HostOperation is deleted before it finishes"
+		cancelled.Status.LastUpdateTime = time.Now().UTC().Format(time.RFC3339)
+		if err := r.recordHistory(ctx, cancelled); err != nil {
+			logger.Errorf("failed to record HostOperation %s in the history of HostStatus %s: %v", hostOp.Name, hostOp.Spec.HostStatusName, err)
+			return ctrl.Result{}, err
+		}
+	}
+	if isDrained(hostOp) {
+		if err := r.uncordon(ctx, hostOp, "the HostOperation is deleted", logger); err != nil {
+			logger.Errorf("%v", err)
+			return ctrl.Result{}, err
+		}
+	}
+	if err := r.removeFinalizer(ctx, hostOp); err != nil {
+		logger.Errorf("%v", err)
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, nil
+}
@@ -0,0 +1,72 @@
+package hostoperation_test
+
+import (
+	"errors"
+	"net"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spidernet-io/bmc/pkg/agent/hostoperation"
+	bmcv1beta1 "github.com/spidernet-io/bmc/pkg/k8s/apis/bmc.spidernet.io/v1beta1"
+)
+
+var _ = Describe("Lifecycle", Label("unitest"), func() {
+	ago := func(d time.Duration) string {
+		return time.Now().Add(-d).UTC().Format(time.RFC3339)
+	}
+	seconds := func(s int32) *int32 { return &s }
+	transient := &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}
+
+	It("times out the operation running longer than spec.timeoutSeconds", func() {
+		op := &bmcv1beta1.HostOperation{}
+		op.Status.StartTime = ago(2 * time.Minute)
+		Expect(hostoperation.TimedOut(op)).To(BeFalse())
+
+		op.Spec.TimeoutSeconds = seconds(300)
+		Expect(hostoperation.TimedOut(op)).To(BeFalse())
+		op.Spec.TimeoutSeconds = seconds(60)
+		Expect(hostoperation.TimedOut(op)).To(BeTrue())
+
+		op.Status.StartTime = ""
+		Expect(hostoperation.TimedOut(op)).To(BeFalse())
+	})
+
+	It("retries the transient errors with the backoff doubled up to the limit", func() {
+		op := &bmcv1beta1.HostOperation{}
+		op.Spec.Retry = &bmcv1beta1.RetryPolicy{Limit: 3, BackoffSeconds: 100}
+		expected := []time.Duration{100 * time.Second, 200 * time.Second, 300 * time.Second}
+		for i, d := range expected {
+			op.Status.Attempts = int32(i + 1)
+			backoff, ok := hostoperation.RetryBackoff(op, transient)
+			Expect(ok).To(BeTrue())
+			Expect(backoff).To(Equal(d))
+		}
+		op.Status.Attempts = 4
+		_, ok := hostoperation.RetryBackoff(op, transient)
+		Expect(ok).To(BeFalse())
+	})
+
+	It("does not retry the permanent errors or without a retry policy", func() {
+		op := &bmcv1beta1.HostOperation{}
+		op.Status.Attempts = 1
+		_, ok := hostoperation.RetryBackoff(op, transient)
+		Expect(ok).To(BeFalse())
+
+		op.Spec.Retry = &bmcv1beta1.RetryPolicy{Limit: 3}
+		backoff, ok := hostoperation.RetryBackoff(op, transient)
+		Expect(ok).To(BeTrue())
+		Expect(backoff).To(Equal(10 * time.Second))
+		_, ok = hostoperation.RetryBackoff(op, errors.New("401 Unauthorized"))
+		Expect(ok).To(BeFalse())
+	})
+
+	It("waits until the next retry time", func() {
+		op := &bmcv1beta1.HostOperation{}
+		Expect(hostoperation.RetryWait(op)).To(BeZero())
+		op.Status.NextRetryTime = time.Now().Add(time.Minute).UTC().Format(time.RFC3339)
+		Expect(hostoperation.RetryWait(op)).To(BeNumerically(">", 50*time.Second))
+		op.Status.NextRetryTime = ago(time.Minute)
+		Expect(hostoperation.RetryWait(op)).To(BeNumerically("<=", 0))
+	})
+})
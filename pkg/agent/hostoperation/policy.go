@@ -0,0 +1,24 @@
+package hostoperation
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spidernet-io/bmc/pkg/hostpolicy"
+	bmcv1beta1 "github.com/spidernet-io/bmc/pkg/k8s/apis/bmc.spidernet.io/v1beta1"
+)
+
+// checkPolicies returns the reasons why the HostPolicies deny the action of the operation at the time.
+// the webhook checks them when the operation is created, but the operation could start much later after waiting
+// in the queue, draining the node or retrying, when a maintenance window has closed or the host becomes protected
+func (r *HostOperationController) checkPolicies(ctx context.Context, hostOp *bmcv1beta1.HostOperation, hostStatus *bmcv1beta1.HostStatus, now time.Time) ([]string, error) {
+	if hostpolicy.Overridden(hostOp.Annotations) {
+		return nil, nil
+	}
+	policies := &bmcv1beta1.HostPolicyList{}
+	if err := r.List(ctx, policies); err != nil {
+		return nil, fmt.Errorf("failed to list HostPolicies: %v", err)
+	}
+	return hostpolicy.Evaluate(policies.Items, hostStatus, hostOp.Spec.Action, now)
+}
@@ -0,0 +1,64 @@
+package hostoperation_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spidernet-io/bmc/pkg/agent/hostoperation"
+	bmcv1beta1 "github.com/spidernet-io/bmc/pkg/k8s/apis/bmc.spidernet.io/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("Policy", Label("unitest"), func() {
+	// the window opens at 02:00 UTC every day for an hour
+	policy := &bmcv1beta1.HostPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "night"},
+		Spec: bmcv1beta1.HostPolicySpec{
+			HostStatusNames:    []string{"host1"},
+			MaintenanceWindows: []bmcv1beta1.MaintenanceWindow{{Schedule: "0 2 * * *", DurationMinutes: 60}},
+		},
+	}
+	hostStatus := &bmcv1beta1.HostStatus{ObjectMeta: metav1.ObjectMeta{Name: "host1"}}
+	inWindow := time.Date(2024, 1, 1, 2, 30, 0, 0, time.UTC)
+	outOfWindow := time.Date(2024, 1, 1, 3, 30, 0, 0, time.UTC)
+
+	check := func(hostOp *bmcv1beta1.HostOperation, now time.Time) []string {
+		scheme := runtime.NewScheme()
+		Expect(bmcv1beta1.AddToScheme(scheme)).To(Succeed())
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(policy.DeepCopy()).Build()
+		reasons, err := hostoperation.CheckPolicies(context.Background(), c, hostOp, hostStatus, now)
+		Expect(err).NotTo(HaveOccurred())
+		return reasons
+	}
+	op := func(action string) *bmcv1beta1.HostOperation {
+		return &bmcv1beta1.HostOperation{
+			ObjectMeta: metav1.ObjectMeta{Name: "op1"},
+			Spec: bmcv1beta1.HostOperationSpec{
+				HostStatusName:          "host1",
+				HostOperationActionSpec: bmcv1beta1.HostOperationActionSpec{Action: action},
+			},
+		}
+	}
+
+	It("allows the destructive action in the maintenance window", func() {
+		Expect(check(op(bmcv1beta1.BootCmdForceRestart), inWindow)).To(BeEmpty())
+	})
+
+	It("denies the destructive action queued until the maintenance window closes", func() {
+		Expect(check(op(bmcv1beta1.BootCmdForceRestart), outOfWindow)).To(HaveLen(1))
+	})
+
+	It("allows the action which is not destructive out of the maintenance window", func() {
+		Expect(check(op(bmcv1beta1.BootCmdOn), outOfWindow)).To(BeEmpty())
+	})
+
+	It("allows the operation overriding the policies", func() {
+		overridden := op(bmcv1beta1.BootCmdForceRestart)
+		overridden.Annotations = map[string]string{bmcv1beta1.AnnotationOverridePolicy: "the host hangs"}
+		Expect(check(overridden, outOfWindow)).To(BeEmpty())
+	})
+})
@@ -0,0 +1,135 @@
+package hostoperation
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	bmcv1beta1 "github.com/spidernet-io/bmc/pkg/k8s/apis/bmc.spidernet.io/v1beta1"
+	"github.com/spidernet-io/bmc/pkg/log"
+	"go.uber.org/zap"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// the interval to check the queue again, in case the event of the finished operation is missed
+const queueCheckInterval = 30 * time.Second
+
+// indexHostStatusName indexes the HostOperations by the host
+func indexHostStatusName(obj client.Object) []string {
+	hostOp, ok := obj.(*bmcv1beta1.HostOperation)
+	if !ok || hostOp.Spec.HostStatusName == "" {
+		return nil
+	}
+	return []string{hostOp.Spec.HostStatusName}
+}
+
+// isQueued returns whether the operation waits for its turn, an operation leaves the queue once it is started
+func isQueued(hostOp *bmcv1beta1.HostOperation) bool {
+	return isActive(hostOp) && hostOp.Status.StartTime == "" && !hostOp.Spec.Cancel && hostOp.DeletionTimestamp == nil
+}
+
+// isAhead returns whether the operation a goes before b, the operations are ordered by the creation time
+func isAhead(a, b *bmcv1beta1.HostOperation) bool {
+	if !a.CreationTimestamp.Equal(&b.CreationTimestamp) {
+		return a.CreationTimestamp.Before(&b.CreationTimestamp)
+	}
+	return a.Name < b.Name
+}
+
+// listHostOperations returns the operations of the host
+func (r *HostOperationController) listHostOperations(ctx context.Context, hostStatusName string) ([]bmcv1beta1.HostOperation, error) {
+	list := &bmcv1beta1.HostOperationList{}
+	if err := r.List(ctx, list, client.MatchingFields{bmcv1beta1.HostOperationHostStatusNameIndex: hostStatusName}); err != nil {
+		return nil, fmt.Errorf("failed to list HostOperations of %s: %v", hostStatusName, err)
+	}
+	return list.Items, nil
+}
+
+// queuePosition returns the number of the operations ahead of the operation on the same host,
+// and the one it waits for. the started operation goes first, and the others follow the creation order
+func (r *HostOperationController) queuePosition(ctx context.Context, hostOp *bmcv1beta1.HostOperation) (int32, string, error) {
+	items, err := r.listHostOperations(ctx, hostOp.Spec.HostStatusName)
+	if err != nil {
+		return 0, "", err
+	}
+	var position int32
+	var blocker *bmcv1beta1.HostOperation
+	for i := range items {
+		other := &items[i]
+		if other.Name == hostOp.Name || !isActive(other) || other.Spec.Cancel || other.DeletionTimestamp != nil {
+			continue
+		}
+		started := other.Status.StartTime != ""
+		if !started && !isAhead(other, hostOp) {
+			continue
+		}
+		position++
+		if blocker == nil || started || (blocker.Status.StartTime == "" && isAhead(other, blocker)) {
+			blocker = other
+		}
+	}
+	if blocker == nil {
+		return 0, "", nil
+	}
+	return position, blocker.Name, nil
+}
+
+// waitInQueue holds the operation until the operations ahead of it on the same host finish.
+// it returns true when the operation should wait
+func (r *HostOperationController) waitInQueue(ctx context.Context, hostOp *bmcv1beta1.HostOperation, logger *zap.SugaredLogger) (bool, ctrl.Result, error) {
+	position, blocker, err := r.queuePosition(ctx, hostOp)
+	if err != nil {
+		logger.Errorf("%v", err)
+		return true, ctrl.Result{}, err
+	}
+	if position == 0 {
+		hostOp.Status.QueuePosition = 0
+		return false, ctrl.Result{}, nil
+	}
+
+	// the webhook rejects the operation when the host is busy, but the operations created at the same time may still conflict
+	if hostOp.Spec.QueuePolicy == bmcv1beta1.HostOperationQueuePolicyReject {
+		hostOp.Status.Status = bmcv1beta1.HostOperationStatusFailed
+		hostOp.Status.Message = fmt.Sprintf("the host is busy with HostOperation %s", blocker)
+		hostOp.Status.QueuePosition = 0
+		hostOp.Status.LastUpdateTime = time.Now().UTC().Format(time.RFC3339)
+		return true, ctrl.Result{}, r.updateStatus(ctx, hostOp, logger)
+	}
+
+	message := fmt.Sprintf("waiting for HostOperation %s on the same host, %d operations ahead", blocker, position)
+	if hostOp.Status.Status == bmcv1beta1.HostOperationStatusPending && hostOp.Status.QueuePosition == position && hostOp.Status.Message == message {
+		return true, ctrl.Result{RequeueAfter: queueCheckInterval}, nil
+	}
+	logger.Infof("HostOperation %s is queued: %s", hostOp.Name, message)
+	hostOp.Status.Status = bmcv1beta1.HostOperationStatusPending
+	hostOp.Status.Message = message
+	hostOp.Status.QueuePosition = position
+	hostOp.Status.ClusterAgent = r.agentConfig.ClusterAgentName
+	hostOp.Status.LastUpdateTime = time.Now().UTC().Format(time.RFC3339)
+	if err := r.updateStatus(ctx, hostOp, logger); err != nil {
+		return true, ctrl.Result{}, err
+	}
+	return true, ctrl.Result{RequeueAfter: queueCheckInterval}, nil
+}
+
+// enqueueQueued triggers the queued operations of the same host, so that the next one starts once an operation finishes
+func (r *HostOperationController) enqueueQueued(ctx context.Context, obj client.Object) []reconcile.Request {
+	hostOp, ok := obj.(*bmcv1beta1.HostOperation)
+	if !ok || isQueued(hostOp) {
+		return nil
+	}
+	items, err := r.listHostOperations(ctx, hostOp.Spec.HostStatusName)
+	if err != nil {
+		log.Logger.Errorf("%v", err)
+		return nil
+	}
+	var requests []reconcile.Request
+	for i := range items {
+		if items[i].Name != hostOp.Name && isQueued(&items[i]) {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKey{Name: items[i].Name}})
+		}
+	}
+	return requests
+}
@@ -0,0 +1,74 @@
+package hostoperation_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spidernet-io/bmc/pkg/agent/hostoperation"
+	bmcv1beta1 "github.com/spidernet-io/bmc/pkg/k8s/apis/bmc.spidernet.io/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("Queue", Label("unitest"), func() {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	op := func(name, host string, created int, status string) *bmcv1beta1.HostOperation {
+		return &bmcv1beta1.HostOperation{
+			ObjectMeta: metav1.ObjectMeta{Name: name, CreationTimestamp: metav1.NewTime(base.Add(time.Duration(created) * time.Second))},
+			Spec:       bmcv1beta1.HostOperationSpec{HostStatusName: host},
+			Status:     bmcv1beta1.HostOperationStatus{Status: status},
+		}
+	}
+	position := func(target *bmcv1beta1.HostOperation, objs ...client.Object) (int32, string) {
+		scheme := runtime.NewScheme()
+		Expect(bmcv1beta1.AddToScheme(scheme)).To(Succeed())
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(append(objs, target)...).
+			WithIndex(&bmcv1beta1.HostOperation{}, bmcv1beta1.HostOperationHostStatusNameIndex, hostoperation.IndexHostStatusName).Build()
+		n, blocker, err := hostoperation.QueuePosition(context.Background(), c, target)
+		Expect(err).NotTo(HaveOccurred())
+		return n, blocker
+	}
+
+	It("orders the operations of a host by the creation time", func() {
+		n, blocker := position(op("op3", "host1", 3, ""),
+			op("op1", "host1", 1, bmcv1beta1.HostOperationStatusPending),
+			op("op2", "host1", 2, ""),
+			op("op4", "host1", 4, ""),
+			op("other", "host2", 0, ""))
+		Expect(n).To(Equal(int32(2)))
+		Expect(blocker).To(Equal("op1"))
+	})
+
+	It("lets the started operation go first", func() {
+		started := op("op9", "host1", 9, bmcv1beta1.HostOperationStatusRunning)
+		started.Status.StartTime = base.Format(time.RFC3339)
+		n, blocker := position(op("op3", "host1", 3, ""), started, op("op1", "host1", 1, ""))
+		Expect(n).To(Equal(int32(2)))
+		Expect(blocker).To(Equal("op9"))
+	})
+
+	It("skips the finished and the cancelled operations", func() {
+		cancelled := op("op2", "host1", 2, "")
+		cancelled.Spec.Cancel = true
+		n, blocker := position(op("op3", "host1", 3, ""), op("op1", "host1", 1, bmcv1beta1.HostOperationStatusSuccess), cancelled)
+		Expect(n).To(BeZero())
+		Expect(blocker).To(BeEmpty())
+	})
+
+	It("breaks the tie of the creation time by the name", func() {
+		n, blocker := position(op("b", "host1", 1, ""), op("a", "host1", 1, ""), op("c", "host1", 1, ""))
+		Expect(n).To(Equal(int32(1)))
+		Expect(blocker).To(Equal("a"))
+	})
+
+	It("keeps an operation in the queue until it starts", func() {
+		queued := op("op1", "host1", 1, bmcv1beta1.HostOperationStatusPending)
+		Expect(hostoperation.IsQueued(queued)).To(BeTrue())
+		queued.Status.StartTime = base.Format(time.RFC3339)
+		Expect(hostoperation.IsQueued(queued)).To(BeFalse())
+	})
+})
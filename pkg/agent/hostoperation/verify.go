@@ -0,0 +1,218 @@
+package hostoperation
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/spidernet-io/bmc/pkg/agent/hoststatus/data"
+	bmcv1beta1 "github.com/spidernet-io/bmc/pkg/k8s/apis/bmc.spidernet.io/v1beta1"
+	"github.com/spidernet-io/bmc/pkg/redfish"
+	gofishredfish "github.com/stmcginnis/gofish/redfish"
+	"go.uber.org/zap"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+const (
+	// the interval of polling the power state after a power action
+	powerVerifyInterval = 5 * time.Second
+	// the maximum number of power state transitions kept in the status
+	maxPowerTransitions = 20
+)
+
+// powerVerifyTimeout returns the time to wait for the target power state of the power action
+func (r *HostOperationController) powerVerifyTimeout(action string) time.Duration {
+	var seconds int
+	switch action {
+	case bmcv1beta1.BootCmdOn, bmcv1beta1.BootCmdForceOn:
+		seconds = r.agentConfig.PowerOnTimeout
+	case bmcv1beta1.BootCmdForceOff:
+		seconds = r.agentConfig.ForceOffTimeout
+	case bmcv1beta1.BootCmdGracefulShutdown:
+		seconds = r.agentConfig.GracefulShutdownTimeout
+	default:
+		seconds = r.agentConfig.RestartTimeout
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// powerTarget returns the power state expected after the action, and whether the host should restart
+func powerTarget(action string) (string, bool, bool) {
+	switch action {
+	case bmcv1beta1.BootCmdOn, bmcv1beta1.BootCmdForceOn:
+		return string(gofishredfish.OnPowerState), false, true
+	case bmcv1beta1.BootCmdForceOff, bmcv1beta1.BootCmdGracefulShutdown:
+		return string(gofishredfish.OffPowerState), false, true
+	case bmcv1beta1.BootCmdForceRestart, bmcv1beta1.BootCmdGracefulRestart, bmcv1beta1.BootCmdResetPxeOnce:
+		return string(gofishredfish.OnPowerState), true, true
+	default:
+		return "", false, false
+	}
+}
+
+func isRestartAction(action string) bool {
+	_, restart, _ := powerTarget(action)
+	return restart
+}
+
+// hasBootProgress returns whether the BMC reports the boot progress in the record
+func hasBootProgress(record *bmcv1beta1.PowerStateRecord) bool {
+	return record.BootProgress != "" || record.BootProgressTime != ""
+}
+
+// newPowerVerification prepares the verification of a power action with the power state observed before the action.
+// the restart on a BMC reporting no boot progress is observed for the window at most, which is capped by the timeout
+func newPowerVerification(action string, initial *bmcv1beta1.PowerStateRecord, timeout, window time.Duration) *bmcv1beta1.PowerVerificationStatus {
+	target, restart, ok := powerTarget(action)
+	if !ok {
+		return nil
+	}
+	v := &bmcv1beta1.PowerVerificationStatus{
+		TargetPowerState: target,
+		TimeoutSeconds:   int32(timeout.Seconds()),
+	}
+	if restart {
+		if window <= 0 || window > timeout {
+			window = timeout
+		}
+		v.ObservationSeconds = int32(window.Seconds())
+	}
+	if initial != nil {
+		v.InitialBootProgressTime = initial.BootProgressTime
+		v.BootProgressUnsupported = restart && !hasBootProgress(initial)
+		// a host powered off before the restart action does not need to be observed powering off
+		if restart && initial.PowerState != string(gofishredfish.OnPowerState) {
+			v.RestartObserved = true
+		}
+		v.Transitions = []bmcv1beta1.PowerStateRecord{*initial}
+	}
+	return v
+}
+
+// startPowerVerification moves the HostOperation to the running phase to wait for the target power state
+func startPowerVerification(hostOp *bmcv1beta1.HostOperation) {
+	v := hostOp.Status.PowerVerification
+	v.StartTime = time.Now().UTC().Format(time.RFC3339)
+	hostOp.Status.Status = bmcv1beta1.HostOperationStatusRunning
+	hostOp.Status.Message = fmt.Sprintf("waiting for the host to reach power state %s", v.TargetPowerState)
+}
+
+// observePowerState records the power state, and returns whether the host has reached the target state
+func observePowerState(action string, v *bmcv1beta1.PowerVerificationStatus, record *bmcv1beta1.PowerStateRecord) bool {
+	n := len(v.Transitions)
+	if isRestartAction(action) {
+		// the first sample is taken here when the power state could not be read before the action
+		if n == 0 {
+			v.BootProgressUnsupported = !hasBootProgress(record)
+		} else if hasBootProgress(record) {
+			v.BootProgressUnsupported = false
+		}
+	}
+	if n == 0 || v.Transitions[n-1].PowerState != record.PowerState || v.Transitions[n-1].BootProgress != record.BootProgress ||
+		v.Transitions[n-1].BootProgressTime != record.BootProgressTime {
+		v.Transitions = append(v.Transitions, *record)
+		if len(v.Transitions) > maxPowerTransitions {
+			v.Transitions = v.Transitions[len(v.Transitions)-maxPowerTransitions:]
+		}
+	}
+
+	if isRestartAction(action) && !v.RestartObserved {
+		// the host powers off or boots again with a newer boot progress
+		if record.PowerState != string(gofishredfish.OnPowerState) ||
+			(record.BootProgressTime != "" && record.BootProgressTime != v.InitialBootProgressTime) {
+			v.RestartObserved = true
+		}
+	}
+
+	if record.PowerState != v.TargetPowerState {
+		return false
+	}
+	return !isRestartAction(action) || v.RestartObserved
+}
+
+// unverifiedRestart returns whether the restart action on a BMC reporting no boot progress is resolved as unverified after
+// the observation window, while the host is in the target state. many BMCs keep the power state On during the restart,
+// and the short off window may be missed by the poll, so the restart is neither confirmed nor refuted without the boot
+// progress, and waiting until the timeout would block the other operations of the host for nothing
+func unverifiedRestart(action string, v *bmcv1beta1.PowerVerificationStatus, elapsed time.Duration) bool {
+	if !isRestartAction(action) || v.RestartObserved || !v.BootProgressUnsupported || v.InitialBootProgressTime != "" {
+		return false
+	}
+	if elapsed < time.Duration(v.ObservationSeconds)*time.Second {
+		return false
+	}
+	n := len(v.Transitions)
+	if n == 0 || v.Transitions[n-1].PowerState != v.TargetPowerState {
+		return false
+	}
+	for i := range v.Transitions {
+		if hasBootProgress(&v.Transitions[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// processPowerVerification polls the power state of the host until it reaches the target state or times out
+func (r *HostOperationController) processPowerVerification(ctx context.Context, hostOp *bmcv1beta1.HostOperation, logger *zap.SugaredLogger) (ctrl.Result, error) {
+	v := hostOp.Status.PowerVerification
+	start, err := time.Parse(time.RFC3339, v.StartTime)
+	if err != nil {
+		start = time.Now()
+	}
+
+	var record *bmcv1beta1.PowerStateRecord
+	d := data.HostCacheDatabase.Get(hostOp.Spec.HostStatusName)
+	if d == nil {
+		logger.Warnf("Failed to get connect config %s from cache, retry later", hostOp.Spec.HostStatusName)
+	} else if c, err := redfish.NewClient(*d, logger); err != nil {
+		// the BMC may not respond while the host restarts
+		logger.Warnf("Failed to connect %s, retry later: %v", hostOp.Spec.HostStatusName, err)
+	} else if record, err = c.GetPowerState(); err != nil {
+		logger.Warnf("Failed to get power state of %s, retry later: %v", hostOp.Spec.HostStatusName, err)
+	}
+
+	old := v.DeepCopy()
+	reached := record != nil && observePowerState(hostOp.Spec.Action, v, record)
+	elapsed := time.Since(start)
+	timeout := elapsed > time.Duration(v.TimeoutSeconds)*time.Second
+
+	switch {
+	case reached:
+		logger.Infof("%s reached power state %s after action %s", hostOp.Spec.HostStatusName, v.TargetPowerState, hostOp.Spec.Action)
+		hostOp.Status.Status = bmcv1beta1.HostOperationStatusSuccess
+		hostOp.Status.Message = fmt.Sprintf("the host reached power state %s", v.TargetPowerState)
+	case unverifiedRestart(hostOp.Spec.Action, v, elapsed):
+		logger.Warnf("the restart of %s by action %s could not be verified", hostOp.Spec.HostStatusName, hostOp.Spec.Action)
+		v.Unverified = true
+		hostOp.Status.Status = bmcv1beta1.HostOperationStatusSuccess
+		hostOp.Status.Message = fmt.Sprintf("unverified: the host is in power state %s, but it was not observed restarting within %d seconds "+
+			"and the BMC reports no boot progress", v.TargetPowerState, int32(elapsed.Seconds()))
+	case timeout:
+		last := "unknown"
+		if n := len(v.Transitions); n > 0 {
+			last = v.Transitions[n-1].PowerState
+		}
+		reason := fmt.Sprintf("the host did not reach power state %s within %d seconds, the last observed power state is %s", v.TargetPowerState, v.TimeoutSeconds, last)
+		if isRestartAction(hostOp.Spec.Action) && !v.RestartObserved {
+			reason = fmt.Sprintf("the host was not observed restarting within %d seconds, the BMC may have ignored the action", v.TimeoutSeconds)
+		}
+		logger.Errorf("action %s of %s failed: %s", hostOp.Spec.Action, hostOp.Spec.HostStatusName, reason)
+		hostOp.Status.Status = bmcv1beta1.HostOperationStatusFailed
+		hostOp.Status.Message = reason
+	default:
+		if reflect.DeepEqual(old, v) {
+			return ctrl.Result{RequeueAfter: powerVerifyInterval}, nil
+		}
+	}
+
+	hostOp.Status.LastUpdateTime = time.Now().UTC().Format(time.RFC3339)
+	if err := r.updateStatus(ctx, hostOp, logger); err != nil {
+		return ctrl.Result{}, err
+	}
+	if hostOp.Status.Status == bmcv1beta1.HostOperationStatusRunning {
+		return ctrl.Result{RequeueAfter: powerVerifyInterval}, nil
+	}
+	return ctrl.Result{}, nil
+}
@@ -0,0 +1,101 @@
+package hostoperation_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spidernet-io/bmc/pkg/agent/hostoperation"
+	bmcv1beta1 "github.com/spidernet-io/bmc/pkg/k8s/apis/bmc.spidernet.io/v1beta1"
+)
+
+var _ = Describe("PowerVerification", Label("unitest"), func() {
+	record := func(state, progress, progressTime string) *bmcv1beta1.PowerStateRecord {
+		return &bmcv1beta1.PowerStateRecord{PowerState: state, BootProgress: progress, BootProgressTime: progressTime}
+	}
+
+	It("expects the power state after each power action", func() {
+		target, restart, ok := hostoperation.PowerTarget(bmcv1beta1.BootCmdGracefulShutdown)
+		Expect(ok).To(BeTrue())
+		Expect(target).To(Equal("Off"))
+		Expect(restart).To(BeFalse())
+
+		target, restart, ok = hostoperation.PowerTarget(bmcv1beta1.BootCmdForceRestart)
+		Expect(ok).To(BeTrue())
+		Expect(target).To(Equal("On"))
+		Expect(restart).To(BeTrue())
+
+		_, _, ok = hostoperation.PowerTarget(bmcv1beta1.ActionLocateOn)
+		Expect(ok).To(BeFalse())
+		Expect(hostoperation.NewPowerVerification(bmcv1beta1.ActionLocateOn, nil, time.Minute, 0)).To(BeNil())
+	})
+
+	It("verifies the host reaches the target power state", func() {
+		v := hostoperation.NewPowerVerification(bmcv1beta1.BootCmdOn, record("Off", "", ""), 300*time.Second, 0)
+		Expect(v.TimeoutSeconds).To(Equal(int32(300)))
+		Expect(hostoperation.ObservePowerState(bmcv1beta1.BootCmdOn, v, record("PoweringOn", "", ""))).To(BeFalse())
+		Expect(hostoperation.ObservePowerState(bmcv1beta1.BootCmdOn, v, record("On", "", ""))).To(BeTrue())
+		Expect(v.Transitions).To(HaveLen(3))
+	})
+
+	It("waits for the restart to be observed by the power state or the boot progress", func() {
+		initial := record("On", "OSRunning", "2024-01-01T00:00:00Z")
+		v := hostoperation.NewPowerVerification(bmcv1beta1.BootCmdForceRestart, initial, 900*time.Second, 120*time.Second)
+		Expect(hostoperation.ObservePowerState(bmcv1beta1.BootCmdForceRestart, v, initial)).To(BeFalse())
+		Expect(hostoperation.ObservePowerState(bmcv1beta1.BootCmdForceRestart, v,
+			record("On", "OSRunning", "2024-01-01T00:05:00Z"))).To(BeTrue())
+
+		v = hostoperation.NewPowerVerification(bmcv1beta1.BootCmdGracefulRestart, initial, 900*time.Second, 120*time.Second)
+		Expect(hostoperation.ObservePowerState(bmcv1beta1.BootCmdGracefulRestart, v, record("Off", "", ""))).To(BeFalse())
+		Expect(hostoperation.ObservePowerState(bmcv1beta1.BootCmdGracefulRestart, v, initial)).To(BeTrue())
+	})
+
+	It("requires a power off for a restart when the BMC reports no boot progress", func() {
+		v := hostoperation.NewPowerVerification(bmcv1beta1.BootCmdForceRestart, record("On", "", ""), 900*time.Second, 120*time.Second)
+		Expect(v.BootProgressUnsupported).To(BeTrue())
+		Expect(v.ObservationSeconds).To(Equal(int32(120)))
+		Expect(hostoperation.ObservePowerState(bmcv1beta1.BootCmdForceRestart, v, record("On", "", ""))).To(BeFalse())
+		Expect(hostoperation.UnverifiedRestart(bmcv1beta1.BootCmdForceRestart, v, 60*time.Second)).To(BeFalse())
+		Expect(hostoperation.UnverifiedRestart(bmcv1beta1.BootCmdForceRestart, v, 121*time.Second)).To(BeTrue())
+
+		Expect(hostoperation.ObservePowerState(bmcv1beta1.BootCmdForceRestart, v, record("Off", "", ""))).To(BeFalse())
+		Expect(hostoperation.ObservePowerState(bmcv1beta1.BootCmdForceRestart, v, record("On", "", ""))).To(BeTrue())
+		Expect(hostoperation.UnverifiedRestart(bmcv1beta1.BootCmdForceRestart, v, 121*time.Second)).To(BeFalse())
+	})
+
+	It("records at the first sample that the BMC reports no boot progress when the initial power state is unknown", func() {
+		v := hostoperation.NewPowerVerification(bmcv1beta1.BootCmdGracefulRestart, nil, 900*time.Second, 120*time.Second)
+		Expect(v.BootProgressUnsupported).To(BeFalse())
+		Expect(hostoperation.ObservePowerState(bmcv1beta1.BootCmdGracefulRestart, v, record("On", "", ""))).To(BeFalse())
+		Expect(v.BootProgressUnsupported).To(BeTrue())
+		Expect(hostoperation.UnverifiedRestart(bmcv1beta1.BootCmdGracefulRestart, v, 121*time.Second)).To(BeTrue())
+
+		Expect(hostoperation.ObservePowerState(bmcv1beta1.BootCmdGracefulRestart, v, record("On", "OSRunning", ""))).To(BeFalse())
+		Expect(v.BootProgressUnsupported).To(BeFalse())
+		Expect(hostoperation.UnverifiedRestart(bmcv1beta1.BootCmdGracefulRestart, v, 121*time.Second)).To(BeFalse())
+	})
+
+	It("caps the observation window by the timeout", func() {
+		v := hostoperation.NewPowerVerification(bmcv1beta1.BootCmdForceRestart, record("On", "", ""), 60*time.Second, 120*time.Second)
+		Expect(v.ObservationSeconds).To(Equal(int32(60)))
+		v = hostoperation.NewPowerVerification(bmcv1beta1.BootCmdForceRestart, record("On", "", ""), 900*time.Second, 0)
+		Expect(v.ObservationSeconds).To(Equal(int32(900)))
+	})
+
+	It("does not treat a restart as unverified when the BMC reports boot progress or the host is not On", func() {
+		initial := record("On", "OSRunning", "2024-01-01T00:00:00Z")
+		v := hostoperation.NewPowerVerification(bmcv1beta1.BootCmdGracefulRestart, initial, 900*time.Second, 120*time.Second)
+		Expect(hostoperation.ObservePowerState(bmcv1beta1.BootCmdGracefulRestart, v, initial)).To(BeFalse())
+		Expect(hostoperation.UnverifiedRestart(bmcv1beta1.BootCmdGracefulRestart, v, 900*time.Second)).To(BeFalse())
+
+		v = hostoperation.NewPowerVerification(bmcv1beta1.BootCmdGracefulRestart, record("On", "", ""), 900*time.Second, 120*time.Second)
+		Expect(hostoperation.ObservePowerState(bmcv1beta1.BootCmdGracefulRestart, v, record("PoweringOff", "", ""))).To(BeFalse())
+		Expect(hostoperation.UnverifiedRestart(bmcv1beta1.BootCmdGracefulRestart, v, 900*time.Second)).To(BeFalse())
+	})
+
+	It("does not wait for a host powered off before the restart to power off", func() {
+		v := hostoperation.NewPowerVerification(bmcv1beta1.BootCmdResetPxeOnce, record("Off", "None", "2024-01-01T00:00:00Z"), 900*time.Second, 120*time.Second)
+		Expect(v.RestartObserved).To(BeTrue())
+		Expect(hostoperation.ObservePowerState(bmcv1beta1.BootCmdResetPxeOnce, v, record("On", "None", "2024-01-01T00:00:00Z"))).To(BeTrue())
+	})
+})
@@ -20,6 +20,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 // the lock-holding timeout is long because it needs to send http request to redfish for each host
@@ -123,27 +124,127 @@ func (c *hostStatusController) UpdateHostStatusInfo(name string, d *hoststatusda
 	updated := existing.DeepCopy()
 
 	// 检查健康状态
-	updated.Status.Healthy = healthy
+	setConnectConditions(updated, err1, err1 == nil)
+	// the capabilities change with the firmware of the BMC, so they are refreshed along with the inventory
+	refreshCapabilities := existing.Status.Capabilities == nil
 	if healthy {
-		infoData, err := client.GetInfo()
+		// the state is collected at every poll, and the inventory is only collected when it may change
+		state, err := client.GetState()
 		if err != nil {
-			log.Logger.Errorf("Failed to get info of HostStatus %s: %v", name, err)
+			log.Logger.Errorf("Failed to get state of HostStatus %s: %v", name, err)
+			// no inventory is collected, the failure is reported as the BMC being unreachable, rejecting the session or
+			// failing to collect the inventory
+			setConnectConditions(updated, err, true)
 			healthy = false
 		} else {
-			updated.Status.Info = infoData
+			info := map[string]string{}
+			for k, v := range existing.Status.Info {
+				info[k] = v
+			}
+			if reason := c.inventoryRefreshReason(existing, state); reason != "" {
+				log.Logger.Debugf("collect the inventory of HostStatus %s, because %s", name, reason)
+				refreshCapabilities = true
+				inventory, components, err := client.GetInventory()
+				setResultCondition(updated, bmcv1beta1.HostStatusConditionInventoryCollected, err)
+				if err != nil {
+					log.Logger.Errorf("Failed to get inventory of HostStatus %s: %v", name, err)
+					healthy = false
+				} else {
+					info = inventory
+					updated.Status.Hardware = components
+					c.recordHardwareChanges(existing, updated)
+					c.syncIdentity(client, existing, updated)
+					updated.Status.Inventory = &bmcv1beta1.InventoryStatus{
+						LastCollectTime: time.Now().UTC().Format(time.RFC3339),
+						RefreshRequest:  existing.Annotations[bmcv1beta1.AnnotationRefreshInventory],
+					}
+				}
+			}
+			if healthy {
+				for k, v := range state {
+					info[k] = v
+				}
+				updated.Status.Info = info
+				setPowerCondition(updated, state["PowerState"])
+			}
 		}
 	}
+	updated.Status.Healthy = healthy
+	if !healthy && bmcResetting(existing.Status) {
+		// the BMC is restarting, keep the last status until the grace period expires
+		log.Logger.Infof("HostStatus %s is not reachable, but its BMC is restarting until %s, skip updating the status", name, existing.Status.BmcResettingUntil)
+		return false, nil
+	}
+	c.schedulePoll(existing, updated, healthy)
+	if updated.Status.BmcResettingUntil != "" && !bmcResetting(existing.Status) {
+		log.Logger.Infof("the BMC restart window of HostStatus %s ends", name)
+		updated.Status.BmcResettingUntil = ""
+	}
 	if !healthy {
 		log.Logger.Debugf("HostStatus %s is not healthy, set info to empty", name)
 		updated.Status.Info = map[string]string{}
+		updated.Status.Power = nil
+		updated.Status.Location = nil
+		setUnknownConditions(updated, bmcv1beta1.HostStatusConditionLogsCollected, bmcv1beta1.HostStatusConditionPowerOn)
 	}
 	if updated.Status.Healthy != existing.Status.Healthy {
 		log.Logger.Infof("HostStatus %s change from %v to %v , update status", name, existing.Status.Healthy, healthy)
 	}
 
+	// 获取功耗和功率上限
+	if healthy {
+		power, err := client.GetPower()
+		if err != nil {
+			// not all BMCs support power control, so it does not affect the healthy
+			log.Logger.Debugf("Failed to get power of HostStatus %s: %v", name, err)
+			updated.Status.Power = nil
+		} else {
+			if changed, err := c.syncPowerLimit(client, existing, power); err != nil {
+				log.Logger.Errorf("Failed to sync power limit of HostStatus %s: %v", name, err)
+			} else if changed {
+				if p, err := client.GetPower(); err == nil {
+					power = p
+				}
+			}
+			if powerStatusEqual(power, existing.Status.Power) {
+				// ignore the slight change of the power consumption
+				updated.Status.Power = existing.Status.Power
+			} else {
+				updated.Status.Power = power
+			}
+		}
+	}
+
+	// 获取物理位置
+	if healthy {
+		location, err := client.GetLocation()
+		if err != nil {
+			log.Logger.Debugf("Failed to get location of HostStatus %s: %v", name, err)
+			updated.Status.Location = nil
+		} else {
+			updated.Status.Location = location
+		}
+	}
+
+	// 获取 BMC 支持的能力，它们很少变化，和 inventory 一起采集
+	if healthy && refreshCapabilities {
+		capabilities, err := client.GetCapabilities()
+		if err != nil {
+			log.Logger.Debugf("Failed to get capabilities of HostStatus %s: %v", name, err)
+		} else {
+			updated.Status.Capabilities = capabilities
+		}
+	}
+
+	// 保持期望的电源状态
+	if healthy {
+		c.syncDesiredPower(client, existing, updated)
+	}
+
 	// 获取日志
 	if healthy {
 		logEntrys, err := client.GetLog()
+		setResultCondition(updated, bmcv1beta1.HostStatusConditionLogsCollected, err)
 		if err != nil {
 			log.Logger.Errorf("Failed to get logs of HostStatus %s: %v", name, err)
 		} else {
@@ -172,7 +273,7 @@ func (c *hostStatusController) UpdateHostStatusInfo(name string, d *hoststatusda
 	if !compareHostStatus(updated.Status, existing.Status, log.Logger) {
 		log.Logger.Debugf("status changed, existing: %v, updated: %v", existing.Status, updated.Status)
 		updated.Status.LastUpdateTime = time.Now().UTC().Format(time.RFC3339)
-		if err := c.client.Status().Update(context.Background(), updated); err != nil {
+		if err := c.patchStatus(existing, updated); err != nil {
 			log.Logger.Errorf("Failed to update status of HostStatus %s: %v", name, err)
 			return true, err
 		}
@@ -182,6 +283,14 @@ func (c *hostStatusController) UpdateHostStatusInfo(name string, d *hoststatusda
 	return false, nil
 }
 
+// patchStatus writes the changes of the poll to the status. the status is also written by the other controllers while
+// the BMC is queried, such as the node name and the remediation, and an update would conflict with them. the poll has
+// emitted the hardware change events and created the operations of the desired power by then, which would be replayed
+// by the next poll, so only the fields changed by the poll are patched, without the resource version
+func (c *hostStatusController) patchStatus(existing, updated *bmcv1beta1.HostStatus) error {
+	return c.client.Status().Patch(context.Background(), updated, client.MergeFrom(existing))
+}
+
 // this is called by UpdateHostStatusAtInterval and
 func (c *hostStatusController) UpdateHostStatusInfoWrapper(name string) error {
 	syncData := make(map[string]hoststatusdata.HostConnectCon)
@@ -204,7 +313,15 @@ func (c *hostStatusController) UpdateHostStatusInfoWrapper(name string) error {
 		modeinfo = " during hoststatus reconcile"
 	}
 
+	now := time.Now()
 	for item, t := range syncData {
+		if len(name) == 0 {
+			if !c.poller.due(item, now) {
+				continue
+			}
+			// it is rescheduled with the backoff after the poll
+			c.poller.set(item, now.Add(time.Duration(c.config.HostStatusUpdateInterval)*time.Second))
+		}
 		log.Logger.Debugf("updating status of the hostStatus %s", item)
 		if updated, err := c.UpdateHostStatusInfo(item, &t); err != nil {
 			log.Logger.Errorf("failed to update HostStatus %s %s: %v", item, modeinfo, err)
@@ -222,10 +339,10 @@ func (c *hostStatusController) UpdateHostStatusInfoWrapper(name string) error {
 
 // ------------------------------  hoststatus spec.info 的	周期更新
 func (c *hostStatusController) UpdateHostStatusAtInterval() {
-	interval := time.Duration(c.config.HostStatusUpdateInterval) * time.Second
-	ticker := time.NewTicker(interval)
+	ticker := time.NewTicker(pollTickInterval)
 	defer ticker.Stop()
-	log.Logger.Infof("begin to update all hostStatus at interval of %v seconds", c.config.HostStatusUpdateInterval)
+	log.Logger.Infof("begin to update all hostStatus at interval of %v seconds, and back off the unhealthy ones up to %v seconds",
+		c.config.HostStatusUpdateInterval, c.config.HostStatusMaxBackoffInterval)
 
 	for {
 		select {
@@ -233,7 +350,7 @@ func (c *hostStatusController) UpdateHostStatusAtInterval() {
 			log.Logger.Info("Stopping UpdateHostStatusAtInterval")
 			return
 		case <-ticker.C:
-			log.Logger.Debugf("update all hostStatus at interval ")
+			log.Logger.Debugf("update the hostStatus due to be polled")
 			if err := c.UpdateHostStatusInfoWrapper(""); err != nil {
 				log.Logger.Errorf("Failed to update host status: %v", err)
 			}
@@ -272,6 +389,11 @@ func (c *hostStatusController) processHostStatus(hostStatus *bmcv1beta1.HostStat
 		DhcpHost: hostStatus.Status.Basic.Type == bmcv1beta1.HostTypeDHCP,
 	})
 
+	if inventoryRefreshRequested(hostStatus) {
+		logger.Infof("the inventory of HostStatus %s is requested to be refreshed by annotation %s", hostStatus.Name, bmcv1beta1.AnnotationRefreshInventory)
+		c.poller.trigger(hostStatus.Name)
+	}
+
 	if len(hostStatus.Status.Info) == 0 {
 		if err := c.UpdateHostStatusInfoWrapper(hostStatus.Name); err != nil {
 			logger.Errorf("failed to update HostStatus %s: %v", hostStatus.Name, err)
@@ -300,6 +422,7 @@ func (c *hostStatusController) Reconcile(ctx context.Context, req ctrl.Request)
 		if errors.IsNotFound(err) {
 			logger.Debugf("HostStatus not found, delete from cache")
 			hoststatusdata.HostCacheDatabase.Delete(req.Name)
+			c.poller.remove(req.Name)
 			return ctrl.Result{}, nil
 		}
 		logger.Error(err, "Failed to get HostStatus")
@@ -0,0 +1,52 @@
+package hoststatus_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spidernet-io/bmc/pkg/agent/hoststatus"
+	bmcv1beta1 "github.com/spidernet-io/bmc/pkg/k8s/apis/bmc.spidernet.io/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("PatchStatus", Label("unitest"), func() {
+	It("writes the changes of the poll without conflicting with the status written meanwhile", func() {
+		ctx := context.Background()
+		scheme := runtime.NewScheme()
+		Expect(bmcv1beta1.AddToScheme(scheme)).To(Succeed())
+		host := &bmcv1beta1.HostStatus{
+			ObjectMeta: metav1.ObjectMeta{Name: "host1"},
+			Status: bmcv1beta1.HostStatusStatus{
+				Healthy:      true,
+				DesiredPower: &bmcv1beta1.DesiredPowerStatus{State: bmcv1beta1.DesiredPowerStateOn, Attempts: 1},
+				Info:         map[string]string{"PowerState": "Off"},
+			},
+		}
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(host).WithStatusSubresource(&bmcv1beta1.HostStatus{}).Build()
+
+		existing := &bmcv1beta1.HostStatus{}
+		Expect(c.Get(ctx, client.ObjectKey{Name: "host1"}, existing)).To(Succeed())
+
+		// the node is mapped to the host while the BMC is queried
+		mapped := existing.DeepCopy()
+		patch := client.MergeFrom(mapped.DeepCopy())
+		mapped.Status.NodeName = "node1"
+		Expect(c.Status().Patch(ctx, mapped, patch)).To(Succeed())
+
+		// the poll creates an operation of the desired power and records the attempt
+		updated := existing.DeepCopy()
+		updated.Status.DesiredPower.Attempts = 2
+		updated.Status.Info["PowerState"] = "On"
+		Expect(hoststatus.PatchStatus(c, existing, updated)).To(Succeed())
+
+		latest := &bmcv1beta1.HostStatus{}
+		Expect(c.Get(ctx, client.ObjectKey{Name: "host1"}, latest)).To(Succeed())
+		Expect(latest.Status.NodeName).To(Equal("node1"))
+		Expect(latest.Status.DesiredPower.Attempts).To(Equal(int32(2)))
+		Expect(latest.Status.Info).To(HaveKeyWithValue("PowerState", "On"))
+	})
+})
@@ -0,0 +1,82 @@
+package hoststatus
+
+import (
+	bmcv1beta1 "github.com/spidernet-io/bmc/pkg/k8s/apis/bmc.spidernet.io/v1beta1"
+	"github.com/spidernet-io/bmc/pkg/log"
+	"github.com/spidernet-io/bmc/pkg/redfish"
+	gofishredfish "github.com/stmcginnis/gofish/redfish"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// setCondition updates the condition of the hostStatus, the lastTransitionTime only changes with the status
+func setCondition(hostStatus *bmcv1beta1.HostStatus, conditionType string, status metav1.ConditionStatus, reason, message string) {
+	changed := meta.SetStatusCondition(&hostStatus.Status.Conditions, metav1.Condition{
+		Type:               conditionType,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: hostStatus.Generation,
+	})
+	if changed {
+		log.Logger.Debugf("condition %s of hostStatus %s changes to %s, reason %s: %s", conditionType, hostStatus.Name, status, reason, message)
+	}
+}
+
+// setResultCondition sets the condition by the result of a redfish request
+func setResultCondition(hostStatus *bmcv1beta1.HostStatus, conditionType string, err error) {
+	if err == nil {
+		setCondition(hostStatus, conditionType, metav1.ConditionTrue, bmcv1beta1.HostStatusReasonSucceeded, "")
+		return
+	}
+	setCondition(hostStatus, conditionType, metav1.ConditionFalse, redfish.ClassifyError(err), err.Error())
+}
+
+// setUnknownConditions marks the conditions as unknown when they are not checked because a previous check fails
+func setUnknownConditions(hostStatus *bmcv1beta1.HostStatus, conditionTypes ...string) {
+	for _, t := range conditionTypes {
+		setCondition(hostStatus, t, metav1.ConditionUnknown, bmcv1beta1.HostStatusReasonNotConnected, "the BMC is not connected")
+	}
+}
+
+// setConnectConditions sets the Reachable, Authenticated and InventoryCollected conditions by the error of connecting the BMC
+// or querying its state, loggedIn tells whether the session of the BMC is created. only a rejected session means the
+// BMC does not accept the credentials, the other failures of a BMC which answers, such as a 5xx or a malformed payload,
+// are reported on InventoryCollected
+func setConnectConditions(hostStatus *bmcv1beta1.HostStatus, err error, loggedIn bool) {
+	switch reason := redfish.ClassifyError(err); reason {
+	case bmcv1beta1.HostStatusReasonSucceeded:
+		setCondition(hostStatus, bmcv1beta1.HostStatusConditionReachable, metav1.ConditionTrue, reason, "")
+		setCondition(hostStatus, bmcv1beta1.HostStatusConditionAuthenticated, metav1.ConditionTrue, reason, "")
+	case bmcv1beta1.HostStatusReasonNetworkUnreachable, bmcv1beta1.HostStatusReasonTLSError:
+		setCondition(hostStatus, bmcv1beta1.HostStatusConditionReachable, metav1.ConditionFalse, reason, err.Error())
+		setUnknownConditions(hostStatus, bmcv1beta1.HostStatusConditionAuthenticated, bmcv1beta1.HostStatusConditionInventoryCollected)
+	case bmcv1beta1.HostStatusReasonUnauthorized:
+		// the BMC answers, but it fails to create the session or rejects the requests of the session
+		setCondition(hostStatus, bmcv1beta1.HostStatusConditionReachable, metav1.ConditionTrue, bmcv1beta1.HostStatusReasonSucceeded, "")
+		setCondition(hostStatus, bmcv1beta1.HostStatusConditionAuthenticated, metav1.ConditionFalse, reason, err.Error())
+		setUnknownConditions(hostStatus, bmcv1beta1.HostStatusConditionInventoryCollected)
+	default:
+		setCondition(hostStatus, bmcv1beta1.HostStatusConditionReachable, metav1.ConditionTrue, bmcv1beta1.HostStatusReasonSucceeded, "")
+		if loggedIn {
+			setCondition(hostStatus, bmcv1beta1.HostStatusConditionAuthenticated, metav1.ConditionTrue, bmcv1beta1.HostStatusReasonSucceeded, "")
+		} else {
+			// the login fails for another reason, so the credentials are neither accepted nor rejected
+			setCondition(hostStatus, bmcv1beta1.HostStatusConditionAuthenticated, metav1.ConditionUnknown, reason, err.Error())
+		}
+		setCondition(hostStatus, bmcv1beta1.HostStatusConditionInventoryCollected, metav1.ConditionFalse, reason, err.Error())
+	}
+}
+
+// setPowerCondition sets the PowerOn condition by the power state of the system
+func setPowerCondition(hostStatus *bmcv1beta1.HostStatus, powerState string) {
+	switch powerState {
+	case string(gofishredfish.OnPowerState):
+		setCondition(hostStatus, bmcv1beta1.HostStatusConditionPowerOn, metav1.ConditionTrue, bmcv1beta1.HostStatusReasonPowerOn, "")
+	case string(gofishredfish.OffPowerState):
+		setCondition(hostStatus, bmcv1beta1.HostStatusConditionPowerOn, metav1.ConditionFalse, bmcv1beta1.HostStatusReasonPowerOff, "")
+	default:
+		setCondition(hostStatus, bmcv1beta1.HostStatusConditionPowerOn, metav1.ConditionUnknown, bmcv1beta1.HostStatusReasonPowerUnknown,
+			"the power state is "+powerState)
+	}
+}
@@ -0,0 +1,104 @@
+package hoststatus_test
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/url"
+	"os"
+	"syscall"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spidernet-io/bmc/pkg/agent/hoststatus"
+	bmcv1beta1 "github.com/spidernet-io/bmc/pkg/k8s/apis/bmc.spidernet.io/v1beta1"
+	"github.com/stmcginnis/gofish/common"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("Conditions", Label("unitest"), func() {
+	request := func(err error) error {
+		return &url.Error{Op: "Get", URL: "https://192.168.0.10/redfish/v1/", Err: err}
+	}
+
+	DescribeTable("sets the Reachable, Authenticated and InventoryCollected conditions by the error of connecting the BMC",
+		func(err error, loggedIn bool, reachable metav1.ConditionStatus, reachableReason string, authenticated metav1.ConditionStatus, authenticatedReason string,
+			inventory metav1.ConditionStatus, inventoryReason string) {
+			hostStatus := &bmcv1beta1.HostStatus{}
+			hoststatus.SetConnectConditions(hostStatus, err, loggedIn)
+
+			c := meta.FindStatusCondition(hostStatus.Status.Conditions, bmcv1beta1.HostStatusConditionReachable)
+			Expect(c).NotTo(BeNil())
+			Expect(c.Status).To(Equal(reachable))
+			Expect(c.Reason).To(Equal(reachableReason))
+
+			c = meta.FindStatusCondition(hostStatus.Status.Conditions, bmcv1beta1.HostStatusConditionAuthenticated)
+			Expect(c).NotTo(BeNil())
+			Expect(c.Status).To(Equal(authenticated))
+			Expect(c.Reason).To(Equal(authenticatedReason))
+			if authenticated == metav1.ConditionFalse {
+				Expect(c.Message).To(Equal(err.Error()))
+			}
+
+			c = meta.FindStatusCondition(hostStatus.Status.Conditions, bmcv1beta1.HostStatusConditionInventoryCollected)
+			if inventory == "" {
+				Expect(c).To(BeNil())
+				return
+			}
+			Expect(c).NotTo(BeNil())
+			Expect(c.Status).To(Equal(inventory))
+			Expect(c.Reason).To(Equal(inventoryReason))
+			if inventory == metav1.ConditionFalse {
+				Expect(c.Message).To(Equal(err.Error()))
+			}
+		},
+		Entry("connected", nil, true,
+			metav1.ConditionTrue, bmcv1beta1.HostStatusReasonSucceeded, metav1.ConditionTrue, bmcv1beta1.HostStatusReasonSucceeded,
+			metav1.ConditionStatus(""), ""),
+		Entry("401", &common.Error{HTTPReturnedStatusCode: 401}, false,
+			metav1.ConditionTrue, bmcv1beta1.HostStatusReasonSucceeded, metav1.ConditionFalse, bmcv1beta1.HostStatusReasonUnauthorized,
+			metav1.ConditionUnknown, bmcv1beta1.HostStatusReasonNotConnected),
+		Entry("401 of an expired session", &common.Error{HTTPReturnedStatusCode: 401}, true,
+			metav1.ConditionTrue, bmcv1beta1.HostStatusReasonSucceeded, metav1.ConditionFalse, bmcv1beta1.HostStatusReasonUnauthorized,
+			metav1.ConditionUnknown, bmcv1beta1.HostStatusReasonNotConnected),
+		Entry("TLS", request(tls.RecordHeaderError{Msg: "first record does not look like a TLS handshake"}), false,
+			metav1.ConditionFalse, bmcv1beta1.HostStatusReasonTLSError, metav1.ConditionUnknown, bmcv1beta1.HostStatusReasonNotConnected,
+			metav1.ConditionUnknown, bmcv1beta1.HostStatusReasonNotConnected),
+		Entry("DNS", request(&net.DNSError{Err: "no such host", Name: "bmc1.example.com", IsNotFound: true}), false,
+			metav1.ConditionFalse, bmcv1beta1.HostStatusReasonNetworkUnreachable, metav1.ConditionUnknown, bmcv1beta1.HostStatusReasonNotConnected,
+			metav1.ConditionUnknown, bmcv1beta1.HostStatusReasonNotConnected),
+		Entry("connection refused", request(&net.OpError{Op: "dial", Net: "tcp", Err: os.NewSyscallError("connect", syscall.ECONNREFUSED)}), false,
+			metav1.ConditionFalse, bmcv1beta1.HostStatusReasonNetworkUnreachable, metav1.ConditionUnknown, bmcv1beta1.HostStatusReasonNotConnected,
+			metav1.ConditionUnknown, bmcv1beta1.HostStatusReasonNotConnected),
+		Entry("500 during the login", &common.Error{HTTPReturnedStatusCode: 500}, false,
+			metav1.ConditionTrue, bmcv1beta1.HostStatusReasonSucceeded, metav1.ConditionUnknown, bmcv1beta1.HostStatusReasonRequestFailed,
+			metav1.ConditionFalse, bmcv1beta1.HostStatusReasonRequestFailed),
+		Entry("500 after the login", &common.Error{HTTPReturnedStatusCode: 500}, true,
+			metav1.ConditionTrue, bmcv1beta1.HostStatusReasonSucceeded, metav1.ConditionTrue, bmcv1beta1.HostStatusReasonSucceeded,
+			metav1.ConditionFalse, bmcv1beta1.HostStatusReasonRequestFailed),
+		Entry("malformed payload", json.Unmarshal([]byte("<html></html>"), &map[string]interface{}{}), true,
+			metav1.ConditionTrue, bmcv1beta1.HostStatusReasonSucceeded, metav1.ConditionTrue, bmcv1beta1.HostStatusReasonSucceeded,
+			metav1.ConditionFalse, bmcv1beta1.HostStatusReasonSchemaError),
+		Entry("fallback", errors.New("no system found"), true,
+			metav1.ConditionTrue, bmcv1beta1.HostStatusReasonSucceeded, metav1.ConditionTrue, bmcv1beta1.HostStatusReasonSucceeded,
+			metav1.ConditionFalse, bmcv1beta1.HostStatusReasonSchemaError),
+	)
+
+	It("keeps the transition time while the conditions do not change", func() {
+		hostStatus := &bmcv1beta1.HostStatus{}
+		hoststatus.SetConnectConditions(hostStatus, &common.Error{HTTPReturnedStatusCode: 401}, false)
+		c := meta.FindStatusCondition(hostStatus.Status.Conditions, bmcv1beta1.HostStatusConditionAuthenticated)
+		c.LastTransitionTime = metav1.Unix(0, 0)
+
+		hoststatus.SetConnectConditions(hostStatus, &common.Error{HTTPReturnedStatusCode: 403}, false)
+		c = meta.FindStatusCondition(hostStatus.Status.Conditions, bmcv1beta1.HostStatusConditionAuthenticated)
+		Expect(c.LastTransitionTime).To(Equal(metav1.Unix(0, 0)))
+
+		hoststatus.SetConnectConditions(hostStatus, nil, true)
+		c = meta.FindStatusCondition(hostStatus.Status.Conditions, bmcv1beta1.HostStatusConditionAuthenticated)
+		Expect(c.Status).To(Equal(metav1.ConditionTrue))
+		Expect(c.LastTransitionTime).NotTo(Equal(metav1.Unix(0, 0)))
+	})
+})
@@ -0,0 +1,237 @@
+package hoststatus
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spidernet-io/bmc/pkg/hostpolicy"
+	bmcv1beta1 "github.com/spidernet-io/bmc/pkg/k8s/apis/bmc.spidernet.io/v1beta1"
+	"github.com/spidernet-io/bmc/pkg/log"
+	"github.com/spidernet-io/bmc/pkg/redfish"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	defaultDesiredPowerGracePeriod = 300 * time.Second
+	defaultDesiredPowerMaxAttempts = 3
+)
+
+// hostBusy returns whether an operation or a workflow is in progress on the host,
+// the desired power state is not enforced during it
+func (c *hostStatusController) hostBusy(ctx context.Context, name string) (bool, error) {
+	ops := &bmcv1beta1.HostOperationList{}
+	if err := c.client.List(ctx, ops, client.MatchingFields{bmcv1beta1.HostOperationHostStatusNameIndex: name}); err != nil {
+		return false, err
+	}
+	for _, op := range ops.Items {
+		switch op.Status.Status {
+		case "", bmcv1beta1.HostOperationStatusPending, bmcv1beta1.HostOperationStatusRunning:
+			return true, nil
+		}
+	}
+	wfs := &bmcv1beta1.HostWorkflowList{}
+	if err := c.client.List(ctx, wfs); err != nil {
+		return false, err
+	}
+	for _, wf := range wfs.Items {
+		if wf.Spec.HostStatusName == name && wf.Status.Phase != bmcv1beta1.HostWorkflowPhaseSucceeded && wf.Status.Phase != bmcv1beta1.HostWorkflowPhaseFailed {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// hostPool returns the HostPool selecting the host, the HostPool powers on and off its hosts instead of the HostPolicy
+func (c *hostStatusController) hostPool(ctx context.Context, hostStatus *bmcv1beta1.HostStatus) (string, error) {
+	pools := &bmcv1beta1.HostPoolList{}
+	if err := c.client.List(ctx, pools); err != nil {
+		return "", err
+	}
+	for _, pool := range pools.Items {
+		if pool.Spec.Selector == nil {
+			continue
+		}
+		selector, err := metav1.LabelSelectorAsSelector(pool.Spec.Selector)
+		if err != nil {
+			return "", fmt.Errorf("invalid selector of HostPool %s: %v", pool.Name, err)
+		}
+		if !selector.Empty() && selector.Matches(labels.Set(hostStatus.Labels)) {
+			return pool.Name, nil
+		}
+	}
+	return "", nil
+}
+
+func parseTime(s string) (time.Time, bool) {
+	if s == "" {
+		return time.Time{}, false
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	return t, err == nil
+}
+
+// syncDesiredPower powers on or off the host toward the desired power state declared by the HostPolicy through a HostOperation,
+// and records the progress in the status
+func (c *hostStatusController) syncDesiredPower(client redfish.RefishClient, existing, updated *bmcv1beta1.HostStatus) {
+	ctx := context.Background()
+	policies := &bmcv1beta1.HostPolicyList{}
+	if err := c.client.List(ctx, policies); err != nil {
+		log.Logger.Errorf("Failed to list HostPolicies: %v", err)
+		return
+	}
+	desired, policy, err := hostpolicy.DesiredPower(policies.Items, existing)
+	if err != nil {
+		updated.Status.DesiredPower = &bmcv1beta1.DesiredPowerStatus{Message: err.Error()}
+		return
+	}
+	if desired == nil {
+		updated.Status.DesiredPower = nil
+		return
+	}
+
+	status := existing.Status.DesiredPower.DeepCopy()
+	if status == nil || status.State != desired.State || status.Policy != policy {
+		status = &bmcv1beta1.DesiredPowerStatus{State: desired.State, Policy: policy}
+	}
+	updated.Status.DesiredPower = status
+	if existing.Annotations[bmcv1beta1.AnnotationPauseDesiredPower] == "true" {
+		status.Message = fmt.Sprintf("paused by annotation %s", bmcv1beta1.AnnotationPauseDesiredPower)
+		return
+	}
+	// the remediation powers off the host until the node becomes Ready
+	if existing.Status.Remediation != nil {
+		status.MismatchSince = ""
+		status.Message = fmt.Sprintf("paused during the remediation at step %s", existing.Status.Remediation.Step)
+		return
+	}
+	pool, err := c.hostPool(ctx, existing)
+	if err != nil {
+		log.Logger.Errorf("Failed to check the HostPools of HostStatus %s: %v", existing.Name, err)
+		return
+	}
+	if pool != "" {
+		status.MismatchSince = ""
+		status.Message = fmt.Sprintf("the power is managed by HostPool %s", pool)
+		return
+	}
+
+	current, err := client.GetPowerState()
+	if err != nil {
+		log.Logger.Debugf("Failed to get power state of HostStatus %s: %v", existing.Name, err)
+		return
+	}
+	if current.PowerState == desired.State {
+		status.MismatchSince = ""
+		status.Attempts = 0
+		status.LastAttemptTime = ""
+		status.Message = ""
+		return
+	}
+
+	now := time.Now().UTC()
+	busy, err := c.hostBusy(ctx, existing.Name)
+	if err != nil {
+		log.Logger.Errorf("Failed to check the operations of HostStatus %s: %v", existing.Name, err)
+		return
+	}
+	if busy {
+		// the grace period restarts after the operation
+		status.MismatchSince = now.Format(time.RFC3339)
+		status.Message = fmt.Sprintf("the power state is %s, wait for the operation in progress", current.PowerState)
+		return
+	}
+	since, ok := parseTime(status.MismatchSince)
+	if !ok {
+		status.MismatchSince = now.Format(time.RFC3339)
+		status.Message = fmt.Sprintf("the power state is %s, wait for the grace period", current.PowerState)
+		return
+	}
+
+	grace := defaultDesiredPowerGracePeriod
+	if desired.GracePeriodSeconds != nil {
+		grace = time.Duration(*desired.GracePeriodSeconds) * time.Second
+	}
+	if now.Sub(since) < grace {
+		return
+	}
+	if last, ok := parseTime(status.LastAttemptTime); ok && now.Sub(last) < grace {
+		return
+	}
+
+	t := &corev1.ObjectReference{
+		Kind:       bmcv1beta1.KindHostStatus,
+		Name:       existing.Name,
+		Namespace:  c.config.PodNamespace,
+		APIVersion: bmcv1beta1.APIVersion,
+	}
+	maxAttempts := int32(defaultDesiredPowerMaxAttempts)
+	if desired.MaxAttempts != nil {
+		maxAttempts = *desired.MaxAttempts
+	}
+	if status.Attempts >= maxAttempts {
+		msg := fmt.Sprintf("gave up after %d attempts, the power state is still %s", status.Attempts, current.PowerState)
+		if status.Message != msg {
+			log.Logger.Warnf("HostStatus %s: %s", existing.Name, msg)
+			c.recorder.Event(t, corev1.EventTypeWarning, "DesiredPowerGaveUp", fmt.Sprintf("%s while HostPolicy %s desires %s", msg, policy, desired.State))
+		}
+		status.Message = msg
+		return
+	}
+
+	action := bmcv1beta1.BootCmdOn
+	if desired.State == bmcv1beta1.DesiredPowerStateOff {
+		// shut down gracefully, and force it at the last attempt
+		action = bmcv1beta1.BootCmdGracefulShutdown
+		if status.Attempts+1 >= maxAttempts {
+			action = bmcv1beta1.BootCmdForceOff
+		}
+	}
+	// the protections and the maintenance windows apply to the enforcement as well, it waits until they allow the action
+	reasons, err := hostpolicy.Evaluate(policies.Items, existing, action, now)
+	if err != nil {
+		log.Logger.Errorf("Failed to evaluate HostPolicies of HostStatus %s: %v", existing.Name, err)
+		return
+	}
+	if len(reasons) > 0 {
+		status.Message = fmt.Sprintf("the power state is %s, %s is denied: %s", current.PowerState, action, strings.Join(reasons, "; "))
+		return
+	}
+
+	msg := fmt.Sprintf("the power state is %s while HostPolicy %s desires %s, %s the host (attempt %d/%d)",
+		current.PowerState, policy, desired.State, action, status.Attempts+1, maxAttempts)
+	// the HostOperation is admitted by the webhook, and audited with the events of the operation
+	op := &bmcv1beta1.HostOperation{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   fmt.Sprintf("%s-desiredpower-%s-%d", existing.Name, strings.ToLower(action), now.Unix()),
+			Labels: map[string]string{bmcv1beta1.LabelHostStatusName: bmcv1beta1.LabelValue(existing.Name)},
+		},
+		Spec: bmcv1beta1.HostOperationSpec{
+			HostOperationActionSpec: bmcv1beta1.HostOperationActionSpec{Action: action},
+			HostStatusName:          existing.Name,
+			Reason:                  msg,
+		},
+	}
+	if err := c.client.Create(ctx, op); err != nil {
+		if !errors.IsForbidden(err) && !errors.IsInvalid(err) {
+			// try again at the next poll
+			log.Logger.Errorf("Failed to create HostOperation %s: %v", op.Name, err)
+			return
+		}
+		status.Attempts++
+		status.LastAttemptTime = now.Format(time.RFC3339)
+		status.Message = fmt.Sprintf("attempt %d is denied: %v", status.Attempts, err)
+		c.recorder.Event(t, corev1.EventTypeWarning, "DesiredPowerFailed", fmt.Sprintf("%s: %v", msg, err))
+		return
+	}
+	status.Attempts++
+	status.LastAttemptTime = now.Format(time.RFC3339)
+	log.Logger.Infof("HostStatus %s: %s", existing.Name, msg)
+	status.Message = fmt.Sprintf("%s by HostOperation %s at attempt %d", action, op.Name, status.Attempts)
+	c.recorder.Event(t, corev1.EventTypeWarning, "DesiredPowerEnforced", msg)
+}
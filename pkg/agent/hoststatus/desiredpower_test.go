@@ -0,0 +1,124 @@
+package hoststatus_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spidernet-io/bmc/pkg/agent/config"
+	"github.com/spidernet-io/bmc/pkg/agent/hoststatus"
+	bmcv1beta1 "github.com/spidernet-io/bmc/pkg/k8s/apis/bmc.spidernet.io/v1beta1"
+	"github.com/spidernet-io/bmc/pkg/redfish"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// powerStateClient reports the power state, and fails the test when the power is changed through the BMC directly
+type powerStateClient struct {
+	redfish.RefishClient
+	state string
+}
+
+func (p *powerStateClient) GetPowerState() (*bmcv1beta1.PowerStateRecord, error) {
+	return &bmcv1beta1.PowerStateRecord{PowerState: p.state}, nil
+}
+
+func (p *powerStateClient) Power(action string) (string, error) {
+	Fail("the power is changed without a HostOperation")
+	return "", nil
+}
+
+var _ = Describe("DesiredPower", Label("unitest"), func() {
+	var (
+		ctx     context.Context
+		objects []client.Object
+		host    *bmcv1beta1.HostStatus
+		policy  *bmcv1beta1.HostPolicy
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		policy = &bmcv1beta1.HostPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "always-on"},
+			Spec: bmcv1beta1.HostPolicySpec{
+				Selector:     &metav1.LabelSelector{MatchLabels: map[string]string{"role": "database"}},
+				DesiredPower: &bmcv1beta1.DesiredPowerSpec{State: bmcv1beta1.DesiredPowerStateOn},
+			},
+		}
+		host = &bmcv1beta1.HostStatus{
+			ObjectMeta: metav1.ObjectMeta{Name: "host1", Labels: map[string]string{"role": "database"}},
+			Status: bmcv1beta1.HostStatusStatus{
+				Healthy: true,
+				// the power state is changed long ago
+				DesiredPower: &bmcv1beta1.DesiredPowerStatus{
+					State:         bmcv1beta1.DesiredPowerStateOn,
+					Policy:        "always-on",
+					MismatchSince: time.Now().Add(-time.Hour).UTC().Format(time.RFC3339),
+				},
+			},
+		}
+		objects = nil
+	})
+
+	sync := func(state string) (*bmcv1beta1.DesiredPowerStatus, []bmcv1beta1.HostOperation) {
+		scheme := runtime.NewScheme()
+		Expect(bmcv1beta1.AddToScheme(scheme)).To(Succeed())
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(append(objects, policy, host)...).
+			WithIndex(&bmcv1beta1.HostOperation{}, bmcv1beta1.HostOperationHostStatusNameIndex, func(obj client.Object) []string {
+				return []string{obj.(*bmcv1beta1.HostOperation).Spec.HostStatusName}
+			}).Build()
+		status := hoststatus.SyncDesiredPower(c, &config.AgentConfig{ClusterAgentName: "agent1"}, &powerStateClient{state: state}, host)
+		ops := &bmcv1beta1.HostOperationList{}
+		Expect(c.List(ctx, ops)).To(Succeed())
+		return status, ops.Items
+	}
+
+	It("powers on the host through a HostOperation", func() {
+		status, ops := sync("Off")
+		Expect(ops).To(HaveLen(1))
+		Expect(ops[0].Spec.Action).To(Equal(bmcv1beta1.BootCmdOn))
+		Expect(ops[0].Spec.HostStatusName).To(Equal("host1"))
+		Expect(ops[0].Spec.Reason).To(ContainSubstring("HostPolicy always-on desires On"))
+		Expect(status.Attempts).To(Equal(int32(1)))
+		Expect(status.Message).To(ContainSubstring(ops[0].Name))
+	})
+
+	It("does nothing when the power state is desired", func() {
+		status, ops := sync("On")
+		Expect(ops).To(BeEmpty())
+		Expect(status.MismatchSince).To(BeEmpty())
+	})
+
+	It("waits while the HostPolicy denies the action", func() {
+		one := int32(1)
+		policy.Spec.DesiredPower = &bmcv1beta1.DesiredPowerSpec{State: bmcv1beta1.DesiredPowerStateOff, MaxAttempts: &one}
+		policy.Spec.Protected = true
+		host.Status.DesiredPower.State = bmcv1beta1.DesiredPowerStateOff
+		status, ops := sync("On")
+		Expect(ops).To(BeEmpty())
+		Expect(status.Attempts).To(BeZero())
+		Expect(status.Message).To(ContainSubstring("ForceOff is denied: the host is protected by HostPolicy always-on"))
+	})
+
+	It("leaves the host selected by a HostPool to the pool", func() {
+		objects = append(objects, &bmcv1beta1.HostPool{
+			ObjectMeta: metav1.ObjectMeta{Name: "burst"},
+			Spec: bmcv1beta1.HostPoolSpec{
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"role": "database"}},
+			},
+		})
+		status, ops := sync("Off")
+		Expect(ops).To(BeEmpty())
+		Expect(status.Message).To(ContainSubstring("managed by HostPool burst"))
+	})
+
+	It("leaves the host powered off by the remediation", func() {
+		host.Status.Remediation = &bmcv1beta1.RemediationStatus{Step: bmcv1beta1.RemediationStepPoweredOff}
+		status, ops := sync("Off")
+		Expect(ops).To(BeEmpty())
+		Expect(status.Message).To(ContainSubstring("remediation"))
+	})
+})
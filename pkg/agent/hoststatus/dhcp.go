@@ -2,14 +2,18 @@ package hoststatus
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"time"
 
 	dhcptypes "github.com/spidernet-io/bmc/pkg/dhcpserver/types"
 	bmcv1beta1 "github.com/spidernet-io/bmc/pkg/k8s/apis/bmc.spidernet.io/v1beta1"
 	"github.com/spidernet-io/bmc/pkg/log"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 const (
@@ -18,7 +22,8 @@ const (
 )
 
 func shouldRetry(err error) bool {
-	return errors.IsConflict(err) || errors.IsServerTimeout(err) || errors.IsTooManyRequests(err)
+	// AlreadyExists is retried, since the hostStatus created or moved just now may be missing in the cache
+	return errors.IsConflict(err) || errors.IsServerTimeout(err) || errors.IsTooManyRequests(err) || errors.IsAlreadyExists(err)
 }
 
 // process the dhcp events sent from DHCP server module, from the channel
@@ -68,22 +73,39 @@ func (c *hostStatusController) handleDHCPAdd(client dhcptypes.ClientInfo) error
 	}
 
 	// Try to get existing HostStatus
-	existing := &bmcv1beta1.HostStatus{}
-	err := c.client.Get(context.Background(), types.NamespacedName{Name: name}, existing)
-	if err == nil {
+	existing, err := c.findDhcpHostStatus(client.IP)
+	if err != nil {
+		log.Logger.Errorf("Failed to get HostStatus of IP %s: %v", client.IP, err)
+		return err
+	}
+	if existing != nil {
+		name = existing.Name
 		// HostStatus exists, check if MAC changed,  or if failed to update status after creating
-		if existing.Status.Basic.Mac == client.MAC {
-			log.Logger.Debugf("HostStatus %s exists with same MAC %s, no update needed", name, client.MAC)
+		if existing.Status.Basic.Mac == client.MAC && existing.Status.Basic.IpAddr == client.IP {
+			if existing.Labels[bmcv1beta1.LabelClientActive] != "false" {
+				log.Logger.Debugf("HostStatus %s exists with same MAC %s, no update needed", name, client.MAC)
+				return nil
+			}
+			// the lease is active again, probe the BMC at once instead of waiting for the backoff
+			log.Logger.Infof("the DHCP lease of HostStatus %s is active again", name)
+			updated := existing.DeepCopy()
+			updated.Labels[bmcv1beta1.LabelClientActive] = "true"
+			if err := c.client.Update(context.Background(), updated); err != nil {
+				log.Logger.Errorf("Failed to update labels of HostStatus %s: %v", name, err)
+				return err
+			}
+			c.poller.trigger(name)
 			return nil
 		}
-		// MAC changed, update the object
-		log.Logger.Infof("Updating HostStatus %s: MAC changed from %s to %s",
-			name, existing.Status.Basic.Mac, client.MAC)
+		// MAC or IP changed, update the object
+		log.Logger.Infof("Updating HostStatus %s: MAC changed from %s to %s, IP changed from %s to %s",
+			name, existing.Status.Basic.Mac, client.MAC, existing.Status.Basic.IpAddr, client.IP)
 
 		// Create a copy of the existing object to avoid modifying the cache
 		updated := existing.DeepCopy()
 		updated.Status.LastUpdateTime = time.Now().UTC().Format(time.RFC3339)
 		updated.Status.Basic.Mac = client.MAC
+		updated.Status.Basic.IpAddr = client.IP
 
 		if err := c.client.Status().Update(context.Background(), updated); err != nil {
 			if errors.IsConflict(err) {
@@ -94,12 +116,23 @@ func (c *hostStatusController) handleDHCPAdd(client dhcptypes.ClientInfo) error
 			return err
 		}
 		log.Logger.Infof("Successfully updated HostStatus %s", name)
+		c.poller.trigger(name)
 		log.Logger.Debugf("Updated DHCP client details - IP: %s, MAC: %s, Lease: %s -> %s",
 			client.IP, client.MAC, client.StartTime, client.EndTime)
 		return nil
 	}
 
-	if !errors.IsNotFound(err) {
+	// the IP is new, the BMC may be a known machine whose lease changes
+	if moved, err := c.moveDhcpHostStatus(client); err != nil || moved {
+		return err
+	}
+
+	// a hostStatus moved to another IP keeps its name, which is taken for the new BMC leasing the IP
+	taken := &bmcv1beta1.HostStatus{}
+	if err := c.client.Get(context.Background(), types.NamespacedName{Name: name}, taken); err == nil {
+		name = formatHostStatusNameWithMac(c.config.ClusterAgentName, client.IP, client.MAC)
+		log.Logger.Infof("HostStatus %s is moved to IP %s, create HostStatus %s for IP %s", taken.Name, taken.Status.Basic.IpAddr, name, client.IP)
+	} else if !errors.IsNotFound(err) {
 		log.Logger.Errorf("Failed to get HostStatus %s: %v", name, err)
 		return err
 	}
@@ -187,16 +220,16 @@ func (c *hostStatusController) handleDHCPDelete(client dhcptypes.ClientInfo) err
 		log.Logger.Infof("Enable Bind DhcpIP, so just label the hoststatus - IP: %s, MAC: %s", client.IP, client.MAC)
 
 		// 获取现有的 HostStatus
-		existing := &bmcv1beta1.HostStatus{}
-		err := c.client.Get(context.Background(), types.NamespacedName{Name: name}, existing)
+		existing, err := c.findDhcpHostStatus(client.IP)
 		if err != nil {
-			if errors.IsNotFound(err) {
-				log.Logger.Debugf("HostStatus %s not found, skip labeling", name)
-				return nil
-			}
 			log.Logger.Errorf("Failed to get HostStatus %s: %v", name, err)
 			return err
 		}
+		if existing == nil {
+			log.Logger.Debugf("HostStatus %s not found, skip labeling", name)
+			return nil
+		}
+		name = existing.Name
 
 		// 创建更新对象的副本
 		updated := existing.DeepCopy()
@@ -217,11 +250,16 @@ func (c *hostStatusController) handleDHCPDelete(client dhcptypes.ClientInfo) err
 	} else {
 		log.Logger.Infof("Disable Bind DhcpIP, so delete the hoststatus - IP: %s, MAC: %s", client.IP, client.MAC)
 
-		existing := &bmcv1beta1.HostStatus{
-			ObjectMeta: metav1.ObjectMeta{
-				Name: name,
-			},
+		existing, err := c.findDhcpHostStatus(client.IP)
+		if err != nil {
+			log.Logger.Errorf("Failed to get HostStatus %s: %v", name, err)
+			return err
 		}
+		if existing == nil {
+			log.Logger.Debugf("HostStatus %s not found, already deleted", name)
+			return nil
+		}
+		name = existing.Name
 		if err := c.client.Delete(context.Background(), existing); err != nil {
 			if errors.IsNotFound(err) {
 				log.Logger.Debugf("HostStatus %s not found, already deleted", name)
@@ -235,3 +273,75 @@ func (c *hostStatusController) handleDHCPDelete(client dhcptypes.ClientInfo) err
 
 	return nil
 }
+
+// findDhcpHostStatus returns the DHCP hostStatus of the agent holding the IP, which is either named after the IP,
+// or moved to the IP from a previous lease. nil is returned when it is not found
+func (c *hostStatusController) findDhcpHostStatus(ip string) (*bmcv1beta1.HostStatus, error) {
+	list := &bmcv1beta1.HostStatusList{}
+	if err := c.client.List(context.Background(), list, client.MatchingLabels{
+		bmcv1beta1.LabelIPAddr:     ip,
+		bmcv1beta1.LabelClientMode: bmcv1beta1.HostTypeDHCP,
+	}); err != nil {
+		return nil, err
+	}
+	name := formatHostStatusName(c.config.ClusterAgentName, ip)
+	for i := range list.Items {
+		t := &list.Items[i]
+		if t.Name == name || t.Status.ClusterAgent == c.config.ClusterAgentName {
+			return t, nil
+		}
+	}
+	return nil, nil
+}
+
+// moveDhcpHostStatus moves the hostStatus of a known BMC to its new IP, so that the history of the machine is kept.
+// it returns false when the MAC of the DHCP client is not known
+func (c *hostStatusController) moveDhcpHostStatus(info dhcptypes.ClientInfo) (bool, error) {
+	list := &bmcv1beta1.HostStatusList{}
+	if err := c.client.List(context.Background(), list, client.MatchingLabels{
+		bmcv1beta1.LabelClientMode: bmcv1beta1.HostTypeDHCP,
+	}); err != nil {
+		return false, err
+	}
+	var known *bmcv1beta1.HostStatus
+	for i := range list.Items {
+		t := &list.Items[i]
+		if t.Status.ClusterAgent == c.config.ClusterAgentName && strings.EqualFold(t.Status.Basic.Mac, info.MAC) {
+			known = t
+			break
+		}
+	}
+	if known == nil {
+		return false, nil
+	}
+
+	msg := fmt.Sprintf("the BMC with MAC %s moves from IP %s to %s", info.MAC, known.Status.Basic.IpAddr, info.IP)
+	log.Logger.Infof("HostStatus %s: %s", known.Name, msg)
+	if known.Labels == nil {
+		known.Labels = map[string]string{}
+	}
+	known.Labels[bmcv1beta1.LabelIPAddr] = info.IP
+	known.Labels[bmcv1beta1.LabelClientActive] = "true"
+	if err := c.client.Update(context.Background(), known); err != nil {
+		log.Logger.Errorf("Failed to update labels of HostStatus %s: %v", known.Name, err)
+		return false, err
+	}
+	// the status is fixed by the next DHCP event if it fails here, because the hostStatus is found by the label then
+	known.Status.Basic.IpAddr = info.IP
+	known.Status.Basic.ActiveDhcpClient = true
+	known.Status.LastUpdateTime = time.Now().UTC().Format(time.RFC3339)
+	if err := c.client.Status().Update(context.Background(), known); err != nil {
+		log.Logger.Errorf("Failed to update status of HostStatus %s: %v", known.Name, err)
+		return false, err
+	}
+
+	t := &corev1.ObjectReference{
+		Kind:       bmcv1beta1.KindHostStatus,
+		Name:       known.Name,
+		Namespace:  c.config.PodNamespace,
+		APIVersion: bmcv1beta1.APIVersion,
+	}
+	c.recorder.Event(t, corev1.EventTypeNormal, "HostMoved", msg)
+	c.poller.trigger(known.Name)
+	return true, nil
+}
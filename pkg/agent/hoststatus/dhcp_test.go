@@ -0,0 +1,76 @@
+package hoststatus_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spidernet-io/bmc/pkg/agent/config"
+	"github.com/spidernet-io/bmc/pkg/agent/hoststatus"
+	dhcptypes "github.com/spidernet-io/bmc/pkg/dhcpserver/types"
+	bmcv1beta1 "github.com/spidernet-io/bmc/pkg/k8s/apis/bmc.spidernet.io/v1beta1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("DHCP", Label("unitest"), func() {
+	var (
+		ctx         context.Context
+		c           client.Client
+		agentConfig *config.AgentConfig
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		scheme := runtime.NewScheme()
+		Expect(bmcv1beta1.AddToScheme(scheme)).To(Succeed())
+		c = fake.NewClientBuilder().WithScheme(scheme).WithStatusSubresource(&bmcv1beta1.HostStatus{}).Build()
+		agentConfig = &config.AgentConfig{
+			ClusterAgentName: "agent1",
+			AgentObjSpec: bmcv1beta1.ClusterAgentSpec{
+				Endpoint: &bmcv1beta1.EndpointConfig{Port: 443, HTTPS: true},
+				Feature: &bmcv1beta1.FeatureConfig{
+					DhcpServerConfig: &bmcv1beta1.DhcpServerConfig{EnableDhcpDiscovery: true},
+				},
+			},
+		}
+	})
+
+	add := func(ip, mac string) {
+		Expect(hoststatus.HandleDHCPAdd(c, agentConfig, dhcptypes.ClientInfo{IP: ip, MAC: mac, Active: true})).To(Succeed())
+	}
+	hostStatus := func(name string) *bmcv1beta1.HostStatus {
+		hs := &bmcv1beta1.HostStatus{}
+		Expect(c.Get(ctx, client.ObjectKey{Name: name}, hs)).To(Succeed())
+		return hs
+	}
+
+	It("creates the hostStatus named after the IP", func() {
+		add("10.0.0.5", "aa:bb:cc:00:00:01")
+		hs := hostStatus("agent1-10-0-0-5")
+		Expect(hs.Labels[bmcv1beta1.LabelIPAddr]).To(Equal("10.0.0.5"))
+		Expect(hs.Status.Basic.Mac).To(Equal("aa:bb:cc:00:00:01"))
+		Expect(hs.Status.ClusterAgent).To(Equal("agent1"))
+	})
+
+	It("moves the hostStatus with the BMC, and names the new BMC leasing the old IP after its MAC", func() {
+		add("10.0.0.5", "aa:bb:cc:00:00:01")
+		add("10.0.0.6", "aa:bb:cc:00:00:01")
+		moved := hostStatus("agent1-10-0-0-5")
+		Expect(moved.Labels[bmcv1beta1.LabelIPAddr]).To(Equal("10.0.0.6"))
+		Expect(moved.Status.Basic.IpAddr).To(Equal("10.0.0.6"))
+
+		add("10.0.0.5", "aa:bb:cc:00:00:02")
+		hs := hostStatus("agent1-10-0-0-5-aabbcc000002")
+		Expect(hs.Labels[bmcv1beta1.LabelIPAddr]).To(Equal("10.0.0.5"))
+		Expect(hs.Status.Basic.Mac).To(Equal("aa:bb:cc:00:00:02"))
+		Expect(hostStatus("agent1-10-0-0-5").Status.Basic.Mac).To(Equal("aa:bb:cc:00:00:01"))
+
+		// the later events of the new BMC find its hostStatus by the IP
+		add("10.0.0.5", "aa:bb:cc:00:00:02")
+		list := &bmcv1beta1.HostStatusList{}
+		Expect(c.List(ctx, list)).To(Succeed())
+		Expect(list.Items).To(HaveLen(2))
+	})
+})
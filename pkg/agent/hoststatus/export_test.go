@@ -0,0 +1,87 @@
+package hoststatus
+
+import (
+	"time"
+
+	"github.com/spidernet-io/bmc/pkg/agent/config"
+	dhcptypes "github.com/spidernet-io/bmc/pkg/dhcpserver/types"
+	bmcv1beta1 "github.com/spidernet-io/bmc/pkg/k8s/apis/bmc.spidernet.io/v1beta1"
+	"github.com/spidernet-io/bmc/pkg/redfish"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func newTestController(c client.Client, agentConfig *config.AgentConfig) *hostStatusController {
+	return &hostStatusController{
+		client:   c,
+		config:   agentConfig,
+		recorder: record.NewFakeRecorder(10),
+		poller:   newPollScheduler(),
+	}
+}
+
+// HandleDHCPAdd processes the DHCP add event with a controller working with the client
+func HandleDHCPAdd(c client.Client, agentConfig *config.AgentConfig, info dhcptypes.ClientInfo) error {
+	return newTestController(c, agentConfig).handleDHCPAdd(info)
+}
+
+// SyncDesiredPower enforces the desired power state of the hostStatus, and returns the updated status
+func SyncDesiredPower(c client.Client, agentConfig *config.AgentConfig, rf redfish.RefishClient, existing *bmcv1beta1.HostStatus) *bmcv1beta1.DesiredPowerStatus {
+	updated := existing.DeepCopy()
+	newTestController(c, agentConfig).syncDesiredPower(rf, existing, updated)
+	return updated.Status.DesiredPower
+}
+
+// the decision functions are exported for the tests
+var (
+	BackoffInterval           = backoffInterval
+	SetConnectConditions      = setConnectConditions
+	ParsePowerLimitAnnotation = parsePowerLimitAnnotation
+	PowerStatusEqual          = powerStatusEqual
+)
+
+// PatchStatus writes the changes of a poll from the existing status to the updated one
+func PatchStatus(c client.Client, existing, updated *bmcv1beta1.HostStatus) error {
+	return newTestController(c, nil).patchStatus(existing, updated)
+}
+
+// SyncPowerLimit applies the power cap declared by the annotation of the hostStatus, and returns whether it is changed
+func SyncPowerLimit(agentConfig *config.AgentConfig, rf redfish.RefishClient, hostStatus *bmcv1beta1.HostStatus, power *bmcv1beta1.PowerStatus) (bool, error) {
+	return newTestController(nil, agentConfig).syncPowerLimit(rf, hostStatus, power)
+}
+
+// PollController exposes the polling of a controller working with the client, its schedule is kept between the calls
+type PollController struct {
+	c *hostStatusController
+}
+
+func NewPollController(c client.Client, agentConfig *config.AgentConfig) *PollController {
+	return &PollController{c: newTestController(c, agentConfig)}
+}
+
+// PollInterval returns the interval of polling the healthy BMC of the hostStatus
+func (p *PollController) PollInterval(hostStatus *bmcv1beta1.HostStatus) time.Duration {
+	return p.c.pollInterval(hostStatus)
+}
+
+// SchedulePoll schedules the next poll of the hostStatus after a poll, and returns the updated polling status
+func (p *PollController) SchedulePoll(existing *bmcv1beta1.HostStatus, healthy bool) *bmcv1beta1.PollingStatus {
+	updated := existing.DeepCopy()
+	p.c.schedulePoll(existing, updated, healthy)
+	return updated.Status.Polling
+}
+
+// Due returns whether the hostStatus is polled at the time
+func (p *PollController) Due(name string, now time.Time) bool {
+	return p.c.poller.due(name, now)
+}
+
+// HandleDHCPAdd processes the DHCP add event
+func (p *PollController) HandleDHCPAdd(info dhcptypes.ClientInfo) error {
+	return p.c.handleDHCPAdd(info)
+}
+
+// InventoryRefreshReason returns why the inventory of the hostStatus is collected at the poll with the state
+func InventoryRefreshReason(agentConfig *config.AgentConfig, existing *bmcv1beta1.HostStatus, state map[string]string) string {
+	return newTestController(nil, agentConfig).inventoryRefreshReason(existing, state)
+}
@@ -0,0 +1,99 @@
+package hoststatus
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	bmcv1beta1 "github.com/spidernet-io/bmc/pkg/k8s/apis/bmc.spidernet.io/v1beta1"
+	"github.com/spidernet-io/bmc/pkg/log"
+	"github.com/spidernet-io/bmc/pkg/redfish"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// the placeholders reported by some BMCs, which do not identify the machine
+var placeholderIdentities = map[string]bool{
+	"":                                     true,
+	"0":                                    true,
+	"00000000-0000-0000-0000-000000000000": true,
+	"FFFFFFFF-FFFF-FFFF-FFFF-FFFFFFFFFFFF": true,
+	"DEFAULT STRING":                       true,
+	"NOT SPECIFIED":                        true,
+	"TO BE FILLED BY O.E.M.":               true,
+}
+
+// sameIdentity returns whether both values identify the same machine
+func sameIdentity(a, b string) bool {
+	return !placeholderIdentities[strings.ToUpper(strings.TrimSpace(a))] && strings.EqualFold(strings.TrimSpace(a), strings.TrimSpace(b))
+}
+
+// duplicates describes the other hostStatuses reporting the same identity as the hostStatus
+func duplicates(hostStatus *bmcv1beta1.HostStatus, others []bmcv1beta1.HostStatus) []string {
+	self := hostStatus.Status.Identity
+	if self == nil {
+		return nil
+	}
+	result := []string{}
+	for i := range others {
+		other := &others[i]
+		if other.Name == hostStatus.Name || other.Status.Identity == nil {
+			continue
+		}
+		var msg string
+		switch {
+		case sameIdentity(self.SystemUUID, other.Status.Identity.SystemUUID):
+			msg = fmt.Sprintf("system UUID %s is also reported by hostStatus %s", self.SystemUUID, other.Name)
+		case sameIdentity(self.ChassisSerial, other.Status.Identity.ChassisSerial):
+			msg = fmt.Sprintf("chassis serial %s is also reported by hostStatus %s", self.ChassisSerial, other.Name)
+		case sameIdentity(self.BmcMac, other.Status.Identity.BmcMac):
+			msg = fmt.Sprintf("BMC MAC %s is also reported by hostStatus %s", self.BmcMac, other.Name)
+		default:
+			continue
+		}
+		if other.Labels[bmcv1beta1.LabelClientActive] == "false" {
+			msg += " whose DHCP lease is inactive, it may be the same machine with a previous IP"
+		}
+		result = append(result, msg)
+	}
+	return result
+}
+
+// syncIdentity records the identity of the host, and flags the hostStatus when another one reports the same identity
+func (c *hostStatusController) syncIdentity(client redfish.RefishClient, existing, updated *bmcv1beta1.HostStatus) {
+	identity, err := client.GetIdentity()
+	if err != nil {
+		log.Logger.Errorf("Failed to get identity of HostStatus %s: %v", existing.Name, err)
+		return
+	}
+	if identity.BmcMac == "" && existing.Status.Basic.Type == bmcv1beta1.HostTypeDHCP {
+		// the DHCP client is the BMC
+		identity.BmcMac = existing.Status.Basic.Mac
+	}
+	updated.Status.Identity = identity
+
+	list := &bmcv1beta1.HostStatusList{}
+	if err := c.client.List(context.Background(), list); err != nil {
+		log.Logger.Errorf("Failed to list HostStatus: %v", err)
+		return
+	}
+	found := duplicates(updated, list.Items)
+	if len(found) == 0 {
+		setCondition(updated, bmcv1beta1.HostStatusConditionDuplicated, metav1.ConditionFalse, bmcv1beta1.HostStatusReasonUniqueIdentity, "")
+		return
+	}
+
+	msg := strings.Join(found, "; ")
+	if !meta.IsStatusConditionTrue(existing.Status.Conditions, bmcv1beta1.HostStatusConditionDuplicated) {
+		log.Logger.Warnf("HostStatus %s is duplicated: %s", existing.Name, msg)
+		t := &corev1.ObjectReference{
+			Kind:       bmcv1beta1.KindHostStatus,
+			Name:       existing.Name,
+			Namespace:  c.config.PodNamespace,
+			APIVersion: bmcv1beta1.APIVersion,
+		}
+		c.recorder.Event(t, corev1.EventTypeWarning, bmcv1beta1.HostStatusReasonDuplicateIdentity, msg)
+	}
+	setCondition(updated, bmcv1beta1.HostStatusConditionDuplicated, metav1.ConditionTrue, bmcv1beta1.HostStatusReasonDuplicateIdentity, msg)
+}
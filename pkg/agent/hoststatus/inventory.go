@@ -0,0 +1,80 @@
+package hoststatus
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spidernet-io/bmc/pkg/hardware"
+	bmcv1beta1 "github.com/spidernet-io/bmc/pkg/k8s/apis/bmc.spidernet.io/v1beta1"
+	"github.com/spidernet-io/bmc/pkg/log"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// inventoryRefreshRequested returns whether the annotation requests a new collection of the inventory
+func inventoryRefreshRequested(hostStatus *bmcv1beta1.HostStatus) bool {
+	v := hostStatus.Annotations[bmcv1beta1.AnnotationRefreshInventory]
+	if v == "" {
+		return false
+	}
+	return hostStatus.Status.Inventory == nil || hostStatus.Status.Inventory.RefreshRequest != v
+}
+
+// inventoryRefreshReason returns why the inventory should be collected, or "" when the collected one is still valid.
+// the inventory is collected for the first time, after the BMC recovers or the power state changes, on demand
+// by the annotation, or when it is older than the inventory interval
+func (c *hostStatusController) inventoryRefreshReason(existing *bmcv1beta1.HostStatus, state map[string]string) string {
+	if len(existing.Status.Info) == 0 || existing.Status.Inventory == nil {
+		return "it has not been collected"
+	}
+	if !existing.Status.Healthy {
+		return "the BMC recovers"
+	}
+	if last, now := existing.Status.Info["PowerState"], state["PowerState"]; last != now {
+		return fmt.Sprintf("the power state changes from %s to %s", last, now)
+	}
+	if inventoryRefreshRequested(existing) {
+		return fmt.Sprintf("it is requested by annotation %s", bmcv1beta1.AnnotationRefreshInventory)
+	}
+	last, ok := parseTime(existing.Status.Inventory.LastCollectTime)
+	interval := time.Duration(c.config.HostStatusInventoryInterval) * time.Second
+	if !ok || time.Since(last) >= interval {
+		return fmt.Sprintf("it is older than %v", interval)
+	}
+	return ""
+}
+
+// recordHardwareChanges compares the hardware components with the last collection, emits an event for each change,
+// and keeps the latest changes in the status
+func (c *hostStatusController) recordHardwareChanges(existing, updated *bmcv1beta1.HostStatus) {
+	if len(existing.Status.Hardware) == 0 {
+		// nothing to compare with for the first collection
+		return
+	}
+	changes := hardware.Diff(existing.Status.Hardware, updated.Status.Hardware)
+	if len(changes) == 0 {
+		return
+	}
+
+	t := &corev1.ObjectReference{
+		Kind:       bmcv1beta1.KindHostStatus,
+		Name:       existing.Name,
+		Namespace:  c.config.PodNamespace,
+		APIVersion: bmcv1beta1.APIVersion,
+	}
+	now := time.Now().UTC().Format(time.RFC3339)
+	history := append([]bmcv1beta1.HardwareChange{}, existing.Status.HardwareChanges...)
+	for _, change := range changes {
+		log.Logger.Infof("hardware of hostStatus %s changes: %s", existing.Name, change.Message)
+		eventType := corev1.EventTypeNormal
+		if change.Change == bmcv1beta1.HardwareChangeRemoved || change.Change == bmcv1beta1.HardwareChangeReplaced {
+			eventType = corev1.EventTypeWarning
+		}
+		c.recorder.Event(t, eventType, "Hardware"+change.Change, change.Message)
+		change.Time = now
+		history = append(history, change)
+	}
+	if limit := c.config.HardwareChangeHistoryLimit; limit > 0 && len(history) > limit {
+		history = history[len(history)-limit:]
+	}
+	updated.Status.HardwareChanges = history
+}
@@ -0,0 +1,84 @@
+package hoststatus_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spidernet-io/bmc/pkg/agent/config"
+	"github.com/spidernet-io/bmc/pkg/agent/hoststatus"
+	bmcv1beta1 "github.com/spidernet-io/bmc/pkg/k8s/apis/bmc.spidernet.io/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("Inventory", Label("unitest"), func() {
+	var (
+		agentConfig *config.AgentConfig
+		host        *bmcv1beta1.HostStatus
+		state       map[string]string
+	)
+
+	BeforeEach(func() {
+		agentConfig = &config.AgentConfig{HostStatusInventoryInterval: 3600}
+		// the inventory is collected 10 minutes ago
+		host = &bmcv1beta1.HostStatus{
+			ObjectMeta: metav1.ObjectMeta{Name: "host1"},
+			Status: bmcv1beta1.HostStatusStatus{
+				Healthy: true,
+				Info:    map[string]string{"PowerState": "On", "SerialNumber": "SN1"},
+				Inventory: &bmcv1beta1.InventoryStatus{
+					LastCollectTime: time.Now().Add(-10 * time.Minute).UTC().Format(time.RFC3339),
+				},
+			},
+		}
+		state = map[string]string{"PowerState": "On"}
+	})
+
+	reason := func() string {
+		return hoststatus.InventoryRefreshReason(agentConfig, host, state)
+	}
+
+	It("keeps the inventory collected within the interval", func() {
+		Expect(reason()).To(BeEmpty())
+	})
+
+	It("collects the inventory for the first time", func() {
+		host.Status.Inventory = nil
+		Expect(reason()).To(Equal("it has not been collected"))
+
+		host.Status.Inventory = &bmcv1beta1.InventoryStatus{LastCollectTime: time.Now().UTC().Format(time.RFC3339)}
+		host.Status.Info = nil
+		Expect(reason()).To(Equal("it has not been collected"))
+	})
+
+	It("collects the inventory after the BMC recovers", func() {
+		host.Status.Healthy = false
+		Expect(reason()).To(Equal("the BMC recovers"))
+	})
+
+	It("collects the inventory when the power state changes", func() {
+		state["PowerState"] = "Off"
+		Expect(reason()).To(Equal("the power state changes from On to Off"))
+	})
+
+	It("collects the inventory once for each request of the annotation", func() {
+		host.Annotations = map[string]string{bmcv1beta1.AnnotationRefreshInventory: "1"}
+		Expect(reason()).To(ContainSubstring("it is requested by annotation"))
+
+		// the request is recorded by the last collection
+		host.Status.Inventory.RefreshRequest = "1"
+		Expect(reason()).To(BeEmpty())
+
+		host.Annotations[bmcv1beta1.AnnotationRefreshInventory] = "2"
+		Expect(reason()).To(ContainSubstring("it is requested by annotation"))
+	})
+
+	It("collects the inventory older than the interval", func() {
+		host.Status.Inventory.LastCollectTime = time.Now().Add(-2 * time.Hour).UTC().Format(time.RFC3339)
+		Expect(reason()).To(Equal("it is older than 1h0m0s"))
+
+		// the collect time could not be parsed
+		host.Status.Inventory.LastCollectTime = "yesterday"
+		Expect(reason()).To(Equal("it is older than 1h0m0s"))
+	})
+})
@@ -33,6 +33,7 @@ type hostStatusController struct {
 	stopCh     chan struct{}
 	wg         sync.WaitGroup
 	recorder   record.EventRecorder
+	poller     *pollScheduler
 }
 
 func NewHostStatusController(kubeClient kubernetes.Interface, config *config.AgentConfig, mgr ctrl.Manager) HostStatusController {
@@ -51,6 +52,7 @@ func NewHostStatusController(kubeClient kubernetes.Interface, config *config.Age
 		deleteChan: make(chan types.ClientInfo),
 		stopCh:     make(chan struct{}),
 		recorder:   recorder,
+		poller:     newPollScheduler(),
 	}
 
 	log.Logger.Debugf("HostStatus controller created successfully")
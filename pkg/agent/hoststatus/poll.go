@@ -0,0 +1,117 @@
+package hoststatus
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/spidernet-io/bmc/pkg/hostpolicy"
+	bmcv1beta1 "github.com/spidernet-io/bmc/pkg/k8s/apis/bmc.spidernet.io/v1beta1"
+	"github.com/spidernet-io/bmc/pkg/log"
+)
+
+// the interval of checking which hostStatus is due to be polled
+const pollTickInterval = time.Second
+
+// pollScheduler records when the BMC of each hostStatus is polled next
+type pollScheduler struct {
+	lock sync.Mutex
+	next map[string]time.Time
+}
+
+func newPollScheduler() *pollScheduler {
+	return &pollScheduler{next: map[string]time.Time{}}
+}
+
+// due returns whether the hostStatus should be polled, the one never polled is due
+func (s *pollScheduler) due(name string, now time.Time) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	t, ok := s.next[name]
+	return !ok || !now.Before(t)
+}
+
+func (s *pollScheduler) set(name string, t time.Time) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.next[name] = t
+}
+
+func (s *pollScheduler) remove(name string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	delete(s.next, name)
+}
+
+// trigger polls the hostStatus at the next tick regardless of its backoff
+func (s *pollScheduler) trigger(name string) {
+	s.set(name, time.Time{})
+}
+
+// backoffInterval doubles the interval with each failure after the first one, up to the max
+func backoffInterval(interval, max time.Duration, failures int32) time.Duration {
+	if max < interval {
+		max = interval
+	}
+	wait := interval
+	for i := int32(1); i < failures && wait < max; i++ {
+		wait *= 2
+	}
+	if wait > max {
+		wait = max
+	}
+	return wait
+}
+
+// pollInterval returns the interval of polling the healthy BMC of the hostStatus, which is declared by
+// the annotation of the hostStatus, the HostPolicy or the agent in order
+func (c *hostStatusController) pollInterval(hostStatus *bmcv1beta1.HostStatus) time.Duration {
+	if v, ok := hostStatus.Annotations[bmcv1beta1.AnnotationPollIntervalSeconds]; ok {
+		n, err := strconv.Atoi(v)
+		if err == nil && n > 0 {
+			return time.Duration(n) * time.Second
+		}
+		log.Logger.Warnf("ignore invalid value %q of annotation %s of hostStatus %s, it should be a positive integer",
+			v, bmcv1beta1.AnnotationPollIntervalSeconds, hostStatus.Name)
+	}
+
+	policies := &bmcv1beta1.HostPolicyList{}
+	if err := c.client.List(context.Background(), policies); err != nil {
+		log.Logger.Errorf("Failed to list HostPolicies: %v", err)
+	} else if n, policy, err := hostpolicy.PollInterval(policies.Items, hostStatus); err != nil {
+		log.Logger.Errorf("Failed to get the poll interval of hostStatus %s: %v", hostStatus.Name, err)
+	} else if n > 0 {
+		log.Logger.Debugf("poll hostStatus %s at the interval of %d seconds declared by HostPolicy %s", hostStatus.Name, n, policy)
+		return time.Duration(n) * time.Second
+	}
+
+	return time.Duration(c.config.HostStatusUpdateInterval) * time.Second
+}
+
+// schedulePoll decides when to poll the BMC next, the BMC failing in a row is backed off,
+// and records the schedule in the status. the next poll time is only recorded while the BMC is backed off,
+// otherwise it changes at every poll and each poll would update the status
+func (c *hostStatusController) schedulePoll(existing, updated *bmcv1beta1.HostStatus, healthy bool) {
+	interval := c.pollInterval(existing)
+	var failures int32
+	if !healthy {
+		failures = 1
+		if existing.Status.Polling != nil {
+			failures = existing.Status.Polling.ConsecutiveFailures + 1
+		}
+	}
+
+	wait := backoffInterval(interval, time.Duration(c.config.HostStatusMaxBackoffInterval)*time.Second, failures)
+	next := time.Now().Add(wait)
+	c.poller.set(existing.Name, next)
+
+	updated.Status.Polling = &bmcv1beta1.PollingStatus{
+		IntervalSeconds:     int32(interval / time.Second),
+		ConsecutiveFailures: failures,
+	}
+	if failures > 0 {
+		updated.Status.Polling.NextPollTime = next.UTC().Format(time.RFC3339)
+		log.Logger.Debugf("the BMC of hostStatus %s fails %d times in a row, poll it again after %v", existing.Name, failures, wait)
+	}
+}
@@ -0,0 +1,170 @@
+package hoststatus_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spidernet-io/bmc/pkg/agent/config"
+	"github.com/spidernet-io/bmc/pkg/agent/hoststatus"
+	dhcptypes "github.com/spidernet-io/bmc/pkg/dhcpserver/types"
+	bmcv1beta1 "github.com/spidernet-io/bmc/pkg/k8s/apis/bmc.spidernet.io/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("Poll", Label("unitest"), func() {
+	var (
+		ctx         context.Context
+		agentConfig *config.AgentConfig
+		host        *bmcv1beta1.HostStatus
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		agentConfig = &config.AgentConfig{
+			ClusterAgentName:             "agent1",
+			HostStatusUpdateInterval:     60,
+			HostStatusMaxBackoffInterval: 600,
+			AgentObjSpec: bmcv1beta1.ClusterAgentSpec{
+				Endpoint: &bmcv1beta1.EndpointConfig{Port: 443, HTTPS: true},
+				Feature: &bmcv1beta1.FeatureConfig{
+					DhcpServerConfig: &bmcv1beta1.DhcpServerConfig{EnableDhcpDiscovery: true},
+				},
+			},
+		}
+		host = &bmcv1beta1.HostStatus{ObjectMeta: metav1.ObjectMeta{Name: "host1", Labels: map[string]string{"rack": "r1"}}}
+	})
+
+	newClient := func(objs ...client.Object) client.Client {
+		scheme := runtime.NewScheme()
+		Expect(bmcv1beta1.AddToScheme(scheme)).To(Succeed())
+		return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).WithStatusSubresource(&bmcv1beta1.HostStatus{}).Build()
+	}
+	policy := func(name string, seconds int32) *bmcv1beta1.HostPolicy {
+		return &bmcv1beta1.HostPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: bmcv1beta1.HostPolicySpec{
+				Selector:            &metav1.LabelSelector{MatchLabels: map[string]string{"rack": "r1"}},
+				PollIntervalSeconds: ptr.To(seconds),
+			},
+		}
+	}
+
+	DescribeTable("backs off the failing BMC",
+		func(interval, max time.Duration, failures int32, wait time.Duration) {
+			Expect(hoststatus.BackoffInterval(interval, max, failures)).To(Equal(wait))
+		},
+		Entry("healthy", time.Minute, 10*time.Minute, int32(0), time.Minute),
+		Entry("the first failure", time.Minute, 10*time.Minute, int32(1), time.Minute),
+		Entry("the second failure", time.Minute, 10*time.Minute, int32(2), 2*time.Minute),
+		Entry("the fourth failure", time.Minute, 10*time.Minute, int32(4), 8*time.Minute),
+		Entry("capped by the max", time.Minute, 10*time.Minute, int32(5), 10*time.Minute),
+		Entry("capped after many failures", time.Minute, 10*time.Minute, int32(1000), 10*time.Minute),
+		Entry("the max shorter than the interval", time.Minute, 30*time.Second, int32(3), time.Minute),
+	)
+
+	Context("the interval", func() {
+		It("uses the interval of the agent by default", func() {
+			p := hoststatus.NewPollController(newClient(), agentConfig)
+			Expect(p.PollInterval(host)).To(Equal(time.Minute))
+		})
+
+		It("prefers the shortest interval of the HostPolicies to the agent", func() {
+			p := hoststatus.NewPollController(newClient(policy("slow", 120), policy("fast", 30)), agentConfig)
+			Expect(p.PollInterval(host)).To(Equal(30 * time.Second))
+		})
+
+		It("prefers the annotation to the HostPolicies", func() {
+			host.Annotations = map[string]string{bmcv1beta1.AnnotationPollIntervalSeconds: "15"}
+			p := hoststatus.NewPollController(newClient(policy("fast", 30)), agentConfig)
+			Expect(p.PollInterval(host)).To(Equal(15 * time.Second))
+		})
+
+		It("ignores an invalid annotation", func() {
+			p := hoststatus.NewPollController(newClient(policy("fast", 30)), agentConfig)
+			for _, v := range []string{"0", "-10", "fast"} {
+				host.Annotations = map[string]string{bmcv1beta1.AnnotationPollIntervalSeconds: v}
+				Expect(p.PollInterval(host)).To(Equal(30 * time.Second))
+			}
+		})
+	})
+
+	Context("the schedule", func() {
+		It("polls the healthy BMC at the interval without recording the next poll", func() {
+			p := hoststatus.NewPollController(newClient(), agentConfig)
+			Expect(p.Due(host.Name, time.Now())).To(BeTrue())
+
+			host.Status.Polling = &bmcv1beta1.PollingStatus{IntervalSeconds: 60, ConsecutiveFailures: 3, NextPollTime: "2024-01-01T00:00:00Z"}
+			polling := p.SchedulePoll(host, true)
+			Expect(polling).To(Equal(&bmcv1beta1.PollingStatus{IntervalSeconds: 60}))
+			Expect(p.Due(host.Name, time.Now())).To(BeFalse())
+			Expect(p.Due(host.Name, time.Now().Add(time.Minute+time.Second))).To(BeTrue())
+		})
+
+		It("counts the failures in a row and backs off", func() {
+			p := hoststatus.NewPollController(newClient(), agentConfig)
+			polling := p.SchedulePoll(host, false)
+			Expect(polling.ConsecutiveFailures).To(Equal(int32(1)))
+			Expect(polling.NextPollTime).NotTo(BeEmpty())
+
+			host.Status.Polling = &bmcv1beta1.PollingStatus{IntervalSeconds: 60, ConsecutiveFailures: 3}
+			polling = p.SchedulePoll(host, false)
+			Expect(polling.ConsecutiveFailures).To(Equal(int32(4)))
+			next, err := time.Parse(time.RFC3339, polling.NextPollTime)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(next).To(BeTemporally("~", time.Now().Add(8*time.Minute), 2*time.Second))
+			Expect(p.Due(host.Name, time.Now().Add(7*time.Minute))).To(BeFalse())
+			Expect(p.Due(host.Name, time.Now().Add(8*time.Minute+time.Second))).To(BeTrue())
+		})
+
+		It("probes the BMC at once when its DHCP lease is active again", func() {
+			c := newClient()
+			p := hoststatus.NewPollController(c, agentConfig)
+			Expect(p.HandleDHCPAdd(dhcptypes.ClientInfo{IP: "10.0.0.5", MAC: "aa:bb:cc:00:00:01", Active: true})).To(Succeed())
+			hs := &bmcv1beta1.HostStatus{}
+			Expect(c.Get(ctx, client.ObjectKey{Name: "agent1-10-0-0-5"}, hs)).To(Succeed())
+
+			// the lease expires, and the BMC is backed off
+			hs.Labels[bmcv1beta1.LabelClientActive] = "false"
+			Expect(c.Update(ctx, hs)).To(Succeed())
+			hs.Status.Polling = &bmcv1beta1.PollingStatus{IntervalSeconds: 60, ConsecutiveFailures: 10}
+			p.SchedulePoll(hs, false)
+			Expect(p.Due(hs.Name, time.Now())).To(BeFalse())
+
+			Expect(p.HandleDHCPAdd(dhcptypes.ClientInfo{IP: "10.0.0.5", MAC: "aa:bb:cc:00:00:01", Active: true})).To(Succeed())
+			Expect(p.Due(hs.Name, time.Now())).To(BeTrue())
+			Expect(c.Get(ctx, client.ObjectKey{Name: hs.Name}, hs)).To(Succeed())
+			Expect(hs.Labels[bmcv1beta1.LabelClientActive]).To(Equal("true"))
+		})
+
+		It("probes the BMC at once when a new MAC leases its IP", func() {
+			c := newClient()
+			p := hoststatus.NewPollController(c, agentConfig)
+			Expect(p.HandleDHCPAdd(dhcptypes.ClientInfo{IP: "10.0.0.5", MAC: "aa:bb:cc:00:00:01", Active: true})).To(Succeed())
+			hs := &bmcv1beta1.HostStatus{}
+			Expect(c.Get(ctx, client.ObjectKey{Name: "agent1-10-0-0-5"}, hs)).To(Succeed())
+			hs.Status.Polling = &bmcv1beta1.PollingStatus{IntervalSeconds: 60, ConsecutiveFailures: 10}
+			p.SchedulePoll(hs, false)
+
+			Expect(p.HandleDHCPAdd(dhcptypes.ClientInfo{IP: "10.0.0.5", MAC: "aa:bb:cc:00:00:02", Active: true})).To(Succeed())
+			Expect(p.Due(hs.Name, time.Now())).To(BeTrue())
+		})
+
+		It("keeps the schedule when the DHCP lease does not change", func() {
+			c := newClient()
+			p := hoststatus.NewPollController(c, agentConfig)
+			Expect(p.HandleDHCPAdd(dhcptypes.ClientInfo{IP: "10.0.0.5", MAC: "aa:bb:cc:00:00:01", Active: true})).To(Succeed())
+			hs := &bmcv1beta1.HostStatus{}
+			Expect(c.Get(ctx, client.ObjectKey{Name: "agent1-10-0-0-5"}, hs)).To(Succeed())
+			p.SchedulePoll(hs, true)
+
+			Expect(p.HandleDHCPAdd(dhcptypes.ClientInfo{IP: "10.0.0.5", MAC: "aa:bb:cc:00:00:01", Active: true})).To(Succeed())
+			Expect(p.Due(hs.Name, time.Now())).To(BeFalse())
+		})
+	})
+})
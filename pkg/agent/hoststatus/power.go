@@ -0,0 +1,123 @@
+package hoststatus
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	bmcv1beta1 "github.com/spidernet-io/bmc/pkg/k8s/apis/bmc.spidernet.io/v1beta1"
+	"github.com/spidernet-io/bmc/pkg/log"
+	"github.com/spidernet-io/bmc/pkg/redfish"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// the power consumption changes all the time, in order to avoid updating the hostStatus at every interval,
+// the power metrics are only refreshed when they change more than this percentage
+const powerChangeThresholdPercent = 5
+
+// BMCs round the power cap to their own steps, so the power cap read back within this range of the declared one
+// is considered as applied, instead of setting it again at every poll
+const powerLimitToleranceWatts = 10
+
+// parsePowerLimitAnnotation returns whether the annotation is set, and the desired power cap (nil for no power cap)
+func parsePowerLimitAnnotation(annotations map[string]string) (bool, *int32, error) {
+	v, ok := annotations[bmcv1beta1.AnnotationPowerLimitWatts]
+	if !ok {
+		return false, nil, nil
+	}
+	v = strings.TrimSpace(v)
+	if strings.EqualFold(v, bmcv1beta1.PowerLimitNone) {
+		return true, nil, nil
+	}
+	n, err := strconv.ParseInt(v, 10, 32)
+	if err != nil || n <= 0 {
+		return true, nil, fmt.Errorf("invalid value %q of annotation %s, it should be a positive integer or %q", v, bmcv1beta1.AnnotationPowerLimitWatts, bmcv1beta1.PowerLimitNone)
+	}
+	t := int32(n)
+	return true, &t, nil
+}
+
+// syncPowerLimit keeps the power cap of the BMC in line with the annotation of the hostStatus
+// it returns true when the power cap of the BMC is changed
+func (c *hostStatusController) syncPowerLimit(client redfish.RefishClient, hostStatus *bmcv1beta1.HostStatus, power *bmcv1beta1.PowerStatus) (bool, error) {
+	set, desired, err := parsePowerLimitAnnotation(hostStatus.Annotations)
+	if err != nil || !set {
+		return false, err
+	}
+
+	if powerLimitApplied(desired, power.LimitInWatts) {
+		return false, nil
+	}
+
+	var msg string
+	if desired == nil {
+		msg = fmt.Sprintf("clear power limit %d watts as declared by annotation %s", *power.LimitInWatts, bmcv1beta1.AnnotationPowerLimitWatts)
+	} else {
+		msg = fmt.Sprintf("set power limit to %d watts as declared by annotation %s", *desired, bmcv1beta1.AnnotationPowerLimitWatts)
+	}
+	log.Logger.Infof("hostStatus %s: %s", hostStatus.Name, msg)
+
+	t := &corev1.ObjectReference{
+		Kind:       bmcv1beta1.KindHostStatus,
+		Name:       hostStatus.Name,
+		Namespace:  c.config.PodNamespace,
+		APIVersion: bmcv1beta1.APIVersion,
+	}
+	if err := client.SetPowerLimit(desired, ""); err != nil {
+		c.recorder.Event(t, corev1.EventTypeWarning, "PowerLimitFailed", fmt.Sprintf("failed to %s: %v", msg, err))
+		return false, err
+	}
+	c.recorder.Event(t, corev1.EventTypeNormal, "PowerLimitChanged", msg)
+	return true, nil
+}
+
+// powerLimitApplied returns whether the power cap of the BMC is the declared one, with the tolerance of the rounding
+func powerLimitApplied(desired, current *int32) bool {
+	if desired == nil || current == nil {
+		return desired == current
+	}
+	diff := *desired - *current
+	if diff < 0 {
+		diff = -diff
+	}
+	return diff <= powerLimitToleranceWatts
+}
+
+func int32PtrEqual(a, b *int32) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func wattsChanged(a, b int32) bool {
+	diff := a - b
+	if diff < 0 {
+		diff = -diff
+	}
+	base := b
+	if base < 0 {
+		base = -base
+	}
+	if base == 0 {
+		return diff != 0
+	}
+	return diff*100 > base*powerChangeThresholdPercent
+}
+
+// powerStatusEqual compares the power status, and ignores slight changes of the power consumption
+func powerStatusEqual(a, b *bmcv1beta1.PowerStatus) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	if !int32PtrEqual(a.LimitInWatts, b.LimitInWatts) || a.LimitException != b.LimitException {
+		return false
+	}
+	if a.CapacityWatts != b.CapacityWatts || a.IntervalInMin != b.IntervalInMin {
+		return false
+	}
+	return !wattsChanged(a.ConsumedWatts, b.ConsumedWatts) &&
+		!wattsChanged(a.AverageConsumedWatts, b.AverageConsumedWatts) &&
+		!wattsChanged(a.MinConsumedWatts, b.MinConsumedWatts) &&
+		!wattsChanged(a.MaxConsumedWatts, b.MaxConsumedWatts)
+}
@@ -0,0 +1,126 @@
+package hoststatus_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spidernet-io/bmc/pkg/agent/config"
+	"github.com/spidernet-io/bmc/pkg/agent/hoststatus"
+	bmcv1beta1 "github.com/spidernet-io/bmc/pkg/k8s/apis/bmc.spidernet.io/v1beta1"
+	"github.com/spidernet-io/bmc/pkg/redfish"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+// powerLimitClient records the power caps set through the BMC
+type powerLimitClient struct {
+	redfish.RefishClient
+	limits []*int32
+}
+
+func (p *powerLimitClient) SetPowerLimit(limitInWatts *int32, limitException string) error {
+	p.limits = append(p.limits, limitInWatts)
+	return nil
+}
+
+var _ = Describe("Power", Label("unitest"), func() {
+
+	DescribeTable("parses the annotation of the power cap",
+		func(annotations map[string]string, set bool, limit *int32, fails bool) {
+			s, l, err := hoststatus.ParsePowerLimitAnnotation(annotations)
+			if fails {
+				Expect(err).To(HaveOccurred())
+			} else {
+				Expect(err).NotTo(HaveOccurred())
+			}
+			Expect(s).To(Equal(set))
+			Expect(l).To(Equal(limit))
+		},
+		Entry("without the annotation", map[string]string{}, false, nil, false),
+		Entry("with a number of watts", map[string]string{bmcv1beta1.AnnotationPowerLimitWatts: " 500 "}, true, ptr.To(int32(500)), false),
+		Entry("with none", map[string]string{bmcv1beta1.AnnotationPowerLimitWatts: "None"}, true, nil, false),
+		Entry("with zero", map[string]string{bmcv1beta1.AnnotationPowerLimitWatts: "0"}, true, nil, true),
+		Entry("with a negative number", map[string]string{bmcv1beta1.AnnotationPowerLimitWatts: "-100"}, true, nil, true),
+		Entry("with a decimal", map[string]string{bmcv1beta1.AnnotationPowerLimitWatts: "500.5"}, true, nil, true),
+		Entry("with a number out of range", map[string]string{bmcv1beta1.AnnotationPowerLimitWatts: "3000000000"}, true, nil, true),
+	)
+
+	DescribeTable("ignores the slight change of the power consumption",
+		func(consumed int32, equal bool) {
+			last := &bmcv1beta1.PowerStatus{ConsumedWatts: 400, AverageConsumedWatts: 380, LimitInWatts: ptr.To(int32(500))}
+			cur := last.DeepCopy()
+			cur.ConsumedWatts = consumed
+			Expect(hoststatus.PowerStatusEqual(cur, last)).To(Equal(equal))
+		},
+		Entry("the same consumption", int32(400), true),
+		Entry("5% more", int32(420), true),
+		Entry("5% less", int32(380), true),
+		Entry("more than 5% more", int32(421), false),
+		Entry("more than 5% less", int32(379), false),
+	)
+
+	It("reports the change of the power cap and the missing power status", func() {
+		last := &bmcv1beta1.PowerStatus{ConsumedWatts: 400, LimitInWatts: ptr.To(int32(500))}
+		cur := last.DeepCopy()
+		cur.LimitInWatts = ptr.To(int32(505))
+		Expect(hoststatus.PowerStatusEqual(cur, last)).To(BeFalse())
+		cur.LimitInWatts = nil
+		Expect(hoststatus.PowerStatusEqual(cur, last)).To(BeFalse())
+		Expect(hoststatus.PowerStatusEqual(nil, last)).To(BeFalse())
+		Expect(hoststatus.PowerStatusEqual(nil, nil)).To(BeTrue())
+		// the consumption of 0 watts changes to any other value
+		Expect(hoststatus.PowerStatusEqual(&bmcv1beta1.PowerStatus{ConsumedWatts: 1}, &bmcv1beta1.PowerStatus{})).To(BeFalse())
+	})
+
+	Context("sync the power cap", func() {
+		host := func(annotation string) *bmcv1beta1.HostStatus {
+			h := &bmcv1beta1.HostStatus{ObjectMeta: metav1.ObjectMeta{Name: "host1"}}
+			if annotation != "" {
+				h.Annotations = map[string]string{bmcv1beta1.AnnotationPowerLimitWatts: annotation}
+			}
+			return h
+		}
+		sync := func(annotation string, current *int32) ([]*int32, bool, error) {
+			rf := &powerLimitClient{}
+			changed, err := hoststatus.SyncPowerLimit(&config.AgentConfig{}, rf, host(annotation), &bmcv1beta1.PowerStatus{LimitInWatts: current})
+			return rf.limits, changed, err
+		}
+
+		It("sets the declared power cap", func() {
+			limits, changed, err := sync("500", ptr.To(int32(600)))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(changed).To(BeTrue())
+			Expect(limits).To(Equal([]*int32{ptr.To(int32(500))}))
+		})
+
+		It("does not set the power cap rounded by the BMC again", func() {
+			limits, changed, err := sync("333", ptr.To(int32(330)))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(changed).To(BeFalse())
+			Expect(limits).To(BeEmpty())
+		})
+
+		It("clears the power cap for none", func() {
+			limits, changed, err := sync("none", ptr.To(int32(500)))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(changed).To(BeTrue())
+			Expect(limits).To(Equal([]*int32{nil}))
+
+			limits, changed, err = sync("none", nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(changed).To(BeFalse())
+			Expect(limits).To(BeEmpty())
+		})
+
+		It("leaves the power cap alone without a valid annotation", func() {
+			limits, changed, err := sync("", ptr.To(int32(500)))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(changed).To(BeFalse())
+			Expect(limits).To(BeEmpty())
+
+			limits, changed, err = sync("many", ptr.To(int32(500)))
+			Expect(err).To(HaveOccurred())
+			Expect(changed).To(BeFalse())
+			Expect(limits).To(BeEmpty())
+		})
+	})
+})
@@ -3,7 +3,9 @@ package hoststatus
 import (
 	"context"
 	"fmt"
+	"reflect"
 	"strings"
+	"time"
 
 	bmcv1beta1 "github.com/spidernet-io/bmc/pkg/k8s/apis/bmc.spidernet.io/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -42,6 +44,23 @@ func formatHostStatusName(agentName, ip string) string {
 	return fmt.Sprintf("%s-%s", agentName, strings.ReplaceAll(ip, ".", "-"))
 }
 
+// formatHostStatusNameWithMac returns the name of the DHCP hostStatus when the name of its IP is kept by a hostStatus moved to another IP
+func formatHostStatusNameWithMac(agentName, ip, mac string) string {
+	return fmt.Sprintf("%s-%s", formatHostStatusName(agentName, ip), strings.ToLower(strings.ReplaceAll(mac, ":", "")))
+}
+
+// bmcResetting returns true when the BMC is expected to be offline after a BMC reset
+func bmcResetting(status bmcv1beta1.HostStatusStatus) bool {
+	if status.BmcResettingUntil == "" {
+		return false
+	}
+	until, err := time.Parse(time.RFC3339, status.BmcResettingUntil)
+	if err != nil {
+		return false
+	}
+	return time.Now().Before(until)
+}
+
 // 比较两个Status的内容是否相同，忽略指针等问题
 func compareHostStatus(a, b bmcv1beta1.HostStatusStatus, logger *zap.SugaredLogger) bool {
 	if a.Healthy != b.Healthy {
@@ -113,6 +132,67 @@ func compareHostStatus(a, b bmcv1beta1.HostStatusStatus, logger *zap.SugaredLogg
 		return false
 	}
 
+	if a.BmcResettingUntil != b.BmcResettingUntil {
+		if logger != nil {
+			logger.Debugf("compareHostStatus BmcResettingUntil changed: %v -> %v", b.BmcResettingUntil, a.BmcResettingUntil)
+		}
+		return false
+	}
+	if !reflect.DeepEqual(a.Location, b.Location) {
+		if logger != nil {
+			logger.Debugf("compareHostStatus Location changed: %+v -> %+v", b.Location, a.Location)
+		}
+		return false
+	}
+	if !reflect.DeepEqual(a.Identity, b.Identity) {
+		if logger != nil {
+			logger.Debugf("compareHostStatus Identity changed: %+v -> %+v", b.Identity, a.Identity)
+		}
+		return false
+	}
+	if !reflect.DeepEqual(a.Hardware, b.Hardware) || !reflect.DeepEqual(a.HardwareChanges, b.HardwareChanges) {
+		if logger != nil {
+			logger.Debugf("compareHostStatus Hardware changed")
+		}
+		return false
+	}
+	if !reflect.DeepEqual(a.Inventory, b.Inventory) {
+		if logger != nil {
+			logger.Debugf("compareHostStatus Inventory changed: %+v -> %+v", b.Inventory, a.Inventory)
+		}
+		return false
+	}
+	if !reflect.DeepEqual(a.Polling, b.Polling) {
+		if logger != nil {
+			logger.Debugf("compareHostStatus Polling changed: %+v -> %+v", b.Polling, a.Polling)
+		}
+		return false
+	}
+	if !reflect.DeepEqual(a.Conditions, b.Conditions) {
+		if logger != nil {
+			logger.Debugf("compareHostStatus Conditions changed: %+v -> %+v", b.Conditions, a.Conditions)
+		}
+		return false
+	}
+	if !reflect.DeepEqual(a.Capabilities, b.Capabilities) {
+		if logger != nil {
+			logger.Debugf("compareHostStatus Capabilities changed: %+v -> %+v", b.Capabilities, a.Capabilities)
+		}
+		return false
+	}
+	if !reflect.DeepEqual(a.DesiredPower, b.DesiredPower) {
+		if logger != nil {
+			logger.Debugf("compareHostStatus DesiredPower changed: %+v -> %+v", b.DesiredPower, a.DesiredPower)
+		}
+		return false
+	}
+	if !powerStatusEqual(a.Power, b.Power) {
+		if logger != nil {
+			logger.Debugf("compareHostStatus Power changed: %+v -> %+v", b.Power, a.Power)
+		}
+		return false
+	}
+
 	// 比较Info map中的内容
 	if len(a.Info) != len(b.Info) {
 		if logger != nil {
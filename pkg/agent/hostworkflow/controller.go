@@ -0,0 +1,421 @@
+package hostworkflow
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/spidernet-io/bmc/pkg/agent/config"
+	"github.com/spidernet-io/bmc/pkg/agent/hoststatus/data"
+	bmcv1beta1 "github.com/spidernet-io/bmc/pkg/k8s/apis/bmc.spidernet.io/v1beta1"
+	"github.com/spidernet-io/bmc/pkg/log"
+	"github.com/spidernet-io/bmc/pkg/redfish"
+)
+
+// the interval to check the waits of the steps
+const stepPollInterval = 5 * time.Second
+
+// HostWorkflowController runs the steps of the HostWorkflows on the hosts of the agent
+type HostWorkflowController struct {
+	client.Client
+	Scheme *runtime.Scheme
+	// apiReader confirms with the API server that a HostOperation missing from the cache is deleted
+	apiReader   client.Reader
+	agentConfig *config.AgentConfig
+}
+
+func NewHostWorkflowController(mgr ctrl.Manager, agentConfig *config.AgentConfig) (*HostWorkflowController, error) {
+	return &HostWorkflowController{
+		Client:      mgr.GetClient(),
+		Scheme:      mgr.GetScheme(),
+		apiReader:   mgr.GetAPIReader(),
+		agentConfig: agentConfig,
+	}, nil
+}
+
+// Reconcile is part of the main kubernetes reconciliation loop
+func (r *HostWorkflowController) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.Logger.Named("HostWorkflowController").With(
+		zap.String("HostWorkflow", req.Name),
+	)
+
+	wf := &bmcv1beta1.HostWorkflow{}
+	if err := r.Get(ctx, req.NamespacedName, wf); err != nil {
+		if errors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+	if wf.Status.Phase == bmcv1beta1.HostWorkflowPhaseSucceeded || wf.Status.Phase == bmcv1beta1.HostWorkflowPhaseFailed {
+		return ctrl.Result{}, nil
+	}
+
+	hostStatus := &bmcv1beta1.HostStatus{}
+	if err := r.Get(ctx, client.ObjectKey{Name: wf.Spec.HostStatusName}, hostStatus); err != nil {
+		logger.Errorf("Failed to get HostStatus %s: %v", wf.Spec.HostStatusName, err)
+		return ctrl.Result{}, err
+	}
+	if hostStatus.Status.ClusterAgent != r.agentConfig.ClusterAgentName {
+		logger.Debugf("Skipping HostWorkflow %s as it belongs to agent %s", wf.Name, hostStatus.Status.ClusterAgent)
+		return ctrl.Result{}, nil
+	}
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	if wf.Status.Phase == "" || wf.Status.Phase == bmcv1beta1.HostWorkflowPhasePending {
+		logger.Infof("Start HostWorkflow %s with %d steps on %s", wf.Name, len(wf.Spec.Steps), wf.Spec.HostStatusName)
+		wf.Status.Phase = bmcv1beta1.HostWorkflowPhaseRunning
+		wf.Status.ClusterAgent = r.agentConfig.ClusterAgentName
+		wf.Status.StartTime = now
+		wf.Status.CurrentStep = 0
+		wf.Status.Steps = make([]bmcv1beta1.HostWorkflowStepStatus, len(wf.Spec.Steps))
+		for i, step := range wf.Spec.Steps {
+			wf.Status.Steps[i] = bmcv1beta1.HostWorkflowStepStatus{Name: step.Name, Phase: bmcv1beta1.WorkflowStepPhasePending}
+		}
+	}
+
+	result, err := r.runSteps(ctx, wf, logger)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if err := r.Status().Update(ctx, wf); err != nil {
+		logger.Errorf("failed to update HostWorkflow status: %v", err)
+		return ctrl.Result{}, fmt.Errorf("failed to update HostWorkflow status: %v", err)
+	}
+	return result, nil
+}
+
+// runSteps drives the steps as far as possible, and returns when a step needs to wait
+func (r *HostWorkflowController) runSteps(ctx context.Context, wf *bmcv1beta1.HostWorkflow, logger *zap.SugaredLogger) (ctrl.Result, error) {
+	for int(wf.Status.CurrentStep) < len(wf.Spec.Steps) {
+		i := int(wf.Status.CurrentStep)
+		step := &wf.Spec.Steps[i]
+		s := &wf.Status.Steps[i]
+
+		if s.Phase == bmcv1beta1.WorkflowStepPhasePending {
+			logger.Infof("Start step %d %s of HostWorkflow %s", i, step.Name, wf.Name)
+			s.Phase = bmcv1beta1.WorkflowStepPhaseRunning
+			s.StartTime = time.Now().UTC().Format(time.RFC3339)
+			s.Message = ""
+		}
+
+		if s.Phase == bmcv1beta1.WorkflowStepPhaseRunning {
+			done, requeue, err := r.runStep(ctx, wf, i, logger)
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+			if !done && stepTimedOut(step, s) {
+				r.cancelOperation(ctx, s.HostOperationName, logger)
+				done = true
+				s.Phase = bmcv1beta1.WorkflowStepPhaseFailed
+				s.Message = fmt.Sprintf("the step timed out after %d seconds", *step.TimeoutSeconds)
+			}
+			if !done {
+				return ctrl.Result{RequeueAfter: requeue}, nil
+			}
+			s.FinishTime = time.Now().UTC().Format(time.RFC3339)
+			logger.Infof("Step %d %s of HostWorkflow %s finished: %s %s", i, step.Name, wf.Name, s.Phase, s.Message)
+		}
+
+		if s.Phase == bmcv1beta1.WorkflowStepPhaseFailed {
+			switch step.OnFailure {
+			case bmcv1beta1.StepOnFailureContinue:
+			case bmcv1beta1.StepOnFailureRollback:
+				done, err := r.rollback(ctx, wf, i, logger)
+				if err != nil || !done {
+					return ctrl.Result{RequeueAfter: stepPollInterval}, err
+				}
+				r.finish(wf, bmcv1beta1.HostWorkflowPhaseFailed, fmt.Sprintf("step %s failed and is rolled back with result %s: %s", step.Name, s.RollbackStatus, s.Message))
+				return ctrl.Result{}, nil
+			default:
+				r.finish(wf, bmcv1beta1.HostWorkflowPhaseFailed, fmt.Sprintf("step %s failed: %s", step.Name, s.Message))
+				return ctrl.Result{}, nil
+			}
+		}
+		wf.Status.CurrentStep++
+	}
+
+	failed := 0
+	for _, s := range wf.Status.Steps {
+		if s.Phase == bmcv1beta1.WorkflowStepPhaseFailed {
+			failed++
+		}
+	}
+	msg := "all steps succeeded"
+	if failed > 0 {
+		msg = fmt.Sprintf("completed, %d failed steps are ignored", failed)
+	}
+	r.finish(wf, bmcv1beta1.HostWorkflowPhaseSucceeded, msg)
+	return ctrl.Result{}, nil
+}
+
+func (r *HostWorkflowController) finish(wf *bmcv1beta1.HostWorkflow, phase, msg string) {
+	log.Logger.Infof("HostWorkflow %s is %s: %s", wf.Name, phase, msg)
+	wf.Status.Phase = phase
+	wf.Status.Message = msg
+	wf.Status.CompletionTime = time.Now().UTC().Format(time.RFC3339)
+}
+
+func stepTimedOut(step *bmcv1beta1.HostWorkflowStep, s *bmcv1beta1.HostWorkflowStepStatus) bool {
+	if step.TimeoutSeconds == nil {
+		return false
+	}
+	start, err := time.Parse(time.RFC3339, s.StartTime)
+	if err != nil {
+		return false
+	}
+	return time.Since(start) > time.Duration(*step.TimeoutSeconds)*time.Second
+}
+
+// runStep checks the step, it returns whether the step finishes, and when to check it again
+func (r *HostWorkflowController) runStep(ctx context.Context, wf *bmcv1beta1.HostWorkflow, i int, logger *zap.SugaredLogger) (bool, time.Duration, error) {
+	step := &wf.Spec.Steps[i]
+	s := &wf.Status.Steps[i]
+
+	switch {
+	case step.Operation != nil:
+		// the name is recorded in the status only after the HostOperation is created
+		created := s.HostOperationName != ""
+		if !created {
+			s.HostOperationName = fmt.Sprintf("%s-%d", wf.Name, i)
+		}
+		status, msg, err := r.runOperation(ctx, wf, s.HostOperationName, created, step.Operation)
+		if err != nil {
+			if !refused(err) {
+				return false, 0, err
+			}
+			logger.Errorf("failed to create HostOperation %s: %v", s.HostOperationName, err)
+			s.Phase = bmcv1beta1.WorkflowStepPhaseFailed
+			s.Message = fmt.Sprintf("failed to create HostOperation: %v", err)
+			return true, 0, nil
+		}
+		switch status {
+		case bmcv1beta1.HostOperationStatusSuccess:
+			s.Phase = bmcv1beta1.WorkflowStepPhaseSucceeded
+			s.Message = msg
+			return true, 0, nil
+		case bmcv1beta1.HostOperationStatusFailed, bmcv1beta1.HostOperationStatusCancelled:
+			s.Phase = bmcv1beta1.WorkflowStepPhaseFailed
+			s.Message = fmt.Sprintf("HostOperation %s is %s: %s", s.HostOperationName, status, msg)
+			return true, 0, nil
+		}
+		s.Message = fmt.Sprintf("waiting for HostOperation %s", s.HostOperationName)
+		return false, stepPollInterval, nil
+
+	case step.DelaySeconds != nil:
+		start, err := time.Parse(time.RFC3339, s.StartTime)
+		if err != nil {
+			start = time.Now()
+		}
+		remaining := time.Until(start.Add(time.Duration(*step.DelaySeconds) * time.Second))
+		if remaining <= 0 {
+			s.Phase = bmcv1beta1.WorkflowStepPhaseSucceeded
+			return true, 0, nil
+		}
+		s.Message = fmt.Sprintf("waiting for %d seconds", *step.DelaySeconds)
+		return false, remaining, nil
+
+	case step.WaitPowerState != "":
+		c, err := r.redfishClient(wf, logger)
+		if err == nil {
+			var state *bmcv1beta1.PowerStateRecord
+			if state, err = c.GetPowerState(); err == nil {
+				if state.PowerState == step.WaitPowerState {
+					s.Phase = bmcv1beta1.WorkflowStepPhaseSucceeded
+					s.Message = fmt.Sprintf("the power state is %s", state.PowerState)
+					return true, 0, nil
+				}
+				s.Message = fmt.Sprintf("waiting for power state %s, current %s", step.WaitPowerState, state.PowerState)
+			}
+		}
+		if err != nil {
+			// the BMC may be unavailable for a while during the restart
+			logger.Debugf("failed to get power state of %s: %v", wf.Spec.HostStatusName, err)
+			s.Message = fmt.Sprintf("waiting for power state %s: %v", step.WaitPowerState, err)
+		}
+		return false, stepPollInterval, nil
+
+	case step.WaitProperty != nil:
+		p := step.WaitProperty
+		c, err := r.redfishClient(wf, logger)
+		if err == nil {
+			var value string
+			if value, err = c.GetProperty(p.URI, p.Property); err == nil {
+				if value == p.Value {
+					s.Phase = bmcv1beta1.WorkflowStepPhaseSucceeded
+					s.Message = fmt.Sprintf("%s of %s is %s", p.Property, p.URI, value)
+					return true, 0, nil
+				}
+				s.Message = fmt.Sprintf("waiting for %s of %s to be %s, current %s", p.Property, p.URI, p.Value, value)
+			}
+		}
+		if err != nil {
+			logger.Debugf("failed to get %s of %s: %v", p.Property, p.URI, err)
+			s.Message = fmt.Sprintf("waiting for %s of %s to be %s: %v", p.Property, p.URI, p.Value, err)
+		}
+		return false, stepPollInterval, nil
+	}
+
+	s.Phase = bmcv1beta1.WorkflowStepPhaseFailed
+	s.Message = "the step has nothing to do"
+	return true, 0, nil
+}
+
+// rollback runs the rollback operation of the failed step, it returns whether the rollback finishes
+func (r *HostWorkflowController) rollback(ctx context.Context, wf *bmcv1beta1.HostWorkflow, i int, logger *zap.SugaredLogger) (bool, error) {
+	step := &wf.Spec.Steps[i]
+	s := &wf.Status.Steps[i]
+	if step.Rollback == nil || s.RollbackStatus != "" {
+		return true, nil
+	}
+	created := s.RollbackHostOperationName != ""
+	if !created {
+		s.RollbackHostOperationName = fmt.Sprintf("%s-%d-rollback", wf.Name, i)
+		logger.Infof("Roll back step %d %s of HostWorkflow %s with %s", i, step.Name, wf.Name, step.Rollback.Action)
+	}
+	status, _, err := r.runOperation(ctx, wf, s.RollbackHostOperationName, created, step.Rollback)
+	if err != nil {
+		if !refused(err) {
+			return false, err
+		}
+		logger.Errorf("failed to create HostOperation %s: %v", s.RollbackHostOperationName, err)
+		s.RollbackStatus = bmcv1beta1.HostOperationStatusFailed
+		return true, nil
+	}
+	switch status {
+	case bmcv1beta1.HostOperationStatusSuccess, bmcv1beta1.HostOperationStatusFailed, bmcv1beta1.HostOperationStatusCancelled:
+		s.RollbackStatus = status
+		return true, nil
+	}
+	return false, nil
+}
+
+// refused returns whether the HostOperation of the step could never be created, the webhook denies it, such as by
+// the HostPolicy, or another HostOperation has the name. the other errors, such as a timeout, are retried
+func refused(err error) bool {
+	return errors.IsForbidden(err) || errors.IsInvalid(err) || errors.IsAlreadyExists(err)
+}
+
+// operationStatus returns the status of the HostOperation of the step, or an AlreadyExists error when it is not owned by the workflow
+func operationStatus(wf *bmcv1beta1.HostWorkflow, hostOp *bmcv1beta1.HostOperation) (string, string, error) {
+	if !metav1.IsControlledBy(hostOp, wf) {
+		return "", "", errors.NewAlreadyExists(schema.GroupResource{Group: bmcv1beta1.GroupName, Resource: "hostoperations"}, hostOp.Name)
+	}
+	return hostOp.Status.Status, hostOp.Status.Message, nil
+}
+
+// runOperation creates the HostOperation of the step if it is not created yet, and returns its status
+func (r *HostWorkflowController) runOperation(ctx context.Context, wf *bmcv1beta1.HostWorkflow, name string, created bool, action *bmcv1beta1.HostOperationActionSpec) (string, string, error) {
+	hostOp := &bmcv1beta1.HostOperation{}
+	err := r.Get(ctx, client.ObjectKey{Name: name}, hostOp)
+	if err == nil {
+		return operationStatus(wf, hostOp)
+	}
+	if !errors.IsNotFound(err) {
+		return "", "", err
+	}
+	if created {
+		// never create the HostOperation again, its action would run twice
+		return r.deletedOperation(ctx, wf, name)
+	}
+
+	hostOp = &bmcv1beta1.HostOperation{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+			Labels: map[string]string{
+				bmcv1beta1.LabelHostWorkflow:   bmcv1beta1.LabelValue(wf.Name),
+				bmcv1beta1.LabelHostStatusName: bmcv1beta1.LabelValue(wf.Spec.HostStatusName),
+			},
+		},
+		Spec: bmcv1beta1.HostOperationSpec{
+			HostOperationActionSpec: *action.DeepCopy(),
+			HostStatusName:          wf.Spec.HostStatusName,
+			Reason:                  wf.Spec.Reason,
+			Requester:               wf.Spec.Requester.DeepCopy(),
+		},
+	}
+	// the override of the HostPolicy applies to all the steps of the workflow
+	if v, ok := wf.Annotations[bmcv1beta1.AnnotationOverridePolicy]; ok {
+		hostOp.Annotations = map[string]string{bmcv1beta1.AnnotationOverridePolicy: v}
+	}
+	if err := controllerutil.SetControllerReference(wf, hostOp, r.Scheme); err != nil {
+		return "", "", err
+	}
+	if err := r.Create(ctx, hostOp); err != nil {
+		if !errors.IsAlreadyExists(err) {
+			return "", "", err
+		}
+		// the cache has not seen the HostOperation created by the last reconcile yet
+		if err := r.Get(ctx, client.ObjectKey{Name: name}, hostOp); err != nil {
+			if errors.IsNotFound(err) {
+				return "", "", fmt.Errorf("HostOperation %s is not in the cache yet", name)
+			}
+			return "", "", err
+		}
+		return operationStatus(wf, hostOp)
+	}
+	return "", "", nil
+}
+
+// deletedOperation returns the status of the created HostOperation which is missing from the cache. the cache may
+// not have seen it yet, otherwise it is deleted and its result is taken from the operation history of the HostStatus
+func (r *HostWorkflowController) deletedOperation(ctx context.Context, wf *bmcv1beta1.HostWorkflow, name string) (string, string, error) {
+	hostOp := &bmcv1beta1.HostOperation{}
+	err := r.apiReader.Get(ctx, client.ObjectKey{Name: name}, hostOp)
+	if err == nil {
+		return operationStatus(wf, hostOp)
+	}
+	if !errors.IsNotFound(err) {
+		return "", "", err
+	}
+
+	hostStatus := &bmcv1beta1.HostStatus{}
+	if err := r.Get(ctx, client.ObjectKey{Name: wf.Spec.HostStatusName}, hostStatus); err != nil {
+		return "", "", err
+	}
+	for i := len(hostStatus.Status.OperationHistory) - 1; i >= 0; i-- {
+		if h := hostStatus.Status.OperationHistory[i]; h.Name == name {
+			return h.Result, h.Message, nil
+		}
+	}
+	return bmcv1beta1.HostOperationStatusFailed, fmt.Sprintf("HostOperation %s is deleted and its result is unknown", name), nil
+}
+
+// cancelOperation cancels the HostOperation of the step which times out
+func (r *HostWorkflowController) cancelOperation(ctx context.Context, name string, logger *zap.SugaredLogger) {
+	if name == "" {
+		return
+	}
+	hostOp := &bmcv1beta1.HostOperation{}
+	if err := r.Get(ctx, client.ObjectKey{Name: name}, hostOp); err != nil || hostOp.Spec.Cancel {
+		return
+	}
+	hostOp.Spec.Cancel = true
+	if err := r.Update(ctx, hostOp); err != nil {
+		logger.Warnf("failed to cancel HostOperation %s: %v", name, err)
+	}
+}
+
+func (r *HostWorkflowController) redfishClient(wf *bmcv1beta1.HostWorkflow, logger *zap.SugaredLogger) (redfish.RefishClient, error) {
+	d := data.HostCacheDatabase.Get(wf.Spec.HostStatusName)
+	if d == nil {
+		return nil, fmt.Errorf("no connect config of %s in cache", wf.Spec.HostStatusName)
+	}
+	return redfish.NewClient(*d, logger)
+}
+
+// SetupWithManager sets up the controller with the Manager
+func (r *HostWorkflowController) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&bmcv1beta1.HostWorkflow{}).
+		Owns(&bmcv1beta1.HostOperation{}).
+		Complete(r)
+}
@@ -0,0 +1,161 @@
+package hostworkflow_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spidernet-io/bmc/pkg/agent/config"
+	"github.com/spidernet-io/bmc/pkg/agent/hostworkflow"
+	bmcv1beta1 "github.com/spidernet-io/bmc/pkg/k8s/apis/bmc.spidernet.io/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+var _ = Describe("HostWorkflow", Label("unitest"), func() {
+	var (
+		ctx    context.Context
+		scheme *runtime.Scheme
+		wf     *bmcv1beta1.HostWorkflow
+		host   *bmcv1beta1.HostStatus
+	)
+	hostOperations := schema.GroupResource{Group: bmcv1beta1.GroupName, Resource: "hostoperations"}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		scheme = runtime.NewScheme()
+		Expect(bmcv1beta1.AddToScheme(scheme)).To(Succeed())
+		host = &bmcv1beta1.HostStatus{
+			ObjectMeta: metav1.ObjectMeta{Name: "host1"},
+			Status:     bmcv1beta1.HostStatusStatus{ClusterAgent: "agent1"},
+		}
+		wf = &bmcv1beta1.HostWorkflow{
+			ObjectMeta: metav1.ObjectMeta{Name: "upgrade", UID: "wf-uid"},
+			Spec: bmcv1beta1.HostWorkflowSpec{
+				HostStatusName: "host1",
+				Steps: []bmcv1beta1.HostWorkflowStep{{
+					Name:      "restart",
+					Operation: &bmcv1beta1.HostOperationActionSpec{Action: bmcv1beta1.BootCmdGracefulRestart},
+				}},
+			},
+		}
+	})
+
+	newController := func(funcs *interceptor.Funcs, objs ...client.Object) (*hostworkflow.HostWorkflowController, client.Client) {
+		builder := fake.NewClientBuilder().WithScheme(scheme).WithObjects(append(objs, host, wf)...).
+			WithStatusSubresource(&bmcv1beta1.HostWorkflow{}, &bmcv1beta1.HostOperation{})
+		if funcs != nil {
+			builder = builder.WithInterceptorFuncs(*funcs)
+		}
+		c := builder.Build()
+		return hostworkflow.NewTestController(c, scheme, &config.AgentConfig{ClusterAgentName: "agent1"}), c
+	}
+	reconcile := func(r *hostworkflow.HostWorkflowController) error {
+		_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKey{Name: wf.Name}})
+		return err
+	}
+	workflow := func(c client.Client) *bmcv1beta1.HostWorkflow {
+		result := &bmcv1beta1.HostWorkflow{}
+		Expect(c.Get(ctx, client.ObjectKey{Name: wf.Name}, result)).To(Succeed())
+		return result
+	}
+	operation := func(owned bool) *bmcv1beta1.HostOperation {
+		op := &bmcv1beta1.HostOperation{ObjectMeta: metav1.ObjectMeta{Name: "upgrade-0"}}
+		if owned {
+			Expect(controllerutil.SetControllerReference(wf, op, scheme)).To(Succeed())
+		}
+		return op
+	}
+	createFails := func(err error) *interceptor.Funcs {
+		return &interceptor.Funcs{Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+			return err
+		}}
+	}
+
+	It("creates the HostOperation of the step and waits for it", func() {
+		r, c := newController(nil)
+		Expect(reconcile(r)).To(Succeed())
+		Expect(workflow(c).Status.Phase).To(Equal(bmcv1beta1.HostWorkflowPhaseRunning))
+
+		op := &bmcv1beta1.HostOperation{}
+		Expect(c.Get(ctx, client.ObjectKey{Name: "upgrade-0"}, op)).To(Succeed())
+		Expect(metav1.IsControlledBy(op, wf)).To(BeTrue())
+		op.Status.Status = bmcv1beta1.HostOperationStatusSuccess
+		Expect(c.Status().Update(ctx, op)).To(Succeed())
+
+		Expect(reconcile(r)).To(Succeed())
+		Expect(workflow(c).Status.Phase).To(Equal(bmcv1beta1.HostWorkflowPhaseSucceeded))
+	})
+
+	It("takes the result of the deleted HostOperation from the history instead of creating it again", func() {
+		r, c := newController(nil)
+		Expect(reconcile(r)).To(Succeed())
+		op := &bmcv1beta1.HostOperation{}
+		Expect(c.Get(ctx, client.ObjectKey{Name: "upgrade-0"}, op)).To(Succeed())
+
+		// the GC deletes the finished HostOperation before the workflow sees its result
+		Expect(c.Delete(ctx, op)).To(Succeed())
+		hostStatus := &bmcv1beta1.HostStatus{}
+		Expect(c.Get(ctx, client.ObjectKey{Name: "host1"}, hostStatus)).To(Succeed())
+		hostStatus.Status.OperationHistory = []bmcv1beta1.OperationRecord{{Name: "upgrade-0", Result: bmcv1beta1.HostOperationStatusSuccess}}
+		Expect(c.Update(ctx, hostStatus)).To(Succeed())
+
+		Expect(reconcile(r)).To(Succeed())
+		Expect(workflow(c).Status.Phase).To(Equal(bmcv1beta1.HostWorkflowPhaseSucceeded))
+		err := c.Get(ctx, client.ObjectKey{Name: "upgrade-0"}, &bmcv1beta1.HostOperation{})
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("fails the step when the deleted HostOperation is not in the history", func() {
+		r, c := newController(nil)
+		Expect(reconcile(r)).To(Succeed())
+		Expect(c.Delete(ctx, &bmcv1beta1.HostOperation{ObjectMeta: metav1.ObjectMeta{Name: "upgrade-0"}})).To(Succeed())
+
+		Expect(reconcile(r)).To(Succeed())
+		Expect(workflow(c).Status.Phase).To(Equal(bmcv1beta1.HostWorkflowPhaseFailed))
+		err := c.Get(ctx, client.ObjectKey{Name: "upgrade-0"}, &bmcv1beta1.HostOperation{})
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("carries on with its own HostOperation which the cache has not seen", func() {
+		stale := true
+		funcs := &interceptor.Funcs{Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+			if _, ok := obj.(*bmcv1beta1.HostOperation); ok && stale {
+				stale = false
+				return apierrors.NewNotFound(hostOperations, key.Name)
+			}
+			return c.Get(ctx, key, obj, opts...)
+		}}
+		r, c := newController(funcs, operation(true))
+		Expect(reconcile(r)).To(Succeed())
+		Expect(workflow(c).Status.Phase).To(Equal(bmcv1beta1.HostWorkflowPhaseRunning))
+		Expect(workflow(c).Status.Steps[0].Phase).To(Equal(bmcv1beta1.WorkflowStepPhaseRunning))
+	})
+
+	It("fails the step when the HostOperation is not owned by the workflow", func() {
+		r, c := newController(nil, operation(false))
+		Expect(reconcile(r)).To(Succeed())
+		Expect(workflow(c).Status.Phase).To(Equal(bmcv1beta1.HostWorkflowPhaseFailed))
+	})
+
+	It("fails the step when the webhook denies the HostOperation", func() {
+		r, c := newController(createFails(apierrors.NewForbidden(hostOperations, "upgrade-0", nil)))
+		Expect(reconcile(r)).To(Succeed())
+		result := workflow(c)
+		Expect(result.Status.Phase).To(Equal(bmcv1beta1.HostWorkflowPhaseFailed))
+		Expect(result.Status.Steps[0].Phase).To(Equal(bmcv1beta1.WorkflowStepPhaseFailed))
+	})
+
+	It("retries the step when the HostOperation fails to be created for the other errors", func() {
+		r, c := newController(createFails(apierrors.NewServerTimeout(hostOperations, "create", 1)))
+		Expect(reconcile(r)).NotTo(Succeed())
+		Expect(workflow(c).Status.Phase).To(BeEmpty())
+	})
+})
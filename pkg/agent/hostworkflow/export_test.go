@@ -0,0 +1,12 @@
+package hostworkflow
+
+import (
+	"github.com/spidernet-io/bmc/pkg/agent/config"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// NewTestController returns the controller working with the client instead of a manager
+func NewTestController(c client.Client, scheme *runtime.Scheme, agentConfig *config.AgentConfig) *HostWorkflowController {
+	return &HostWorkflowController{Client: c, Scheme: scheme, apiReader: c, agentConfig: agentConfig}
+}
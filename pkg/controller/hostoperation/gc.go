@@ -0,0 +1,131 @@
+package hostoperation
+
+import (
+	"context"
+	"time"
+
+	bmcv1beta1 "github.com/spidernet-io/bmc/pkg/k8s/apis/bmc.spidernet.io/v1beta1"
+	"github.com/spidernet-io/bmc/pkg/log"
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// the interval to check again the operation used by an unfinished HostOperationSet, HostWorkflow or remediation
+const ownerCheckInterval = time.Minute
+
+// HostOperationGCReconciler deletes the finished HostOperations after their TTL expires, the agent has recorded
+// them in the operation history of the HostStatus when they finished
+type HostOperationGCReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+	// DefaultTTLSecondsAfterFinished applies to the HostOperations without spec.ttlSecondsAfterFinished, negative to keep them
+	DefaultTTLSecondsAfterFinished int
+}
+
+// finishTime returns the time when the operation finished, and false if it is not finished yet
+func finishTime(hostOp *bmcv1beta1.HostOperation) (time.Time, bool) {
+	switch hostOp.Status.Status {
+	case bmcv1beta1.HostOperationStatusSuccess, bmcv1beta1.HostOperationStatusFailed, bmcv1beta1.HostOperationStatusCancelled:
+	default:
+		return time.Time{}, false
+	}
+	// the indicator of a timed locate operation is not turned off yet
+	if hostOp.Status.LocateOffTime != "" {
+		return time.Time{}, false
+	}
+	// the node is not uncordoned yet
+	if hostOp.Status.Drain != nil && hostOp.Status.Drain.Phase == bmcv1beta1.DrainPhaseWaitingForReady {
+		return time.Time{}, false
+	}
+	t, err := time.Parse(time.RFC3339, hostOp.Status.LastUpdateTime)
+	if err != nil {
+		t = hostOp.CreationTimestamp.Time
+	}
+	return t, true
+}
+
+// Reconcile is part of the main kubernetes reconciliation loop
+func (r *HostOperationGCReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.Logger.With(
+		zap.String("reconcile", "hostoperation-gc"),
+		zap.String("name", req.Name),
+	)
+
+	hostOp := &bmcv1beta1.HostOperation{}
+	if err := r.Get(ctx, req.NamespacedName, hostOp); err != nil {
+		if errors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+	if hostOp.DeletionTimestamp != nil {
+		return ctrl.Result{}, nil
+	}
+
+	finished, ok := finishTime(hostOp)
+	if !ok {
+		return ctrl.Result{}, nil
+	}
+	ttl := r.DefaultTTLSecondsAfterFinished
+	if hostOp.Spec.TTLSecondsAfterFinished != nil {
+		ttl = int(*hostOp.Spec.TTLSecondsAfterFinished)
+	}
+	if ttl < 0 {
+		return ctrl.Result{}, nil
+	}
+	if wait := time.Until(finished.Add(time.Duration(ttl) * time.Second)); wait > 0 {
+		return ctrl.Result{RequeueAfter: wait}, nil
+	}
+
+	// the owner needs the result of its operations until it finishes
+	if r.inUse(ctx, hostOp) {
+		return ctrl.Result{RequeueAfter: ownerCheckInterval}, nil
+	}
+
+	logger.Infof("delete HostOperation %s finished at %s", hostOp.Name, finished.UTC().Format(time.RFC3339))
+	if err := r.Delete(ctx, hostOp); err != nil && !errors.IsNotFound(err) {
+		logger.Errorf("failed to delete HostOperation %s: %v", hostOp.Name, err)
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, nil
+}
+
+// inUse returns whether the operation is owned by a HostOperationSet or a HostWorkflow which is not finished,
+// or it is the step of the remediation of its host
+func (r *HostOperationGCReconciler) inUse(ctx context.Context, hostOp *bmcv1beta1.HostOperation) bool {
+	if _, ok := hostOp.Labels[bmcv1beta1.LabelRemediation]; ok {
+		hostStatus := &bmcv1beta1.HostStatus{}
+		if r.Get(ctx, client.ObjectKey{Name: hostOp.Spec.HostStatusName}, hostStatus) == nil &&
+			hostStatus.Status.Remediation != nil && hostStatus.Status.Remediation.HostOperation == hostOp.Name {
+			return true
+		}
+	}
+
+	owner := metav1.GetControllerOf(hostOp)
+	if owner == nil {
+		return false
+	}
+	switch owner.Kind {
+	case bmcv1beta1.KindHostOperationSet:
+		set := &bmcv1beta1.HostOperationSet{}
+		return r.Get(ctx, client.ObjectKey{Name: owner.Name}, set) == nil &&
+			set.Status.Phase != bmcv1beta1.HostOperationSetPhaseSucceeded && set.Status.Phase != bmcv1beta1.HostOperationSetPhaseFailed
+	case bmcv1beta1.KindHostWorkflow:
+		wf := &bmcv1beta1.HostWorkflow{}
+		return r.Get(ctx, client.ObjectKey{Name: owner.Name}, wf) == nil &&
+			wf.Status.Phase != bmcv1beta1.HostWorkflowPhaseSucceeded && wf.Status.Phase != bmcv1beta1.HostWorkflowPhaseFailed
+	}
+	return false
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *HostOperationGCReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("hostoperation-gc").
+		For(&bmcv1beta1.HostOperation{}).
+		Complete(r)
+}
@@ -0,0 +1,153 @@
+package hostoperation_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spidernet-io/bmc/pkg/controller/hostoperation"
+	bmcv1beta1 "github.com/spidernet-io/bmc/pkg/k8s/apis/bmc.spidernet.io/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("HostOperationGC", Label("unitest"), func() {
+	var (
+		ctx    context.Context
+		scheme *runtime.Scheme
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		scheme = runtime.NewScheme()
+		Expect(bmcv1beta1.AddToScheme(scheme)).To(Succeed())
+	})
+
+	operation := func(name, status string, finished time.Time) *bmcv1beta1.HostOperation {
+		return &bmcv1beta1.HostOperation{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: bmcv1beta1.HostOperationSpec{
+				HostStatusName:          "host1",
+				TTLSecondsAfterFinished: ptr.To[int32](60),
+			},
+			Status: bmcv1beta1.HostOperationStatus{
+				Status:         status,
+				LastUpdateTime: finished.UTC().Format(time.RFC3339),
+			},
+		}
+	}
+	host := func() *bmcv1beta1.HostStatus {
+		return &bmcv1beta1.HostStatus{ObjectMeta: metav1.ObjectMeta{Name: "host1"}}
+	}
+
+	newReconciler := func(objs ...client.Object) (*hostoperation.HostOperationGCReconciler, client.Client) {
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).
+			WithStatusSubresource(&bmcv1beta1.HostStatus{}, &bmcv1beta1.HostOperation{}, &bmcv1beta1.HostOperationSet{}, &bmcv1beta1.HostWorkflow{}).Build()
+		return &hostoperation.HostOperationGCReconciler{Client: c, Scheme: scheme, DefaultTTLSecondsAfterFinished: 60}, c
+	}
+	reconcile := func(r *hostoperation.HostOperationGCReconciler, name string) ctrl.Result {
+		result, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKey{Name: name}})
+		Expect(err).NotTo(HaveOccurred())
+		return result
+	}
+	exists := func(c client.Client, name string) bool {
+		err := c.Get(ctx, client.ObjectKey{Name: name}, &bmcv1beta1.HostOperation{})
+		if apierrors.IsNotFound(err) {
+			return false
+		}
+		Expect(err).NotTo(HaveOccurred())
+		return true
+	}
+
+	It("keeps the unfinished operation", func() {
+		r, c := newReconciler(host(), operation("op1", bmcv1beta1.HostOperationStatusRunning, time.Now().Add(-time.Hour)))
+		Expect(reconcile(r, "op1")).To(Equal(ctrl.Result{}))
+		Expect(exists(c, "op1")).To(BeTrue())
+	})
+
+	It("waits for the TTL before deleting the finished operation", func() {
+		r, c := newReconciler(host(), operation("op1", bmcv1beta1.HostOperationStatusSuccess, time.Now()))
+		result := reconcile(r, "op1")
+		Expect(result.RequeueAfter).To(BeNumerically(">", 0))
+		Expect(result.RequeueAfter).To(BeNumerically("<=", time.Minute))
+		Expect(exists(c, "op1")).To(BeTrue())
+	})
+
+	It("keeps the operation with a negative TTL", func() {
+		op := operation("op1", bmcv1beta1.HostOperationStatusFailed, time.Now().Add(-time.Hour))
+		op.Spec.TTLSecondsAfterFinished = ptr.To[int32](-1)
+		r, c := newReconciler(host(), op)
+		Expect(reconcile(r, "op1")).To(Equal(ctrl.Result{}))
+		Expect(exists(c, "op1")).To(BeTrue())
+	})
+
+	It("keeps the timed locate operation until the indicator is turned off", func() {
+		op := operation("op1", bmcv1beta1.HostOperationStatusSuccess, time.Now().Add(-time.Hour))
+		op.Status.LocateOffTime = time.Now().Add(-time.Minute).UTC().Format(time.RFC3339)
+		r, c := newReconciler(host(), op)
+		Expect(reconcile(r, "op1")).To(Equal(ctrl.Result{}))
+		Expect(exists(c, "op1")).To(BeTrue())
+	})
+
+	It("keeps the operation of an unfinished HostOperationSet", func() {
+		set := &bmcv1beta1.HostOperationSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "set1", UID: "set1-uid"},
+			Status:     bmcv1beta1.HostOperationSetStatus{Phase: bmcv1beta1.HostOperationSetPhaseRunning},
+		}
+		op := operation("op1", bmcv1beta1.HostOperationStatusSuccess, time.Now().Add(-time.Hour))
+		op.OwnerReferences = []metav1.OwnerReference{{
+			APIVersion: bmcv1beta1.SchemeGroupVersion.String(), Kind: bmcv1beta1.KindHostOperationSet,
+			Name: set.Name, UID: set.UID, Controller: ptr.To(true),
+		}}
+		r, c := newReconciler(host(), set, op)
+		Expect(reconcile(r, "op1")).To(Equal(ctrl.Result{RequeueAfter: time.Minute}))
+		Expect(exists(c, "op1")).To(BeTrue())
+
+		set.Status.Phase = bmcv1beta1.HostOperationSetPhaseSucceeded
+		Expect(c.Status().Update(ctx, set)).To(Succeed())
+		Expect(reconcile(r, "op1")).To(Equal(ctrl.Result{}))
+		Expect(exists(c, "op1")).To(BeFalse())
+	})
+
+	It("keeps the operation of an unfinished HostWorkflow even with a TTL of 0", func() {
+		wf := &bmcv1beta1.HostWorkflow{
+			ObjectMeta: metav1.ObjectMeta{Name: "wf1", UID: "wf1-uid"},
+			Status:     bmcv1beta1.HostWorkflowStatus{Phase: bmcv1beta1.HostWorkflowPhaseRunning},
+		}
+		op := operation("op1", bmcv1beta1.HostOperationStatusSuccess, time.Now())
+		op.Spec.TTLSecondsAfterFinished = ptr.To[int32](0)
+		op.OwnerReferences = []metav1.OwnerReference{{
+			APIVersion: bmcv1beta1.SchemeGroupVersion.String(), Kind: bmcv1beta1.KindHostWorkflow,
+			Name: wf.Name, UID: wf.UID, Controller: ptr.To(true),
+		}}
+		r, c := newReconciler(host(), wf, op)
+		Expect(reconcile(r, "op1")).To(Equal(ctrl.Result{RequeueAfter: time.Minute}))
+		Expect(exists(c, "op1")).To(BeTrue())
+
+		wf.Status.Phase = bmcv1beta1.HostWorkflowPhaseFailed
+		Expect(c.Status().Update(ctx, wf)).To(Succeed())
+		Expect(reconcile(r, "op1")).To(Equal(ctrl.Result{}))
+		Expect(exists(c, "op1")).To(BeFalse())
+	})
+
+	It("keeps the operation of the current remediation step", func() {
+		hs := host()
+		hs.Status.Remediation = &bmcv1beta1.RemediationStatus{Step: bmcv1beta1.RemediationStepGracefulRestart, HostOperation: "op1"}
+		op := operation("op1", bmcv1beta1.HostOperationStatusSuccess, time.Now().Add(-time.Hour))
+		op.Labels = map[string]string{bmcv1beta1.LabelRemediation: "host1"}
+		r, c := newReconciler(hs, op)
+		Expect(reconcile(r, "op1")).To(Equal(ctrl.Result{RequeueAfter: time.Minute}))
+		Expect(exists(c, "op1")).To(BeTrue())
+
+		hs.Status.Remediation = nil
+		Expect(c.Status().Update(ctx, hs)).To(Succeed())
+		Expect(reconcile(r, "op1")).To(Equal(ctrl.Result{}))
+		Expect(exists(c, "op1")).To(BeFalse())
+	})
+})
@@ -0,0 +1,250 @@
+package hostoperationschedule
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+
+	bmcv1beta1 "github.com/spidernet-io/bmc/pkg/k8s/apis/bmc.spidernet.io/v1beta1"
+	"github.com/spidernet-io/bmc/pkg/log"
+	"github.com/spidernet-io/bmc/pkg/schedule"
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// the maximum number of missed runs to look back, in case the controller is down for a long time
+const maxMissedRuns = 1000
+
+// HostOperationScheduleReconciler creates a HostOperationSet for each scheduled run of a HostOperationSchedule
+type HostOperationScheduleReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+func isFinished(set *bmcv1beta1.HostOperationSet) bool {
+	return set.Status.Phase == bmcv1beta1.HostOperationSetPhaseSucceeded || set.Status.Phase == bmcv1beta1.HostOperationSetPhaseFailed
+}
+
+// lastMissedRun returns the latest scheduled time in (since, now], and the next scheduled time after now
+func lastMissedRun(s *schedule.Schedule, since, now time.Time) (time.Time, time.Time) {
+	var missed time.Time
+	t := s.Next(since)
+	for i := 0; !t.IsZero() && !t.After(now); i++ {
+		missed = t
+		if i == maxMissedRuns {
+			// jump over the old runs
+			if jump := s.Next(now.Add(-24 * time.Hour)); !jump.IsZero() && jump.After(t) {
+				t = jump
+				continue
+			}
+		}
+		t = s.Next(t)
+	}
+	return missed, t
+}
+
+// Reconcile is part of the main kubernetes reconciliation loop
+func (r *HostOperationScheduleReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.Logger.With(
+		zap.String("reconcile", "hostoperationschedule"),
+		zap.String("name", req.Name),
+	)
+
+	sched := &bmcv1beta1.HostOperationSchedule{}
+	if err := r.Get(ctx, req.NamespacedName, sched); err != nil {
+		if errors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+	oldStatus := sched.Status.DeepCopy()
+
+	// classify the HostOperationSets of the schedule
+	setList := &bmcv1beta1.HostOperationSetList{}
+	if err := r.List(ctx, setList, client.MatchingLabels{bmcv1beta1.LabelHostOperationSchedule: bmcv1beta1.LabelValue(sched.Name)}); err != nil {
+		logger.Errorf("failed to list HostOperationSets: %v", err)
+		return ctrl.Result{}, err
+	}
+	var active, succeeded, failed []*bmcv1beta1.HostOperationSet
+	for i := range setList.Items {
+		set := &setList.Items[i]
+		if !metav1.IsControlledBy(set, sched) || set.DeletionTimestamp != nil {
+			continue
+		}
+		switch {
+		case !isFinished(set):
+			active = append(active, set)
+		case set.Status.Phase == bmcv1beta1.HostOperationSetPhaseSucceeded:
+			succeeded = append(succeeded, set)
+		default:
+			failed = append(failed, set)
+		}
+	}
+	r.cleanupHistory(ctx, succeeded, sched.Spec.SuccessfulHistoryLimit, 3, logger)
+	r.cleanupHistory(ctx, failed, sched.Spec.FailedHistoryLimit, 1, logger)
+
+	s, err := schedule.Parse(sched.Spec.Schedule)
+	if err != nil {
+		sched.Status.Message = fmt.Sprintf("invalid schedule: %v", err)
+		return ctrl.Result{}, r.updateStatus(ctx, sched, oldStatus, active, logger)
+	}
+	loc, err := schedule.LoadLocation(sched.Spec.TimeZone)
+	if err != nil {
+		sched.Status.Message = fmt.Sprintf("invalid time zone: %v", err)
+		return ctrl.Result{}, r.updateStatus(ctx, sched, oldStatus, active, logger)
+	}
+
+	now := time.Now().In(loc)
+	since := sched.CreationTimestamp.Time
+	if sched.Status.LastScheduleTime != "" {
+		if t, err := time.Parse(time.RFC3339, sched.Status.LastScheduleTime); err == nil {
+			since = t
+		}
+	}
+	missed, next := lastMissedRun(s, since.In(loc), now)
+	if next.IsZero() {
+		sched.Status.NextScheduleTime = ""
+	} else {
+		sched.Status.NextScheduleTime = next.UTC().Format(time.RFC3339)
+	}
+
+	if sched.Spec.Suspend {
+		sched.Status.Message = "suspended"
+		return ctrl.Result{}, r.updateStatus(ctx, sched, oldStatus, active, logger)
+	}
+	if missed.IsZero() {
+		return r.requeue(next, now), r.updateStatus(ctx, sched, oldStatus, active, logger)
+	}
+
+	// the run is handled no matter whether it starts or is skipped
+	sched.Status.LastScheduleTime = missed.UTC().Format(time.RFC3339)
+	if d := sched.Spec.StartingDeadlineSeconds; d != nil && now.Sub(missed) > time.Duration(*d)*time.Second {
+		logger.Warnf("skip the run scheduled at %s, which misses the starting deadline", missed)
+		sched.Status.Message = fmt.Sprintf("skipped the run scheduled at %s, it missed the starting deadline", sched.Status.LastScheduleTime)
+		return r.requeue(next, now), r.updateStatus(ctx, sched, oldStatus, active, logger)
+	}
+
+	if len(active) > 0 {
+		switch sched.Spec.ConcurrencyPolicy {
+		case bmcv1beta1.ConcurrencyPolicyForbid:
+			logger.Infof("skip the run scheduled at %s, since %d runs are still active", missed, len(active))
+			sched.Status.Message = fmt.Sprintf("skipped the run scheduled at %s, the previous run is still active", sched.Status.LastScheduleTime)
+			return r.requeue(next, now), r.updateStatus(ctx, sched, oldStatus, active, logger)
+		case bmcv1beta1.ConcurrencyPolicyReplace:
+			for _, set := range active {
+				logger.Infof("delete the active HostOperationSet %s to replace it", set.Name)
+				if err := r.Delete(ctx, set, client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil && !errors.IsNotFound(err) {
+					logger.Errorf("failed to delete HostOperationSet %s: %v", set.Name, err)
+					return ctrl.Result{}, err
+				}
+			}
+			active = nil
+		}
+	}
+
+	set, err := r.createSet(ctx, sched, missed)
+	if err != nil {
+		logger.Errorf("failed to create HostOperationSet for the run scheduled at %s: %v", missed, err)
+		return ctrl.Result{}, err
+	}
+	logger.Infof("created HostOperationSet %s for the run scheduled at %s", set.Name, missed)
+	sched.Status.Message = fmt.Sprintf("created HostOperationSet %s", set.Name)
+	active = append(active, set)
+	return r.requeue(next, now), r.updateStatus(ctx, sched, oldStatus, active, logger)
+}
+
+func (r *HostOperationScheduleReconciler) requeue(next, now time.Time) ctrl.Result {
+	if next.IsZero() {
+		return ctrl.Result{}
+	}
+	// a little later, to make sure the scheduled time has passed
+	return ctrl.Result{RequeueAfter: next.Sub(now) + time.Second}
+}
+
+func (r *HostOperationScheduleReconciler) updateStatus(ctx context.Context, sched *bmcv1beta1.HostOperationSchedule, oldStatus *bmcv1beta1.HostOperationScheduleStatus,
+	active []*bmcv1beta1.HostOperationSet, logger *zap.SugaredLogger) error {
+	sched.Status.Active = nil
+	for _, set := range active {
+		sched.Status.Active = append(sched.Status.Active, set.Name)
+	}
+	sort.Strings(sched.Status.Active)
+	if reflect.DeepEqual(oldStatus, &sched.Status) {
+		return nil
+	}
+	if err := r.Status().Update(ctx, sched); err != nil {
+		logger.Errorf("failed to update HostOperationSchedule status: %v", err)
+		return err
+	}
+	return nil
+}
+
+// cleanupHistory deletes the oldest finished HostOperationSets exceeding the limit
+func (r *HostOperationScheduleReconciler) cleanupHistory(ctx context.Context, sets []*bmcv1beta1.HostOperationSet, limit *int32, defaultLimit int32, logger *zap.SugaredLogger) {
+	n := defaultLimit
+	if limit != nil {
+		n = *limit
+	}
+	if int32(len(sets)) <= n {
+		return
+	}
+	sort.Slice(sets, func(i, j int) bool {
+		return sets[i].CreationTimestamp.Before(&sets[j].CreationTimestamp)
+	})
+	for _, set := range sets[:int32(len(sets))-n] {
+		logger.Debugf("delete the history HostOperationSet %s", set.Name)
+		if err := r.Delete(ctx, set, client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil && !errors.IsNotFound(err) {
+			logger.Errorf("failed to delete HostOperationSet %s: %v", set.Name, err)
+		}
+	}
+}
+
+// createSet creates the HostOperationSet of the run, named by the scheduled time in minutes like the CronJob
+func (r *HostOperationScheduleReconciler) createSet(ctx context.Context, sched *bmcv1beta1.HostOperationSchedule, scheduled time.Time) (*bmcv1beta1.HostOperationSet, error) {
+	set := &bmcv1beta1.HostOperationSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: fmt.Sprintf("%s-%d", sched.Name, scheduled.Unix()/60),
+			Labels: map[string]string{
+				bmcv1beta1.LabelHostOperationSchedule: bmcv1beta1.LabelValue(sched.Name),
+			},
+			Annotations: map[string]string{
+				bmcv1beta1.AnnotationHostOperationSchedule: sched.Name,
+			},
+		},
+		Spec: bmcv1beta1.HostOperationSetSpec{
+			Selector:        sched.Spec.Selector.DeepCopy(),
+			HostStatusNames: append([]string(nil), sched.Spec.HostStatusNames...),
+			Template:        *sched.Spec.Template.DeepCopy(),
+			MaxConcurrent:   sched.Spec.MaxConcurrent,
+			MaxFailures:     sched.Spec.MaxFailures,
+			Reason:          sched.Spec.Reason,
+			Requester:       sched.Spec.Requester.DeepCopy(),
+		},
+	}
+	if err := controllerutil.SetControllerReference(sched, set, r.Scheme); err != nil {
+		return nil, err
+	}
+	if err := r.Create(ctx, set); err != nil {
+		if !errors.IsAlreadyExists(err) {
+			return nil, err
+		}
+		if err := r.Get(ctx, client.ObjectKey{Name: set.Name}, set); err != nil {
+			return nil, err
+		}
+	}
+	return set, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *HostOperationScheduleReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&bmcv1beta1.HostOperationSchedule{}).
+		Owns(&bmcv1beta1.HostOperationSet{}).
+		Complete(r)
+}
@@ -0,0 +1,43 @@
+package hostoperationschedule_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spidernet-io/bmc/pkg/controller/hostoperationschedule"
+	"github.com/spidernet-io/bmc/pkg/schedule"
+)
+
+var _ = Describe("HostOperationSchedule", Label("unitest"), func() {
+	date := func(month time.Month, day, hour, min int) time.Time {
+		return time.Date(2024, month, day, hour, min, 0, 0, time.UTC)
+	}
+	parse := func(spec string) *schedule.Schedule {
+		s, err := schedule.Parse(spec)
+		Expect(err).NotTo(HaveOccurred())
+		return s
+	}
+
+	It("returns no missed run before the next scheduled time", func() {
+		missed, next := hostoperationschedule.LastMissedRun(parse("0 22 * * *"), date(3, 15, 22, 0), date(3, 16, 21, 0))
+		Expect(missed.IsZero()).To(BeTrue())
+		Expect(next).To(Equal(date(3, 16, 22, 0)))
+	})
+
+	It("returns the latest of the missed runs", func() {
+		missed, next := hostoperationschedule.LastMissedRun(parse("0 22 * * *"), date(3, 15, 22, 0), date(3, 18, 8, 0))
+		Expect(missed).To(Equal(date(3, 17, 22, 0)))
+		Expect(next).To(Equal(date(3, 18, 22, 0)))
+
+		missed, next = hostoperationschedule.LastMissedRun(parse("0 22 * * *"), date(3, 15, 22, 0), date(3, 16, 22, 0))
+		Expect(missed).To(Equal(date(3, 16, 22, 0)))
+		Expect(next).To(Equal(date(3, 17, 22, 0)))
+	})
+
+	It("jumps over the old runs after a long outage", func() {
+		missed, next := hostoperationschedule.LastMissedRun(parse("* * * * *"), date(1, 1, 0, 0), date(3, 18, 8, 30))
+		Expect(missed).To(Equal(date(3, 18, 8, 30)))
+		Expect(next).To(Equal(date(3, 18, 8, 31)))
+	})
+})
@@ -0,0 +1,4 @@
+package hostoperationschedule
+
+// LastMissedRun is exported for the tests
+var LastMissedRun = lastMissedRun
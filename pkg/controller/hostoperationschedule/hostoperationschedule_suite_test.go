@@ -0,0 +1,13 @@
+package hostoperationschedule_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestHostOperationSchedule(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "HostOperationSchedule Suite")
+}
@@ -0,0 +1,308 @@
+package hostoperationset
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"reflect"
+	"sort"
+	"time"
+
+	bmcv1beta1 "github.com/spidernet-io/bmc/pkg/k8s/apis/bmc.spidernet.io/v1beta1"
+	"github.com/spidernet-io/bmc/pkg/log"
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// HostOperationSetReconciler creates the HostOperation of each host selected by a HostOperationSet,
+// and the HostOperations are processed by the agents as usual
+type HostOperationSetReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+// childName returns the name of the HostOperation created for the host
+func childName(setName, hostName string) string {
+	name := fmt.Sprintf("%s-%s", setName, hostName)
+	if len(name) <= 253 {
+		return name
+	}
+	h := fnv.New32a()
+	h.Write([]byte(name))
+	return fmt.Sprintf("%s-%x", name[:244], h.Sum32())
+}
+
+// resolveHosts returns the sorted names of the hosts selected by the set
+func (r *HostOperationSetReconciler) resolveHosts(ctx context.Context, set *bmcv1beta1.HostOperationSet) ([]string, error) {
+	names := map[string]struct{}{}
+	for _, n := range set.Spec.HostStatusNames {
+		names[n] = struct{}{}
+	}
+	if set.Spec.Selector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(set.Spec.Selector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid selector: %v", err)
+		}
+		hostStatusList := &bmcv1beta1.HostStatusList{}
+		if err := r.List(ctx, hostStatusList, client.MatchingLabelsSelector{Selector: selector}); err != nil {
+			return nil, err
+		}
+		for _, item := range hostStatusList.Items {
+			names[item.Name] = struct{}{}
+		}
+	}
+
+	result := make([]string, 0, len(names))
+	for n := range names {
+		result = append(result, n)
+	}
+	sort.Strings(result)
+	return result, nil
+}
+
+func isDone(status string) bool {
+	return status == bmcv1beta1.HostOperationStatusSuccess || status == bmcv1beta1.HostOperationStatusFailed ||
+		status == bmcv1beta1.HostOperationStatusCancelled
+}
+
+// Reconcile is part of the main kubernetes reconciliation loop
+func (r *HostOperationSetReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.Logger.With(
+		zap.String("reconcile", "hostoperationset"),
+		zap.String("name", req.Name),
+	)
+
+	set := &bmcv1beta1.HostOperationSet{}
+	if err := r.Get(ctx, req.NamespacedName, set); err != nil {
+		if errors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+	if set.Status.Phase == bmcv1beta1.HostOperationSetPhaseSucceeded || set.Status.Phase == bmcv1beta1.HostOperationSetPhaseFailed {
+		return ctrl.Result{}, nil
+	}
+	oldStatus := set.Status.DeepCopy()
+
+	// the hosts are resolved once, so that the hosts joining later are not operated by a running set
+	if set.Status.Phase == "" {
+		hosts, err := r.resolveHosts(ctx, set)
+		if err != nil {
+			logger.Errorf("failed to resolve hosts: %v", err)
+			return ctrl.Result{}, err
+		}
+		logger.Infof("HostOperationSet %s selects %d hosts: %v", set.Name, len(hosts), hosts)
+		set.Status.StartTime = time.Now().UTC().Format(time.RFC3339)
+		set.Status.Phase = bmcv1beta1.HostOperationSetPhasePending
+		set.Status.Hosts = make([]bmcv1beta1.HostOperationSetHostStatus, 0, len(hosts))
+		for _, h := range hosts {
+			set.Status.Hosts = append(set.Status.Hosts, bmcv1beta1.HostOperationSetHostStatus{HostStatusName: h})
+		}
+	}
+
+	// sync the result of the created HostOperations
+	children := &bmcv1beta1.HostOperationList{}
+	if err := r.List(ctx, children, client.MatchingLabels{bmcv1beta1.LabelHostOperationSet: bmcv1beta1.LabelValue(set.Name)}); err != nil {
+		logger.Errorf("failed to list HostOperations: %v", err)
+		return ctrl.Result{}, err
+	}
+	childMap := map[string]*bmcv1beta1.HostOperation{}
+	for i := range children.Items {
+		if metav1.IsControlledBy(&children.Items[i], set) {
+			childMap[children.Items[i].Name] = &children.Items[i]
+		}
+	}
+	for i := range set.Status.Hosts {
+		h := &set.Status.Hosts[i]
+		if h.HostOperationName == "" || isDone(h.Status) {
+			continue
+		}
+		if child, ok := childMap[h.HostOperationName]; ok {
+			h.Status = child.Status.Status
+			if h.Status == "" {
+				h.Status = bmcv1beta1.HostOperationStatusPending
+			}
+			h.Message = child.Status.Message
+		}
+	}
+
+	running, _, failed := countHosts(set.Status.Hosts)
+
+	// pause the set when a new host fails
+	if set.Spec.PauseOnFailure && !set.Spec.Paused && failed > set.Status.PausedFailures {
+		logger.Infof("pause HostOperationSet %s since %d hosts failed", set.Name, failed)
+		set.Spec.Paused = true
+		// the update refreshes the object with the status on the server
+		status := set.Status.DeepCopy()
+		if err := r.Update(ctx, set); err != nil {
+			logger.Errorf("failed to pause HostOperationSet: %v", err)
+			return ctrl.Result{}, err
+		}
+		set.Status = *status
+		set.Status.PausedFailures = failed
+	}
+
+	// operate more hosts
+	var createErr error
+	exceeded := failed > set.Spec.MaxFailures
+	if !set.Spec.Paused && !exceeded {
+		maxConcurrent := set.Spec.MaxConcurrent
+		if maxConcurrent <= 0 {
+			maxConcurrent = 1
+		}
+		for i := range set.Status.Hosts {
+			if running >= maxConcurrent {
+				break
+			}
+			h := &set.Status.Hosts[i]
+			if h.HostOperationName != "" {
+				continue
+			}
+			name := childName(set.Name, h.HostStatusName)
+			if err := r.createChild(ctx, set, name, h.HostStatusName); err != nil {
+				// the webhook rejects the hosts which are missing or unhealthy, the other errors such as a timeout are retried
+				if !errors.IsForbidden(err) && !errors.IsInvalid(err) && !errors.IsAlreadyExists(err) {
+					logger.Errorf("failed to create HostOperation %s for host %s, retry later: %v", name, h.HostStatusName, err)
+					createErr = err
+					break
+				}
+				logger.Errorf("failed to create HostOperation %s for host %s: %v", name, h.HostStatusName, err)
+				h.HostOperationName = name
+				h.Status = bmcv1beta1.HostOperationStatusFailed
+				h.Message = fmt.Sprintf("failed to create HostOperation: %v", err)
+				failed++
+				if failed > set.Spec.MaxFailures || set.Spec.PauseOnFailure {
+					break
+				}
+				continue
+			}
+			logger.Infof("created HostOperation %s for host %s", name, h.HostStatusName)
+			h.HostOperationName = name
+			h.Status = bmcv1beta1.HostOperationStatusPending
+			running++
+		}
+	}
+
+	r.updatePhase(set)
+	if !reflect.DeepEqual(oldStatus, &set.Status) {
+		if err := r.Status().Update(ctx, set); err != nil {
+			logger.Errorf("failed to update HostOperationSet status: %v", err)
+			return ctrl.Result{}, err
+		}
+	}
+	return ctrl.Result{}, createErr
+}
+
+func countHosts(hosts []bmcv1beta1.HostOperationSetHostStatus) (running, succeeded, failed int32) {
+	for _, h := range hosts {
+		switch h.Status {
+		case bmcv1beta1.HostOperationStatusSuccess:
+			succeeded++
+		case bmcv1beta1.HostOperationStatusFailed, bmcv1beta1.HostOperationStatusCancelled:
+			failed++
+		case "":
+		default:
+			running++
+		}
+	}
+	return
+}
+
+// updatePhase aggregates the result of the hosts
+func (r *HostOperationSetReconciler) updatePhase(set *bmcv1beta1.HostOperationSet) {
+	running, succeeded, failed := countHosts(set.Status.Hosts)
+	total := int32(len(set.Status.Hosts))
+	set.Status.Total = total
+	set.Status.Running = running
+	set.Status.Succeeded = succeeded
+	set.Status.Failed = failed
+	waiting := total - running - succeeded - failed
+
+	switch {
+	case total == 0:
+		set.Status.Phase = bmcv1beta1.HostOperationSetPhaseFailed
+		set.Status.Message = "no host is selected"
+	case running > 0:
+		set.Status.Phase = bmcv1beta1.HostOperationSetPhaseRunning
+		set.Status.Message = fmt.Sprintf("%d hosts are being operated", running)
+	case failed > set.Spec.MaxFailures:
+		set.Status.Phase = bmcv1beta1.HostOperationSetPhaseFailed
+		set.Status.Message = fmt.Sprintf("%d hosts failed, exceeding maxFailures %d, %d hosts are not operated", failed, set.Spec.MaxFailures, waiting)
+	case waiting == 0:
+		set.Status.Phase = bmcv1beta1.HostOperationSetPhaseSucceeded
+		set.Status.Message = fmt.Sprintf("%d hosts succeeded, %d hosts failed", succeeded, failed)
+	case set.Spec.Paused:
+		set.Status.Phase = bmcv1beta1.HostOperationSetPhasePaused
+		set.Status.Message = fmt.Sprintf("paused, %d hosts are waiting", waiting)
+	default:
+		set.Status.Phase = bmcv1beta1.HostOperationSetPhaseRunning
+		set.Status.Message = fmt.Sprintf("%d hosts are waiting", waiting)
+	}
+
+	if (set.Status.Phase == bmcv1beta1.HostOperationSetPhaseSucceeded || set.Status.Phase == bmcv1beta1.HostOperationSetPhaseFailed) && set.Status.CompletionTime == "" {
+		set.Status.CompletionTime = time.Now().UTC().Format(time.RFC3339)
+	}
+}
+
+// createChild creates the HostOperation of the host, owned by the set
+func (r *HostOperationSetReconciler) createChild(ctx context.Context, set *bmcv1beta1.HostOperationSet, name, hostName string) error {
+	hostOp := &bmcv1beta1.HostOperation{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+			Labels: map[string]string{
+				bmcv1beta1.LabelHostOperationSet: bmcv1beta1.LabelValue(set.Name),
+				bmcv1beta1.LabelHostStatusName:   bmcv1beta1.LabelValue(hostName),
+			},
+			Annotations: map[string]string{
+				bmcv1beta1.AnnotationHostOperationSet: set.Name,
+			},
+		},
+		Spec: bmcv1beta1.HostOperationSpec{
+			HostOperationActionSpec: *set.Spec.Template.DeepCopy(),
+			HostStatusName:          hostName,
+			Reason:                  set.Spec.Reason,
+			Requester:               set.Spec.Requester.DeepCopy(),
+		},
+	}
+	// the HostOperations created by a schedule can be found by the label of the schedule
+	if v, ok := set.Labels[bmcv1beta1.LabelHostOperationSchedule]; ok {
+		hostOp.Labels[bmcv1beta1.LabelHostOperationSchedule] = v
+		hostOp.Annotations[bmcv1beta1.AnnotationHostOperationSchedule] = set.Annotations[bmcv1beta1.AnnotationHostOperationSchedule]
+	}
+	// the override of the HostPolicy applies to all the hosts of the set
+	if v, ok := set.Annotations[bmcv1beta1.AnnotationOverridePolicy]; ok {
+		hostOp.Annotations[bmcv1beta1.AnnotationOverridePolicy] = v
+	}
+	if err := controllerutil.SetControllerReference(set, hostOp, r.Scheme); err != nil {
+		return err
+	}
+	err := r.Create(ctx, hostOp)
+	if errors.IsAlreadyExists(err) {
+		existing := &bmcv1beta1.HostOperation{}
+		if err := r.Get(ctx, client.ObjectKey{Name: name}, existing); err != nil {
+			if errors.IsNotFound(err) {
+				return fmt.Errorf("HostOperation %s is not in the cache yet", name)
+			}
+			return err
+		}
+		if !metav1.IsControlledBy(existing, set) {
+			return errors.NewAlreadyExists(schema.GroupResource{Group: bmcv1beta1.GroupName, Resource: "hostoperations"}, name)
+		}
+		return nil
+	}
+	return err
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *HostOperationSetReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&bmcv1beta1.HostOperationSet{}).
+		Owns(&bmcv1beta1.HostOperation{}).
+		Complete(r)
+}
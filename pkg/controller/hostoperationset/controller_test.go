@@ -0,0 +1,84 @@
+package hostoperationset_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spidernet-io/bmc/pkg/controller/hostoperationset"
+	bmcv1beta1 "github.com/spidernet-io/bmc/pkg/k8s/apis/bmc.spidernet.io/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+var _ = Describe("HostOperationSet", Label("unitest"), func() {
+	var (
+		ctx    context.Context
+		scheme *runtime.Scheme
+	)
+	hostOperations := schema.GroupResource{Group: bmcv1beta1.GroupName, Resource: "hostoperations"}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		scheme = runtime.NewScheme()
+		Expect(bmcv1beta1.AddToScheme(scheme)).To(Succeed())
+	})
+
+	// the Create of the HostOperation for host1 fails with the error
+	run := func(createErr error) (*bmcv1beta1.HostOperationSet, error) {
+		set := &bmcv1beta1.HostOperationSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "restart"},
+			Spec: bmcv1beta1.HostOperationSetSpec{
+				HostStatusNames: []string{"host1", "host2"},
+				Template:        bmcv1beta1.HostOperationActionSpec{Action: bmcv1beta1.BootCmdGracefulRestart},
+				MaxConcurrent:   2,
+				MaxFailures:     1,
+			},
+		}
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(set).
+			WithStatusSubresource(&bmcv1beta1.HostOperationSet{}, &bmcv1beta1.HostOperation{}).
+			WithInterceptorFuncs(interceptor.Funcs{Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+				if op, ok := obj.(*bmcv1beta1.HostOperation); ok && op.Spec.HostStatusName == "host1" && createErr != nil {
+					return createErr
+				}
+				return c.Create(ctx, obj, opts...)
+			}}).Build()
+		r := &hostoperationset.HostOperationSetReconciler{Client: c, Scheme: scheme}
+		_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKey{Name: set.Name}})
+		result := &bmcv1beta1.HostOperationSet{}
+		Expect(c.Get(ctx, client.ObjectKey{Name: set.Name}, result)).To(Succeed())
+		return result, err
+	}
+
+	It("creates the HostOperations of the hosts", func() {
+		set, err := run(nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(set.Status.Hosts).To(HaveLen(2))
+		for _, h := range set.Status.Hosts {
+			Expect(h.Status).To(Equal(bmcv1beta1.HostOperationStatusPending))
+			Expect(h.HostOperationName).To(Equal("restart-" + h.HostStatusName))
+		}
+	})
+
+	It("marks the host denied by the webhook as failed", func() {
+		set, err := run(apierrors.NewForbidden(hostOperations, "restart-host1", nil))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(set.Status.Hosts[0].HostStatusName).To(Equal("host1"))
+		Expect(set.Status.Hosts[0].Status).To(Equal(bmcv1beta1.HostOperationStatusFailed))
+		Expect(set.Status.Hosts[1].Status).To(Equal(bmcv1beta1.HostOperationStatusPending))
+	})
+
+	It("retries the host when the HostOperation fails to be created for the other errors", func() {
+		set, err := run(apierrors.NewServerTimeout(hostOperations, "create", 1))
+		Expect(err).To(HaveOccurred())
+		Expect(set.Status.Hosts[0].HostStatusName).To(Equal("host1"))
+		Expect(set.Status.Hosts[0].Status).To(BeEmpty())
+		Expect(set.Status.Hosts[0].HostOperationName).To(BeEmpty())
+	})
+})
@@ -0,0 +1,350 @@
+package hostpool
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	bmcv1beta1 "github.com/spidernet-io/bmc/pkg/k8s/apis/bmc.spidernet.io/v1beta1"
+	"github.com/spidernet-io/bmc/pkg/log"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	powerStateOn  = "On"
+	powerStateOff = "Off"
+
+	// the time to wait for the agent to observe the power state after the HostOperation succeeds
+	observeTimeout = 5 * time.Minute
+	// the interval of checking the hosts being powered on or off
+	transitionInterval = 30 * time.Second
+	// the time a host is skipped after the pool fails to power it on or off, so that the other hosts are tried
+	failureBackoff = 30 * time.Minute
+)
+
+// HostPoolReconciler powers on or off the hosts of a HostPool through the HostOperations,
+// until the number of the hosts powered on is spec.replicas
+type HostPoolReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+}
+
+// targetOf returns the power state the HostOperation of the pool brings the host to
+func targetOf(op *bmcv1beta1.HostOperation) string {
+	if op.Spec.Action == bmcv1beta1.BootCmdOn {
+		return powerStateOn
+	}
+	return powerStateOff
+}
+
+// inTransition returns whether the HostOperation is still powering on or off the host,
+// it lasts until the agent observes the target power state after the operation succeeds
+func inTransition(op *bmcv1beta1.HostOperation, powerState string) bool {
+	switch op.Status.Status {
+	case "", bmcv1beta1.HostOperationStatusPending, bmcv1beta1.HostOperationStatusRunning:
+		return true
+	case bmcv1beta1.HostOperationStatusSuccess:
+		if powerState == targetOf(op) {
+			return false
+		}
+		t, err := time.Parse(time.RFC3339, op.Status.LastUpdateTime)
+		return err == nil && time.Since(t) < observeTimeout
+	}
+	return false
+}
+
+// failedRecently returns whether the HostOperation of the pool failed to bring the host to the target power state
+// within failureBackoff, including the one succeeding without the power state ever changing
+func failedRecently(op *bmcv1beta1.HostOperation, powerState string, now time.Time) bool {
+	switch op.Status.Status {
+	case bmcv1beta1.HostOperationStatusFailed:
+	case bmcv1beta1.HostOperationStatusSuccess:
+		if powerState == targetOf(op) {
+			return false
+		}
+	default:
+		return false
+	}
+	t, err := time.Parse(time.RFC3339, op.Status.LastUpdateTime)
+	return err == nil && now.Sub(t) < failureBackoff
+}
+
+// powerOffCandidates returns the hosts which could be powered off, the ones without a node come first,
+// then the ones powered on most recently. the hosts on for less than minOn are left
+func powerOffCandidates(members []bmcv1beta1.HostPoolMember, skipped map[string]bool, minOn time.Duration, now time.Time) ([]bmcv1beta1.HostPoolMember, time.Duration) {
+	var candidates []bmcv1beta1.HostPoolMember
+	var wait time.Duration
+	for _, m := range members {
+		if m.PowerState != powerStateOn || m.HostOperation != "" || skipped[m.Name] {
+			continue
+		}
+		on, err := time.Parse(time.RFC3339, m.PowerOnTime)
+		if err == nil && now.Sub(on) < minOn {
+			if w := minOn - now.Sub(on); wait == 0 || w < wait {
+				wait = w
+			}
+			continue
+		}
+		candidates = append(candidates, m)
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if (candidates[i].NodeName == "") != (candidates[j].NodeName == "") {
+			return candidates[i].NodeName == ""
+		}
+		return candidates[i].PowerOnTime > candidates[j].PowerOnTime
+	})
+	return candidates, wait
+}
+
+// Reconcile is part of the main kubernetes reconciliation loop
+func (r *HostPoolReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.Logger.With(
+		zap.String("reconcile", "hostpool"),
+		zap.String("name", req.Name),
+	)
+
+	pool := &bmcv1beta1.HostPool{}
+	if err := r.Get(ctx, req.NamespacedName, pool); err != nil {
+		if errors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+	oldStatus := pool.Status.DeepCopy()
+	now := time.Now().UTC()
+
+	selector, err := metav1.LabelSelectorAsSelector(pool.Spec.Selector)
+	if err != nil {
+		pool.Status.Message = fmt.Sprintf("invalid selector: %v", err)
+		return ctrl.Result{}, r.updateStatus(ctx, pool, oldStatus, logger)
+	}
+	hostList := &bmcv1beta1.HostStatusList{}
+	if err := r.List(ctx, hostList, client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		logger.Errorf("failed to list HostStatus: %v", err)
+		return ctrl.Result{}, err
+	}
+	opList := &bmcv1beta1.HostOperationList{}
+	if err := r.List(ctx, opList, client.MatchingLabels{bmcv1beta1.LabelHostPool: bmcv1beta1.LabelValue(pool.Name)}); err != nil {
+		logger.Errorf("failed to list HostOperations: %v", err)
+		return ctrl.Result{}, err
+	}
+	latest := map[string]*bmcv1beta1.HostOperation{}
+	for i := range opList.Items {
+		op := &opList.Items[i]
+		if op.Annotations[bmcv1beta1.AnnotationHostPool] != pool.Name {
+			continue
+		}
+		if last, ok := latest[op.Spec.HostStatusName]; !ok || last.CreationTimestamp.Before(&op.CreationTimestamp) {
+			latest[op.Spec.HostStatusName] = op
+		}
+	}
+	previous := map[string]bmcv1beta1.HostPoolMember{}
+	for _, m := range pool.Status.Hosts {
+		previous[m.Name] = m
+	}
+
+	// the hosts being powered on count as on, and the ones being powered off count as off
+	sort.Slice(hostList.Items, func(i, j int) bool { return hostList.Items[i].Name < hostList.Items[j].Name })
+	members := []bmcv1beta1.HostPoolMember{}
+	// the hosts failing to be powered on or off recently are skipped, so that the scaling moves on to the other hosts
+	skipped := map[string]bool{}
+	var on int32
+	transitioning := false
+	for i := range hostList.Items {
+		hs := &hostList.Items[i]
+		m := bmcv1beta1.HostPoolMember{Name: hs.Name, NodeName: hs.Status.NodeName}
+		if hs.Status.Healthy {
+			m.PowerState = hs.Status.Info["PowerState"]
+		}
+		target := m.PowerState
+		if op := latest[hs.Name]; op != nil && inTransition(op, m.PowerState) {
+			m.HostOperation = op.Name
+			target = targetOf(op)
+			transitioning = true
+		} else if op != nil && failedRecently(op, m.PowerState, now) {
+			skipped[hs.Name] = true
+		}
+		if target == powerStateOn {
+			on++
+			if m.PowerOnTime = previous[hs.Name].PowerOnTime; m.PowerOnTime == "" {
+				m.PowerOnTime = now.Format(time.RFC3339)
+			}
+		}
+		members = append(members, m)
+	}
+	pool.Status.Total = int32(len(members))
+	pool.Status.Replicas = on
+	pool.Status.Selector = selector.String()
+	pool.Status.Hosts = members
+	pool.Status.Message = ""
+
+	result := ctrl.Result{}
+	if transitioning {
+		result.RequeueAfter = transitionInterval
+	}
+	if pool.Spec.Replicas == nil || *pool.Spec.Replicas == on {
+		return result, r.updateStatus(ctx, pool, oldStatus, logger)
+	}
+
+	desired := *pool.Spec.Replicas
+	if last, err := time.Parse(time.RFC3339, pool.Status.LastScaleTime); err == nil {
+		if wait := last.Add(time.Duration(pool.Spec.CooldownSeconds) * time.Second).Sub(now); wait > 0 {
+			pool.Status.Message = fmt.Sprintf("cooling down until %s", now.Add(wait).Format(time.RFC3339))
+			return ctrl.Result{RequeueAfter: wait}, r.updateStatus(ctx, pool, oldStatus, logger)
+		}
+	}
+
+	var picked []bmcv1beta1.HostPoolMember
+	action := bmcv1beta1.BootCmdOn
+	if desired > on {
+		for _, m := range members {
+			if m.PowerState == powerStateOff && m.HostOperation == "" && !skipped[m.Name] && int32(len(picked)) < desired-on {
+				picked = append(picked, m)
+			}
+		}
+		if len(picked) == 0 {
+			pool.Status.Message = fmt.Sprintf("%d hosts are on, no more host could be powered on", on)
+			if len(skipped) > 0 {
+				pool.Status.Message += fmt.Sprintf(", %d hosts failed to be powered on or off recently", len(skipped))
+				result.RequeueAfter = transitionInterval
+			}
+		}
+	} else {
+		action = bmcv1beta1.BootCmdGracefulShutdown
+		candidates, wait := powerOffCandidates(members, skipped, time.Duration(pool.Spec.MinOnSeconds)*time.Second, now)
+		if int32(len(candidates)) > on-desired {
+			candidates = candidates[:on-desired]
+		}
+		picked = candidates
+		if int32(len(picked)) < on-desired && wait > 0 {
+			pool.Status.Message = fmt.Sprintf("some hosts are on for less than %d seconds", pool.Spec.MinOnSeconds)
+			result.RequeueAfter = wait
+		} else if int32(len(picked)) < on-desired && len(skipped) > 0 {
+			pool.Status.Message = fmt.Sprintf("%d hosts failed to be powered on or off recently", len(skipped))
+			result.RequeueAfter = transitionInterval
+		}
+	}
+
+	created := 0
+	for _, m := range picked {
+		op, err := r.createOperation(ctx, pool, m, action, now)
+		if err != nil {
+			logger.Errorf("failed to create HostOperation %s for host %s: %v", action, m.Name, err)
+			r.Recorder.Event(pool, corev1.EventTypeWarning, "ScaleFailed", fmt.Sprintf("failed to %s host %s: %v", action, m.Name, err))
+			continue
+		}
+		created++
+		for i := range pool.Status.Hosts {
+			if pool.Status.Hosts[i].Name == m.Name {
+				pool.Status.Hosts[i].HostOperation = op.Name
+			}
+		}
+		r.Recorder.Event(pool, corev1.EventTypeNormal, "Scale"+action, fmt.Sprintf("%s host %s by HostOperation %s", action, m.Name, op.Name))
+	}
+	if created > 0 {
+		logger.Infof("%s %d hosts, %d hosts are on and %d are desired", action, created, on, desired)
+		pool.Status.LastScaleTime = now.Format(time.RFC3339)
+		if action == bmcv1beta1.BootCmdOn {
+			pool.Status.Replicas += int32(created)
+		} else {
+			pool.Status.Replicas -= int32(created)
+		}
+		result.RequeueAfter = transitionInterval
+	}
+	return result, r.updateStatus(ctx, pool, oldStatus, logger)
+}
+
+// createOperation powers on or off the host
+func (r *HostPoolReconciler) createOperation(ctx context.Context, pool *bmcv1beta1.HostPool, m bmcv1beta1.HostPoolMember, action string, now time.Time) (*bmcv1beta1.HostOperation, error) {
+	op := newOperation(pool, m, action, now)
+	return op, r.Create(ctx, op)
+}
+
+// newOperation builds the HostOperation powering on or off the host. the node of the host is always drained before the
+// host is powered off, by the drain policy of the pool or the default one, so the power off fails instead of killing
+// the workloads when the pods could not be evicted
+func newOperation(pool *bmcv1beta1.HostPool, m bmcv1beta1.HostPoolMember, action string, now time.Time) *bmcv1beta1.HostOperation {
+	op := &bmcv1beta1.HostOperation{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        fmt.Sprintf("%s-%s-%s-%d", pool.Name, m.Name, strings.ToLower(action), now.Unix()),
+			Labels:      map[string]string{bmcv1beta1.LabelHostPool: bmcv1beta1.LabelValue(pool.Name)},
+			Annotations: map[string]string{bmcv1beta1.AnnotationHostPool: pool.Name},
+		},
+		Spec: bmcv1beta1.HostOperationSpec{
+			HostOperationActionSpec: bmcv1beta1.HostOperationActionSpec{
+				Action: action,
+			},
+			HostStatusName: m.Name,
+			Reason:         fmt.Sprintf("HostPool %s scales to %d hosts", pool.Name, *pool.Spec.Replicas),
+		},
+	}
+	if action == bmcv1beta1.BootCmdGracefulShutdown && m.NodeName != "" {
+		if pool.Spec.DrainPolicy != nil {
+			op.Spec.DrainPolicy = pool.Spec.DrainPolicy.DeepCopy()
+		} else {
+			// the timeouts are defaulted by the CRD
+			op.Spec.DrainPolicy = &bmcv1beta1.DrainPolicy{}
+		}
+	}
+	return op
+}
+
+func (r *HostPoolReconciler) updateStatus(ctx context.Context, pool *bmcv1beta1.HostPool, oldStatus *bmcv1beta1.HostPoolStatus, logger *zap.SugaredLogger) error {
+	if reflect.DeepEqual(oldStatus, &pool.Status) {
+		return nil
+	}
+	if err := r.Status().Update(ctx, pool); err != nil {
+		logger.Errorf("failed to update HostPool status: %v", err)
+		return err
+	}
+	return nil
+}
+
+// enqueuePools triggers the pools selecting the HostStatus
+func (r *HostPoolReconciler) enqueuePools(ctx context.Context, obj client.Object) []reconcile.Request {
+	list := &bmcv1beta1.HostPoolList{}
+	if err := r.List(ctx, list); err != nil {
+		log.Logger.Errorf("failed to list HostPool: %v", err)
+		return nil
+	}
+	var requests []reconcile.Request
+	for i := range list.Items {
+		selector, err := metav1.LabelSelectorAsSelector(list.Items[i].Spec.Selector)
+		if err != nil || !selector.Matches(labels.Set(obj.GetLabels())) {
+			continue
+		}
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKey{Name: list.Items[i].Name}})
+	}
+	return requests
+}
+
+// enqueueOperationPool triggers the pool creating the HostOperation
+func (r *HostPoolReconciler) enqueueOperationPool(ctx context.Context, obj client.Object) []reconcile.Request {
+	if name := obj.GetAnnotations()[bmcv1beta1.AnnotationHostPool]; name != "" {
+		return []reconcile.Request{{NamespacedName: client.ObjectKey{Name: name}}}
+	}
+	return nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *HostPoolReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&bmcv1beta1.HostPool{}).
+		Watches(&bmcv1beta1.HostStatus{}, handler.EnqueueRequestsFromMapFunc(r.enqueuePools)).
+		Watches(&bmcv1beta1.HostOperation{}, handler.EnqueueRequestsFromMapFunc(r.enqueueOperationPool)).
+		Complete(r)
+}
@@ -0,0 +1,125 @@
+package hostpool_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spidernet-io/bmc/pkg/controller/hostpool"
+	bmcv1beta1 "github.com/spidernet-io/bmc/pkg/k8s/apis/bmc.spidernet.io/v1beta1"
+)
+
+var _ = Describe("HostPool", Label("unitest"), func() {
+	now := time.Now().Truncate(time.Second)
+	operation := func(action, status string, updated time.Time) *bmcv1beta1.HostOperation {
+		return &bmcv1beta1.HostOperation{
+			Spec: bmcv1beta1.HostOperationSpec{HostOperationActionSpec: bmcv1beta1.HostOperationActionSpec{Action: action}},
+			Status: bmcv1beta1.HostOperationStatus{
+				Status:         status,
+				LastUpdateTime: updated.UTC().Format(time.RFC3339),
+			},
+		}
+	}
+
+	Context("inTransition", func() {
+		It("lasts while the operation is not finished", func() {
+			for _, status := range []string{"", bmcv1beta1.HostOperationStatusPending, bmcv1beta1.HostOperationStatusRunning} {
+				Expect(hostpool.InTransition(operation(bmcv1beta1.BootCmdOn, status, now), "Off")).To(BeTrue())
+			}
+		})
+
+		It("lasts after the success until the target power state is observed", func() {
+			Expect(hostpool.InTransition(operation(bmcv1beta1.BootCmdOn, bmcv1beta1.HostOperationStatusSuccess, now), "Off")).To(BeTrue())
+			Expect(hostpool.InTransition(operation(bmcv1beta1.BootCmdOn, bmcv1beta1.HostOperationStatusSuccess, now), "On")).To(BeFalse())
+			Expect(hostpool.InTransition(operation(bmcv1beta1.BootCmdGracefulShutdown, bmcv1beta1.HostOperationStatusSuccess, now), "Off")).To(BeFalse())
+		})
+
+		It("ends when the power state is not observed in time", func() {
+			op := operation(bmcv1beta1.BootCmdOn, bmcv1beta1.HostOperationStatusSuccess, now.Add(-10*time.Minute))
+			Expect(hostpool.InTransition(op, "Off")).To(BeFalse())
+		})
+
+		It("ends when the operation fails", func() {
+			Expect(hostpool.InTransition(operation(bmcv1beta1.BootCmdOn, bmcv1beta1.HostOperationStatusFailed, now), "Off")).To(BeFalse())
+		})
+	})
+
+	Context("failedRecently", func() {
+		It("backs off the host after a failure", func() {
+			Expect(hostpool.FailedRecently(operation(bmcv1beta1.BootCmdOn, bmcv1beta1.HostOperationStatusFailed, now.Add(-time.Minute)), "Off", now)).To(BeTrue())
+			Expect(hostpool.FailedRecently(operation(bmcv1beta1.BootCmdOn, bmcv1beta1.HostOperationStatusFailed, now.Add(-time.Hour)), "Off", now)).To(BeFalse())
+		})
+
+		It("treats the success without the power state changing as a failure", func() {
+			op := operation(bmcv1beta1.BootCmdOn, bmcv1beta1.HostOperationStatusSuccess, now.Add(-10*time.Minute))
+			Expect(hostpool.FailedRecently(op, "Off", now)).To(BeTrue())
+			Expect(hostpool.FailedRecently(op, "On", now)).To(BeFalse())
+		})
+
+		It("ignores the unfinished operation", func() {
+			Expect(hostpool.FailedRecently(operation(bmcv1beta1.BootCmdOn, bmcv1beta1.HostOperationStatusRunning, now), "Off", now)).To(BeFalse())
+		})
+	})
+
+	Context("powerOffCandidates", func() {
+		onSince := func(d time.Duration) string {
+			return now.Add(-d).UTC().Format(time.RFC3339)
+		}
+
+		It("prefers the hosts without a node, then the ones powered on most recently", func() {
+			members := []bmcv1beta1.HostPoolMember{
+				{Name: "old", PowerState: "On", NodeName: "node1", PowerOnTime: onSince(3 * time.Hour)},
+				{Name: "new", PowerState: "On", NodeName: "node2", PowerOnTime: onSince(2 * time.Hour)},
+				{Name: "nonode", PowerState: "On", PowerOnTime: onSince(4 * time.Hour)},
+			}
+			candidates, wait := hostpool.PowerOffCandidates(members, nil, time.Hour, now)
+			Expect(wait).To(BeZero())
+			Expect(candidates).To(HaveLen(3))
+			Expect(candidates[0].Name).To(Equal("nonode"))
+			Expect(candidates[1].Name).To(Equal("new"))
+			Expect(candidates[2].Name).To(Equal("old"))
+		})
+
+		It("leaves the hosts off, skipped, being operated or on for less than minOn", func() {
+			members := []bmcv1beta1.HostPoolMember{
+				{Name: "off", PowerState: "Off"},
+				{Name: "skipped", PowerState: "On", PowerOnTime: onSince(2 * time.Hour)},
+				{Name: "operated", PowerState: "On", PowerOnTime: onSince(2 * time.Hour), HostOperation: "op1"},
+				{Name: "recent", PowerState: "On", PowerOnTime: onSince(40 * time.Minute)},
+				{Name: "recent2", PowerState: "On", PowerOnTime: onSince(50 * time.Minute)},
+				{Name: "ready", PowerState: "On", PowerOnTime: onSince(2 * time.Hour)},
+			}
+			candidates, wait := hostpool.PowerOffCandidates(members, map[string]bool{"skipped": true}, time.Hour, now)
+			Expect(candidates).To(HaveLen(1))
+			Expect(candidates[0].Name).To(Equal("ready"))
+			Expect(wait).To(Equal(10 * time.Minute))
+		})
+	})
+
+	Context("newOperation", func() {
+		replicas := int32(1)
+		pool := &bmcv1beta1.HostPool{Spec: bmcv1beta1.HostPoolSpec{Replicas: &replicas}}
+		pool.Name = "burst"
+
+		It("drains the node by the default drain policy when the pool sets none", func() {
+			op := hostpool.NewOperation(pool, bmcv1beta1.HostPoolMember{Name: "host1", NodeName: "node1"}, bmcv1beta1.BootCmdGracefulShutdown, now)
+			Expect(op.Spec.HostStatusName).To(Equal("host1"))
+			Expect(op.Spec.DrainPolicy).NotTo(BeNil())
+			Expect(op.Spec.DrainPolicy.Force).To(BeFalse())
+		})
+
+		It("drains the node by the drain policy of the pool", func() {
+			withPolicy := pool.DeepCopy()
+			withPolicy.Spec.DrainPolicy = &bmcv1beta1.DrainPolicy{TimeoutSeconds: 60}
+			op := hostpool.NewOperation(withPolicy, bmcv1beta1.HostPoolMember{Name: "host1", NodeName: "node1"}, bmcv1beta1.BootCmdGracefulShutdown, now)
+			Expect(op.Spec.DrainPolicy).To(Equal(&bmcv1beta1.DrainPolicy{TimeoutSeconds: 60}))
+		})
+
+		It("does not drain the host without a node or being powered on", func() {
+			op := hostpool.NewOperation(pool, bmcv1beta1.HostPoolMember{Name: "host1"}, bmcv1beta1.BootCmdGracefulShutdown, now)
+			Expect(op.Spec.DrainPolicy).To(BeNil())
+			op = hostpool.NewOperation(pool, bmcv1beta1.HostPoolMember{Name: "host1", NodeName: "node1"}, bmcv1beta1.BootCmdOn, now)
+			Expect(op.Spec.DrainPolicy).To(BeNil())
+		})
+	})
+})
@@ -0,0 +1,8 @@
+package hostpool
+
+var (
+	InTransition       = inTransition
+	FailedRecently     = failedRecently
+	PowerOffCandidates = powerOffCandidates
+	NewOperation       = newOperation
+)
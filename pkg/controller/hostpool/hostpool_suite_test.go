@@ -0,0 +1,13 @@
+package hostpool_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestHostPool(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "HostPool Suite")
+}
@@ -0,0 +1,187 @@
+package nodemapping
+
+import (
+	"context"
+	"reflect"
+
+	bmcv1beta1 "github.com/spidernet-io/bmc/pkg/k8s/apis/bmc.spidernet.io/v1beta1"
+	"github.com/spidernet-io/bmc/pkg/log"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// NodeMappingReconciler maps each HostStatus to the Kubernetes Node running on the host,
+// the node name is recorded in the status of the HostStatus, and the name of the HostStatus is annotated on the Node.
+// the inventory and the critical failures of the hardware are published as the labels and taints of the Node
+type NodeMappingReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+	Config NodeConfig
+}
+
+// Reconcile is part of the main kubernetes reconciliation loop
+func (r *NodeMappingReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.Logger.With(
+		zap.String("reconcile", "nodemapping"),
+		zap.String("name", req.Name),
+	)
+
+	nodeList := &corev1.NodeList{}
+	if err := r.List(ctx, nodeList); err != nil {
+		logger.Errorf("failed to list Nodes: %v", err)
+		return ctrl.Result{}, err
+	}
+
+	hostStatus := &bmcv1beta1.HostStatus{}
+	if err := r.Get(ctx, req.NamespacedName, hostStatus); err != nil {
+		if errors.IsNotFound(err) {
+			// the host is gone, release its nodes
+			return ctrl.Result{}, r.releaseNodes(ctx, req.Name, "", nodeList.Items, logger)
+		}
+		return ctrl.Result{}, err
+	}
+
+	nodeName := ""
+	if hostStatus.DeletionTimestamp == nil {
+		if node := MatchNode(hostStatus, nodeList.Items); node != nil {
+			owned, err := r.ownedByOther(ctx, node, hostStatus.Name)
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+			if owned {
+				logger.Warnf("node %s is already mapped to hostStatus %s", node.Name, node.Annotations[bmcv1beta1.AnnotationHostStatus])
+			} else {
+				nodeName = node.Name
+				if err := r.updateNode(ctx, node, hostStatus); err != nil {
+					logger.Errorf("failed to update node %s: %v", node.Name, err)
+					return ctrl.Result{}, err
+				}
+			}
+		}
+	}
+	if err := r.releaseNodes(ctx, hostStatus.Name, nodeName, nodeList.Items, logger); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if hostStatus.Status.NodeName != nodeName {
+		patch := client.MergeFrom(hostStatus.DeepCopy())
+		hostStatus.Status.NodeName = nodeName
+		if err := r.Status().Patch(ctx, hostStatus, patch); err != nil {
+			logger.Errorf("failed to update the node name of hostStatus: %v", err)
+			return ctrl.Result{}, err
+		}
+		if nodeName == "" {
+			logger.Infof("hostStatus is no longer mapped to a node")
+		} else {
+			logger.Infof("hostStatus is mapped to node %s", nodeName)
+		}
+	}
+	return ctrl.Result{}, nil
+}
+
+// ownedByOther returns whether the node is mapped to another existing HostStatus, which happens when both report the same identity
+func (r *NodeMappingReconciler) ownedByOther(ctx context.Context, node *corev1.Node, hostStatusName string) (bool, error) {
+	other := node.Annotations[bmcv1beta1.AnnotationHostStatus]
+	if other == "" || other == hostStatusName {
+		return false, nil
+	}
+	hostStatus := &bmcv1beta1.HostStatus{}
+	if err := r.Get(ctx, client.ObjectKey{Name: other}, hostStatus); err != nil {
+		if errors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return hostStatus.Status.NodeName == node.Name, nil
+}
+
+// updateNode records the name of the HostStatus on the node, and publishes the hardware of the host.
+// the hardware is left as it was while the BMC is unreachable, as nothing is known about it
+func (r *NodeMappingReconciler) updateNode(ctx context.Context, node *corev1.Node, hostStatus *bmcv1beta1.HostStatus) error {
+	// the taints are replaced as a whole, so the patch fails instead of overwriting the taints set meanwhile
+	patch := client.MergeFromWithOptions(node.DeepCopy(), client.MergeFromWithOptimisticLock{})
+	changed := false
+	if node.Annotations[bmcv1beta1.AnnotationHostStatus] != hostStatus.Name {
+		if node.Annotations == nil {
+			node.Annotations = map[string]string{}
+		}
+		node.Annotations[bmcv1beta1.AnnotationHostStatus] = hostStatus.Name
+		changed = true
+	}
+	if len(hostStatus.Status.Info) > 0 && applyHardware(node, hostStatus.Status.Info, r.Config) {
+		changed = true
+	}
+	if !changed {
+		return nil
+	}
+	return r.Patch(ctx, node, patch)
+}
+
+// releaseNodes removes the annotation and the hardware of the HostStatus from the nodes other than the one it is mapped to
+func (r *NodeMappingReconciler) releaseNodes(ctx context.Context, hostStatusName, keep string, nodes []corev1.Node, logger *zap.SugaredLogger) error {
+	for i := range nodes {
+		node := &nodes[i]
+		if node.Name == keep || node.Annotations[bmcv1beta1.AnnotationHostStatus] != hostStatusName {
+			continue
+		}
+		patch := client.MergeFromWithOptions(node.DeepCopy(), client.MergeFromWithOptimisticLock{})
+		delete(node.Annotations, bmcv1beta1.AnnotationHostStatus)
+		removeHardware(node, r.Config)
+		if err := r.Patch(ctx, node, patch); err != nil && !errors.IsNotFound(err) {
+			logger.Errorf("failed to remove the annotation from node %s: %v", node.Name, err)
+			return err
+		}
+		logger.Infof("removed the annotation from node %s", node.Name)
+	}
+	return nil
+}
+
+// enqueueHostStatuses triggers all the HostStatuses when a node joins, leaves or changes what it is matched by
+func (r *NodeMappingReconciler) enqueueHostStatuses(ctx context.Context, obj client.Object) []reconcile.Request {
+	list := &bmcv1beta1.HostStatusList{}
+	if err := r.List(ctx, list); err != nil {
+		log.Logger.Errorf("failed to list HostStatus: %v", err)
+		return nil
+	}
+	requests := make([]reconcile.Request, 0, len(list.Items))
+	for i := range list.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKey{Name: list.Items[i].Name}})
+	}
+	return requests
+}
+
+// nodeChanged ignores the frequent updates of the node status which do not affect the mapping and the hardware
+func nodeChanged(e event.UpdateEvent) bool {
+	oldNode, ok := e.ObjectOld.(*corev1.Node)
+	if !ok {
+		return true
+	}
+	newNode, ok := e.ObjectNew.(*corev1.Node)
+	if !ok {
+		return true
+	}
+	return oldNode.Status.NodeInfo.SystemUUID != newNode.Status.NodeInfo.SystemUUID ||
+		oldNode.Annotations[bmcv1beta1.AnnotationNodeMacAddresses] != newNode.Annotations[bmcv1beta1.AnnotationNodeMacAddresses] ||
+		oldNode.Annotations[bmcv1beta1.AnnotationHostStatus] != newNode.Annotations[bmcv1beta1.AnnotationHostStatus] ||
+		!reflect.DeepEqual(oldNode.Labels, newNode.Labels) ||
+		!reflect.DeepEqual(oldNode.Spec.Taints, newNode.Spec.Taints)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *NodeMappingReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("nodemapping").
+		For(&bmcv1beta1.HostStatus{}).
+		Watches(&corev1.Node{}, handler.EnqueueRequestsFromMapFunc(r.enqueueHostStatuses),
+			builder.WithPredicates(predicate.Funcs{UpdateFunc: nodeChanged})).
+		Complete(r)
+}
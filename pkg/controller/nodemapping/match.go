@@ -0,0 +1,94 @@
+package nodemapping
+
+import (
+	"net"
+	"strings"
+
+	bmcv1beta1 "github.com/spidernet-io/bmc/pkg/k8s/apis/bmc.spidernet.io/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// the placeholders reported by some BMCs and firmwares, which do not identify the machine
+var placeholderUUIDs = map[string]bool{
+	"":                                     true,
+	"00000000-0000-0000-0000-000000000000": true,
+	"FFFFFFFF-FFFF-FFFF-FFFF-FFFFFFFFFFFF": true,
+	"03000200-0400-0500-0006-000700080009": true,
+}
+
+// swapUUID returns the UUID with the first three fields in the other byte order,
+// some BMCs and the SMBIOS of the node encode them differently
+func swapUUID(uuid string) string {
+	fields := strings.Split(uuid, "-")
+	if len(fields) != 5 || len(fields[0]) != 8 || len(fields[1]) != 4 || len(fields[2]) != 4 {
+		return ""
+	}
+	for i := 0; i < 3; i++ {
+		b := []byte(fields[i])
+		for l, r := 0, len(b)-2; l < r; l, r = l+2, r-2 {
+			b[l], b[l+1], b[r], b[r+1] = b[r], b[r+1], b[l], b[l+1]
+		}
+		fields[i] = string(b)
+	}
+	return strings.Join(fields, "-")
+}
+
+// sameUUID returns whether the system UUID reported by the BMC is the one of the node
+func sameUUID(bmcUUID, nodeUUID string) bool {
+	bmcUUID = strings.ToUpper(strings.TrimSpace(bmcUUID))
+	nodeUUID = strings.ToUpper(strings.TrimSpace(nodeUUID))
+	if placeholderUUIDs[bmcUUID] || placeholderUUIDs[nodeUUID] {
+		return false
+	}
+	return bmcUUID == nodeUUID || swapUUID(bmcUUID) == nodeUUID
+}
+
+// normalizeMac returns the MAC address in the canonical form, or empty when it is not a valid one
+func normalizeMac(mac string) string {
+	hw, err := net.ParseMAC(strings.TrimSpace(mac))
+	if err != nil {
+		return ""
+	}
+	return hw.String()
+}
+
+// sameMac returns whether one of the NICs of the host is one of the MAC addresses annotated on the node
+func sameMac(hostMacs []string, node *corev1.Node) bool {
+	annotated := map[string]bool{}
+	for _, mac := range strings.Split(node.Annotations[bmcv1beta1.AnnotationNodeMacAddresses], ",") {
+		if m := normalizeMac(mac); m != "" {
+			annotated[m] = true
+		}
+	}
+	for _, mac := range hostMacs {
+		if m := normalizeMac(mac); m != "" && annotated[m] {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchNode returns the node running on the host, the system UUID is matched first, then the MAC addresses of the NICs.
+// nothing is returned when the host is matched by more than one node, as the mapping is ambiguous
+func MatchNode(hostStatus *bmcv1beta1.HostStatus, nodes []corev1.Node) *corev1.Node {
+	identity := hostStatus.Status.Identity
+	if identity == nil {
+		return nil
+	}
+	var byUUID, byMac []*corev1.Node
+	for i := range nodes {
+		node := &nodes[i]
+		if sameUUID(identity.SystemUUID, node.Status.NodeInfo.SystemUUID) {
+			byUUID = append(byUUID, node)
+		} else if sameMac(identity.HostMacs, node) {
+			byMac = append(byMac, node)
+		}
+	}
+	switch {
+	case len(byUUID) == 1:
+		return byUUID[0]
+	case len(byUUID) == 0 && len(byMac) == 1:
+		return byMac[0]
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package nodemapping_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spidernet-io/bmc/pkg/controller/nodemapping"
+	bmcv1beta1 "github.com/spidernet-io/bmc/pkg/k8s/apis/bmc.spidernet.io/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("NodeMapping", Label("unitest"), func() {
+
+	node := func(name, uuid, macs string) corev1.Node {
+		n := corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		n.Status.NodeInfo.SystemUUID = uuid
+		if macs != "" {
+			n.Annotations = map[string]string{bmcv1beta1.AnnotationNodeMacAddresses: macs}
+		}
+		return n
+	}
+	host := func(uuid string, macs ...string) *bmcv1beta1.HostStatus {
+		return &bmcv1beta1.HostStatus{Status: bmcv1beta1.HostStatusStatus{
+			Identity: &bmcv1beta1.IdentityStatus{SystemUUID: uuid, HostMacs: macs},
+		}}
+	}
+	nodes := []corev1.Node{
+		node("node1", "4c4c4544-0042-3510-8052-b4c04f4d4e32", ""),
+		node("node2", "33221100-5544-7766-8899-aabbccddeeff", ""),
+		node("node3", "00000000-0000-0000-0000-000000000000", "AA:BB:CC:00:00:01,aa:bb:cc:00:00:02"),
+	}
+
+	It("matches the system UUID regardless of the case and the byte order", func() {
+		Expect(nodemapping.MatchNode(host("4C4C4544-0042-3510-8052-B4C04F4D4E32"), nodes).Name).To(Equal("node1"))
+		Expect(nodemapping.MatchNode(host("00112233-4455-6677-8899-AABBCCDDEEFF"), nodes).Name).To(Equal("node2"))
+	})
+
+	It("falls back to the MAC addresses annotated on the node", func() {
+		Expect(nodemapping.MatchNode(host("00000000-0000-0000-0000-000000000000", "aa-bb-cc-00-00-02"), nodes).Name).To(Equal("node3"))
+		Expect(nodemapping.MatchNode(host("", "aa:bb:cc:00:00:09"), nodes)).To(BeNil())
+	})
+
+	It("does not map the host matched by more than one node", func() {
+		dup := append(nodes, node("node4", "4c4c4544-0042-3510-8052-b4c04f4d4e32", ""))
+		Expect(nodemapping.MatchNode(host("4c4c4544-0042-3510-8052-b4c04f4d4e32"), dup)).To(BeNil())
+	})
+})
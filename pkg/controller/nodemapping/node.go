@@ -0,0 +1,216 @@
+package nodemapping
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/spidernet-io/bmc/pkg/redfish"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// the inventory facts which can be published as the labels of the node
+const (
+	FactVendor             = "vendor"
+	FactModel              = "model"
+	FactCPUModel           = "cpu-model"
+	FactGPUModel           = "gpu-model"
+	FactGPUCount           = "gpu-count"
+	FactMemoryGiB          = "memory-gib"
+	FactBiosVersion        = "bios-version"
+	FactBmcFirmwareVersion = "bmc-firmware-version"
+)
+
+// the hardware failures which can be applied as the taints of the node
+const (
+	TaintPSUFailed      = "psu-failed"
+	TaintMemoryCritical = "memory-critical"
+	TaintSystemCritical = "system-critical"
+)
+
+var KnownFacts = []string{FactVendor, FactModel, FactCPUModel, FactGPUModel, FactGPUCount, FactMemoryGiB, FactBiosVersion, FactBmcFirmwareVersion}
+
+var KnownTaints = []string{TaintPSUFailed, TaintMemoryCritical, TaintSystemCritical}
+
+// NodeConfig is what the hardware of the host is published as on the node
+type NodeConfig struct {
+	// LabelPrefix is the prefix of the keys of the labels and the taints, such as "bmc.spidernet.io/"
+	LabelPrefix string
+	// Labels is the allowlist of the facts published as labels
+	Labels []string
+	// Taints is the allowlist of the failures applied as taints
+	Taints []string
+	// TaintEffect is the effect of the taints
+	TaintEffect corev1.TaintEffect
+}
+
+// Validate checks the config, and adds the missing "/" to the prefix
+func (c *NodeConfig) Validate() error {
+	if c.LabelPrefix != "" && !strings.HasSuffix(c.LabelPrefix, "/") {
+		c.LabelPrefix += "/"
+	}
+	if (len(c.Labels) > 0 || len(c.Taints) > 0) && c.LabelPrefix == "" {
+		return fmt.Errorf("the label prefix is required to publish the hardware on the node")
+	}
+	for _, item := range c.Labels {
+		if !contains(KnownFacts, item) {
+			return fmt.Errorf("unknown node label %q, it should be one of %v", item, KnownFacts)
+		}
+	}
+	for _, item := range c.Taints {
+		if !contains(KnownTaints, item) {
+			return fmt.Errorf("unknown node taint %q, it should be one of %v", item, KnownTaints)
+		}
+	}
+	switch c.TaintEffect {
+	case corev1.TaintEffectNoSchedule, corev1.TaintEffectPreferNoSchedule, corev1.TaintEffectNoExecute:
+	default:
+		return fmt.Errorf("invalid node taint effect %q", c.TaintEffect)
+	}
+	return nil
+}
+
+func contains(list []string, item string) bool {
+	for _, v := range list {
+		if v == item {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	pcieDeviceType = regexp.MustCompile(`^PCIeDevices\[(\d+)\]\.DeviceType$`)
+	memoryHealth   = regexp.MustCompile(`^Memory\[\d+\]\.Health$`)
+	invalidLabel   = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+)
+
+// labelValue makes the value valid for a label, the invalid characters such as spaces are replaced by "_"
+func labelValue(v string) string {
+	v = invalidLabel.ReplaceAllString(strings.TrimSpace(v), "_")
+	if len(v) > 63 {
+		v = v[:63]
+	}
+	return strings.Trim(v, "._-")
+}
+
+// Facts returns the inventory facts of the host collected in status.info, the unknown ones are not returned
+func Facts(info map[string]string) map[string]string {
+	facts := map[string]string{
+		FactVendor:             info["Manufacturer"],
+		FactModel:              info["Model"],
+		FactCPUModel:           info["CpuModel"],
+		FactMemoryGiB:          info["MemoryTotalGiB"],
+		FactBiosVersion:        info["BiosVerison"],
+		FactBmcFirmwareVersion: info["BmcFirmwareVersion"],
+	}
+	if facts[FactVendor] == "" {
+		facts[FactVendor] = info["Vendor"]
+	}
+
+	// the GPUs are the PCIe devices classified as GPU, the most common model is published
+	models := map[string]int{}
+	gpus := 0
+	for k, v := range info {
+		m := pcieDeviceType.FindStringSubmatch(k)
+		if m == nil || v != redfish.DeviceType_GPU {
+			continue
+		}
+		gpus++
+		if model := info[fmt.Sprintf("PCIeDevices[%s].Model", m[1])]; model != "" {
+			models[model]++
+		}
+	}
+	if gpus > 0 {
+		facts[FactGPUCount] = fmt.Sprintf("%d", gpus)
+		names := make([]string, 0, len(models))
+		for name := range models {
+			names = append(names, name)
+		}
+		sort.Slice(names, func(i, j int) bool {
+			if models[names[i]] != models[names[j]] {
+				return models[names[i]] > models[names[j]]
+			}
+			return names[i] < names[j]
+		})
+		if len(names) > 0 {
+			facts[FactGPUModel] = names[0]
+		}
+	}
+
+	for k, v := range facts {
+		if v = labelValue(v); v == "" || v == "0" && k == FactMemoryGiB {
+			delete(facts, k)
+		} else {
+			facts[k] = v
+		}
+	}
+	return facts
+}
+
+// Failures returns the critical hardware failures of the host reported in status.info
+func Failures(info map[string]string) map[string]bool {
+	failures := map[string]bool{
+		TaintPSUFailed:      info["PowerSupplyStatus"] == "Critical",
+		TaintMemoryCritical: info["MemoryStatus"] == "Critical",
+		TaintSystemCritical: info["SyatemStatus"] == "Critical",
+	}
+	for k, v := range info {
+		if memoryHealth.MatchString(k) && v == "Critical" {
+			failures[TaintMemoryCritical] = true
+		}
+	}
+	return failures
+}
+
+// applyHardware sets the allowlisted facts and failures of the host on the node, and returns whether the node is changed.
+// the labels and taints of the facts and failures which are not known anymore are removed
+func applyHardware(node *corev1.Node, info map[string]string, config NodeConfig) bool {
+	changed := false
+	facts := Facts(info)
+	for _, name := range config.Labels {
+		key := config.LabelPrefix + name
+		if v, ok := facts[name]; ok {
+			if node.Labels[key] != v {
+				if node.Labels == nil {
+					node.Labels = map[string]string{}
+				}
+				node.Labels[key] = v
+				changed = true
+			}
+		} else if _, ok := node.Labels[key]; ok {
+			delete(node.Labels, key)
+			changed = true
+		}
+	}
+
+	failures := Failures(info)
+	for _, name := range config.Taints {
+		key := config.LabelPrefix + name
+		index := -1
+		for i := range node.Spec.Taints {
+			if node.Spec.Taints[i].Key == key {
+				index = i
+				break
+			}
+		}
+		switch {
+		case failures[name] && index < 0:
+			node.Spec.Taints = append(node.Spec.Taints, corev1.Taint{Key: key, Value: "true", Effect: config.TaintEffect})
+			changed = true
+		case failures[name] && node.Spec.Taints[index].Effect != config.TaintEffect:
+			node.Spec.Taints[index].Effect = config.TaintEffect
+			changed = true
+		case !failures[name] && index >= 0:
+			node.Spec.Taints = append(node.Spec.Taints[:index], node.Spec.Taints[index+1:]...)
+			changed = true
+		}
+	}
+	return changed
+}
+
+// removeHardware removes the labels and the taints of the hardware from the node, and returns whether the node is changed
+func removeHardware(node *corev1.Node, config NodeConfig) bool {
+	return applyHardware(node, map[string]string{}, config)
+}
@@ -0,0 +1,46 @@
+package nodemapping_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spidernet-io/bmc/pkg/controller/nodemapping"
+)
+
+var _ = Describe("Node", Label("unitest"), func() {
+
+	It("publishes the inventory facts as valid label values", func() {
+		facts := nodemapping.Facts(map[string]string{
+			"Manufacturer":              "Dell Inc.",
+			"Model":                     "PowerEdge R750xa",
+			"CpuModel":                  "Intel(R) Xeon(R) Gold 6330 CPU @ 2.00GHz",
+			"MemoryTotalGiB":            "512",
+			"BiosVerison":               "1.9.2",
+			"PCIeDevices[0].DeviceType": "GPU",
+			"PCIeDevices[0].Model":      "A100",
+			"PCIeDevices[1].DeviceType": "NIC",
+			"PCIeDevices[2].DeviceType": "GPU",
+			"PCIeDevices[2].Model":      "A100",
+		})
+		Expect(facts).To(Equal(map[string]string{
+			nodemapping.FactVendor:      "Dell_Inc",
+			nodemapping.FactModel:       "PowerEdge_R750xa",
+			nodemapping.FactCPUModel:    "Intel_R_Xeon_R_Gold_6330_CPU_2.00GHz",
+			nodemapping.FactMemoryGiB:   "512",
+			nodemapping.FactBiosVersion: "1.9.2",
+			nodemapping.FactGPUModel:    "A100",
+			nodemapping.FactGPUCount:    "2",
+		}))
+	})
+
+	It("reports the critical failures", func() {
+		failures := nodemapping.Failures(map[string]string{
+			"SyatemStatus":      "Warning",
+			"PowerSupplyStatus": "Critical",
+			"MemoryStatus":      "OK",
+			"Memory[3].Health":  "Critical",
+		})
+		Expect(failures[nodemapping.TaintPSUFailed]).To(BeTrue())
+		Expect(failures[nodemapping.TaintMemoryCritical]).To(BeTrue())
+		Expect(failures[nodemapping.TaintSystemCritical]).To(BeFalse())
+	})
+})
@@ -0,0 +1,13 @@
+package nodemapping_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestNodeMapping(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "NodeMapping Suite")
+}
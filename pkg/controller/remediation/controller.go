@@ -0,0 +1,541 @@
+package remediation
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spidernet-io/bmc/pkg/hostpolicy"
+	bmcv1beta1 "github.com/spidernet-io/bmc/pkg/k8s/apis/bmc.spidernet.io/v1beta1"
+	"github.com/spidernet-io/bmc/pkg/log"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	// the defaults of the RemediationSpec of the HostPolicy
+	defaultNotReadySeconds    = 300
+	defaultStepTimeoutSeconds = 600
+
+	// the interval of checking the HostOperation of the current step
+	progressInterval = 15 * time.Second
+	// the interval of checking again when the remediation is not allowed for now
+	retryInterval = 30 * time.Second
+
+	// the taint applied on the node after the host is powered off, so that the stateful pods and the volumes are moved
+	// to the other nodes, see https://kubernetes.io/docs/concepts/cluster-administration/node-shutdown/#non-graceful-node-shutdown
+	outOfServiceTaintKey   = "node.kubernetes.io/out-of-service"
+	outOfServiceTaintValue = "nodeshutdown"
+
+	// the step reported to the HostRemediation while the remediation is not allowed to start
+	stepWaiting = "Waiting"
+)
+
+// the action of the HostOperation for each step
+var stepActions = map[string]string{
+	bmcv1beta1.RemediationStepGracefulRestart: bmcv1beta1.BootCmdGracefulRestart,
+	bmcv1beta1.RemediationStepForceRestart:    bmcv1beta1.BootCmdForceRestart,
+	bmcv1beta1.RemediationStepPowerOff:        bmcv1beta1.BootCmdForceOff,
+}
+
+// the step after each step when the node does not become Ready
+var nextSteps = map[string]string{
+	bmcv1beta1.RemediationStepGracefulRestart: bmcv1beta1.RemediationStepForceRestart,
+	bmcv1beta1.RemediationStepForceRestart:    bmcv1beta1.RemediationStepPowerOff,
+	bmcv1beta1.RemediationStepPowerOff:        bmcv1beta1.RemediationStepFailed,
+}
+
+// Config is the safety limits of the remediation
+type Config struct {
+	// MaxConcurrent is the maximum number of the hosts remediated at the same time
+	MaxConcurrent int
+	// MaxNotReadyPercent stops starting the remediation when more nodes than it are NotReady, which is a cluster-wide outage
+	MaxNotReadyPercent int
+}
+
+// RemediationReconciler remediates the NotReady node of a HostStatus through the BMC, it restarts the host gracefully,
+// then forcibly, and powers off the host with the node tainted out-of-service at last, until the node becomes Ready.
+// the remediation starts by the HostPolicy after the node stays NotReady for a while, or by a HostRemediation of the node
+type RemediationReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Config   Config
+	Recorder record.EventRecorder
+	// the namespace of the events of the HostStatus
+	Namespace string
+	// apiReader reads the HostStatuses from the API server, the cache may not have the remediation
+	// just started for another host yet
+	apiReader client.Reader
+}
+
+// nodeReadiness returns whether the node is Ready, and since when it is not
+func nodeReadiness(node *corev1.Node) (bool, time.Time) {
+	for _, c := range node.Status.Conditions {
+		if c.Type == corev1.NodeReady {
+			return c.Status == corev1.ConditionTrue, c.LastTransitionTime.Time
+		}
+	}
+	return false, node.CreationTimestamp.Time
+}
+
+// isActiveStep returns whether the remediation is performing a step
+func isActiveStep(step string) bool {
+	_, ok := stepActions[step]
+	return ok
+}
+
+// Reconcile is part of the main kubernetes reconciliation loop
+func (r *RemediationReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.Logger.With(
+		zap.String("reconcile", "remediation"),
+		zap.String("name", req.Name),
+	)
+
+	hostStatus := &bmcv1beta1.HostStatus{}
+	if err := r.Get(ctx, req.NamespacedName, hostStatus); err != nil {
+		if errors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	node := &corev1.Node{}
+	if hostStatus.Status.NodeName == "" {
+		node = nil
+	} else if err := r.Get(ctx, client.ObjectKey{Name: hostStatus.Status.NodeName}, node); err != nil {
+		if !errors.IsNotFound(err) {
+			return ctrl.Result{}, err
+		}
+		node = nil
+	}
+	if node == nil {
+		if hostStatus.Status.Remediation != nil {
+			logger.Infof("stop the remediation as the host is not mapped to a node")
+			return ctrl.Result{}, r.setRemediation(ctx, hostStatus, nil)
+		}
+		return ctrl.Result{}, nil
+	}
+
+	requests, err := r.hostRemediations(ctx, node.Name)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	ready, notReadySince := nodeReadiness(node)
+	rem := hostStatus.Status.Remediation
+	switch {
+	case ready && rem == nil:
+		return ctrl.Result{}, nil
+	case ready:
+		return ctrl.Result{}, r.recover(ctx, hostStatus, node, requests, logger)
+	case rem == nil:
+		return r.start(ctx, hostStatus, node, requests, notReadySince, logger)
+	}
+	return r.progress(ctx, hostStatus, node, requests, logger)
+}
+
+// hostRemediations returns the HostRemediations of the node in all namespaces
+func (r *RemediationReconciler) hostRemediations(ctx context.Context, nodeName string) ([]bmcv1beta1.HostRemediation, error) {
+	list := &bmcv1beta1.HostRemediationList{}
+	if err := r.List(ctx, list); err != nil {
+		return nil, err
+	}
+	result := []bmcv1beta1.HostRemediation{}
+	for _, item := range list.Items {
+		if item.Name == nodeName && item.DeletionTimestamp == nil {
+			result = append(result, item)
+		}
+	}
+	return result, nil
+}
+
+// blocked returns why the remediation of the host could not start for now
+func (r *RemediationReconciler) blocked(ctx context.Context, hostStatus *bmcv1beta1.HostStatus) (string, error) {
+	if !hostStatus.Status.Healthy {
+		return "the BMC is unreachable", nil
+	}
+	if hostStatus.Status.Info["PowerState"] == "Off" {
+		return "the host is powered off", nil
+	}
+
+	// the node may be NotReady because of an operation on the host, such as a restart
+	opList := &bmcv1beta1.HostOperationList{}
+	if err := r.List(ctx, opList); err != nil {
+		return "", err
+	}
+	for _, op := range opList.Items {
+		if op.Spec.HostStatusName != hostStatus.Name {
+			continue
+		}
+		switch {
+		case op.Status.Status == "" || op.Status.Status == bmcv1beta1.HostOperationStatusPending || op.Status.Status == bmcv1beta1.HostOperationStatusRunning:
+			return fmt.Sprintf("HostOperation %s is in progress", op.Name), nil
+		case op.Status.Drain != nil && op.Status.Drain.Phase == bmcv1beta1.DrainPhaseWaitingForReady:
+			return fmt.Sprintf("HostOperation %s is waiting for the node", op.Name), nil
+		}
+	}
+
+	nodeList := &corev1.NodeList{}
+	if err := r.List(ctx, nodeList); err != nil {
+		return "", err
+	}
+	notReady := 0
+	for i := range nodeList.Items {
+		if ready, _ := nodeReadiness(&nodeList.Items[i]); !ready {
+			notReady++
+		}
+	}
+	if len(nodeList.Items) > 0 && notReady*100 > r.Config.MaxNotReadyPercent*len(nodeList.Items) {
+		return fmt.Sprintf("%d of %d nodes are NotReady, which is more than %d%%, it looks like a cluster-wide outage",
+			notReady, len(nodeList.Items), r.Config.MaxNotReadyPercent), nil
+	}
+
+	// the reconciles run one at a time, so the remediations started before are all seen by the uncached list
+	hostList := &bmcv1beta1.HostStatusList{}
+	if err := r.apiReader.List(ctx, hostList); err != nil {
+		return "", err
+	}
+	active := 0
+	for _, item := range hostList.Items {
+		if item.Status.Remediation != nil && isActiveStep(item.Status.Remediation.Step) {
+			active++
+		}
+	}
+	if active >= r.Config.MaxConcurrent {
+		return fmt.Sprintf("%d hosts are being remediated, which reaches the limit", active), nil
+	}
+	return "", nil
+}
+
+// start begins the remediation when it is requested by a HostRemediation, or the node is NotReady longer than the HostPolicy allows
+func (r *RemediationReconciler) start(ctx context.Context, hostStatus *bmcv1beta1.HostStatus, node *corev1.Node,
+	requests []bmcv1beta1.HostRemediation, notReadySince time.Time, logger *zap.SugaredLogger) (ctrl.Result, error) {
+	policies := &bmcv1beta1.HostPolicyList{}
+	if err := r.List(ctx, policies); err != nil {
+		return ctrl.Result{}, err
+	}
+	spec, policyName, err := hostpolicy.Remediation(policies.Items, hostStatus)
+	if err != nil {
+		logger.Errorf("failed to evaluate HostPolicy: %v", err)
+		return ctrl.Result{}, err
+	}
+
+	stepTimeout := int32(defaultStepTimeoutSeconds)
+	if spec != nil && spec.StepTimeoutSeconds != nil {
+		stepTimeout = *spec.StepTimeoutSeconds
+	}
+	var reason string
+	if len(requests) > 0 {
+		reason = fmt.Sprintf("requested by HostRemediation %s/%s", requests[0].Namespace, requests[0].Name)
+		if requests[0].Spec.StepTimeoutSeconds != nil {
+			stepTimeout = *requests[0].Spec.StepTimeoutSeconds
+		}
+	} else if spec != nil {
+		notReadySeconds := int32(defaultNotReadySeconds)
+		if spec.NotReadySeconds != nil {
+			notReadySeconds = *spec.NotReadySeconds
+		}
+		if wait := time.Until(notReadySince.Add(time.Duration(notReadySeconds) * time.Second)); wait > 0 {
+			return ctrl.Result{RequeueAfter: wait}, nil
+		}
+		reason = fmt.Sprintf("node %s is NotReady for more than %d seconds, remediated by HostPolicy %s", node.Name, notReadySeconds, policyName)
+	} else {
+		return ctrl.Result{}, nil
+	}
+
+	why, err := r.blocked(ctx, hostStatus)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if why != "" {
+		logger.Infof("remediation of node %s is not allowed for now: %s", node.Name, why)
+		r.syncRequests(ctx, requests, hostStatus.Name, stepWaiting, "the remediation is not allowed for now: "+why, logger)
+		return ctrl.Result{RequeueAfter: retryInterval}, nil
+	}
+
+	rem := &bmcv1beta1.RemediationStatus{
+		Reason:             reason,
+		StartTime:          time.Now().UTC().Format(time.RFC3339),
+		StepTimeoutSeconds: stepTimeout,
+	}
+	r.event(hostStatus, node, corev1.EventTypeWarning, "RemediationStarted", fmt.Sprintf("start to remediate node %s: %s", node.Name, reason))
+	return r.runStep(ctx, hostStatus, node, rem, bmcv1beta1.RemediationStepGracefulRestart, requests, logger)
+}
+
+// runStep creates the HostOperation of the step, the next step follows at once when the HostOperation is denied by the webhook,
+// such as it is denied by the HostPolicy or not supported by the BMC
+func (r *RemediationReconciler) runStep(ctx context.Context, hostStatus *bmcv1beta1.HostStatus, node *corev1.Node,
+	rem *bmcv1beta1.RemediationStatus, step string, requests []bmcv1beta1.HostRemediation, logger *zap.SugaredLogger) (ctrl.Result, error) {
+	now := time.Now().UTC()
+	rem.Step = step
+	rem.StepStartTime = now.Format(time.RFC3339)
+	rem.HostOperation = ""
+
+	action, ok := stepActions[step]
+	if !ok {
+		rem.Message = "all the steps failed, the node is left as it is"
+		r.event(hostStatus, node, corev1.EventTypeWarning, "RemediationFailed", fmt.Sprintf("failed to remediate node %s: %s", node.Name, rem.Message))
+		return ctrl.Result{}, r.save(ctx, hostStatus, rem, requests, logger)
+	}
+
+	timeout := rem.StepTimeoutSeconds
+	op := &bmcv1beta1.HostOperation{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   fmt.Sprintf("%s-remediation-%s-%d", hostStatus.Name, strings.ToLower(step), now.Unix()),
+			Labels: map[string]string{bmcv1beta1.LabelRemediation: bmcv1beta1.LabelValue(hostStatus.Name)},
+		},
+		Spec: bmcv1beta1.HostOperationSpec{
+			HostOperationActionSpec: bmcv1beta1.HostOperationActionSpec{
+				Action:         action,
+				TimeoutSeconds: &timeout,
+			},
+			HostStatusName: hostStatus.Name,
+			Reason:         fmt.Sprintf("remediation of node %s: %s", node.Name, rem.Reason),
+		},
+	}
+	if err := r.Create(ctx, op); err != nil {
+		// the webhook denies the HostOperation with Forbidden, and the other errors such as a timeout are retried
+		if !errors.IsForbidden(err) && !errors.IsInvalid(err) {
+			logger.Errorf("failed to create HostOperation of step %s: %v", step, err)
+			return ctrl.Result{}, err
+		}
+		logger.Warnf("failed to create HostOperation of step %s: %v", step, err)
+		r.event(hostStatus, node, corev1.EventTypeWarning, "RemediationStepSkipped",
+			fmt.Sprintf("step %s of node %s is skipped: %v", step, node.Name, err))
+		return r.runStep(ctx, hostStatus, node, rem, nextSteps[step], requests, logger)
+	}
+	rem.HostOperation = op.Name
+	rem.Message = fmt.Sprintf("HostOperation %s is created", op.Name)
+	r.event(hostStatus, node, corev1.EventTypeWarning, "Remediation"+step,
+		fmt.Sprintf("step %s of node %s: HostOperation %s is created", step, node.Name, op.Name))
+	return ctrl.Result{RequeueAfter: progressInterval}, r.save(ctx, hostStatus, rem, requests, logger)
+}
+
+// progress moves to the next step when the HostOperation of the current step fails, or the node is still NotReady after the step timeout
+func (r *RemediationReconciler) progress(ctx context.Context, hostStatus *bmcv1beta1.HostStatus, node *corev1.Node,
+	requests []bmcv1beta1.HostRemediation, logger *zap.SugaredLogger) (ctrl.Result, error) {
+	rem := hostStatus.Status.Remediation.DeepCopy()
+	if !isActiveStep(rem.Step) {
+		// wait for the node to become Ready
+		r.syncRequests(ctx, requests, hostStatus.Name, rem.Step, rem.Message, logger)
+		return ctrl.Result{}, nil
+	}
+
+	op := &bmcv1beta1.HostOperation{}
+	if err := r.Get(ctx, client.ObjectKey{Name: rem.HostOperation}, op); err != nil {
+		if !errors.IsNotFound(err) {
+			return ctrl.Result{}, err
+		}
+		// the cache may not have the HostOperation yet, or it is deleted after it finished, which is not a failure
+		// of the step, so the node is given the step timeout to become Ready
+		logger.Warnf("HostOperation %s of step %s is not found", rem.HostOperation, rem.Step)
+		return r.waitStep(ctx, hostStatus, node, rem, requests, logger)
+	}
+
+	switch op.Status.Status {
+	case bmcv1beta1.HostOperationStatusSuccess:
+	case bmcv1beta1.HostOperationStatusFailed, bmcv1beta1.HostOperationStatusCancelled:
+		r.event(hostStatus, node, corev1.EventTypeWarning, "RemediationStepFailed",
+			fmt.Sprintf("step %s of node %s failed: HostOperation %s is %s: %s", rem.Step, node.Name, op.Name, op.Status.Status, op.Status.Message))
+		return r.runStep(ctx, hostStatus, node, rem, nextSteps[rem.Step], requests, logger)
+	default:
+		return ctrl.Result{RequeueAfter: progressInterval}, nil
+	}
+
+	if rem.Step == bmcv1beta1.RemediationStepPowerOff {
+		if err := r.setOutOfService(ctx, node, true); err != nil {
+			logger.Errorf("failed to taint node %s out-of-service: %v", node.Name, err)
+			return ctrl.Result{}, err
+		}
+		rem.Step = bmcv1beta1.RemediationStepPoweredOff
+		rem.Message = "the host is powered off, and the node is tainted out-of-service until it becomes Ready"
+		r.event(hostStatus, node, corev1.EventTypeWarning, "RemediationPoweredOff", fmt.Sprintf("node %s: %s", node.Name, rem.Message))
+		return ctrl.Result{}, r.save(ctx, hostStatus, rem, requests, logger)
+	}
+	return r.waitStep(ctx, hostStatus, node, rem, requests, logger)
+}
+
+// waitStep moves to the next step when the node is still NotReady after the step timeout
+func (r *RemediationReconciler) waitStep(ctx context.Context, hostStatus *bmcv1beta1.HostStatus, node *corev1.Node,
+	rem *bmcv1beta1.RemediationStatus, requests []bmcv1beta1.HostRemediation, logger *zap.SugaredLogger) (ctrl.Result, error) {
+	start, err := time.Parse(time.RFC3339, rem.StepStartTime)
+	if err != nil {
+		start = time.Now()
+	}
+	if wait := time.Until(start.Add(time.Duration(rem.StepTimeoutSeconds) * time.Second)); wait > 0 {
+		return ctrl.Result{RequeueAfter: wait}, nil
+	}
+	r.event(hostStatus, node, corev1.EventTypeWarning, "RemediationStepTimedOut",
+		fmt.Sprintf("node %s is still NotReady %d seconds after step %s", node.Name, rem.StepTimeoutSeconds, rem.Step))
+	return r.runStep(ctx, hostStatus, node, rem, nextSteps[rem.Step], requests, logger)
+}
+
+// recover finishes the remediation once the node becomes Ready
+func (r *RemediationReconciler) recover(ctx context.Context, hostStatus *bmcv1beta1.HostStatus, node *corev1.Node,
+	requests []bmcv1beta1.HostRemediation, logger *zap.SugaredLogger) error {
+	rem := hostStatus.Status.Remediation.DeepCopy()
+	if err := r.setOutOfService(ctx, node, false); err != nil {
+		logger.Errorf("failed to remove the out-of-service taint of node %s: %v", node.Name, err)
+		return err
+	}
+	msg := fmt.Sprintf("node %s becomes Ready at step %s", node.Name, rem.Step)
+	r.event(hostStatus, node, corev1.EventTypeNormal, "RemediationSucceeded", msg)
+	r.syncRequests(ctx, requests, hostStatus.Name, "", msg, logger)
+	return r.setRemediation(ctx, hostStatus, nil)
+}
+
+// setOutOfService adds or removes the out-of-service taint of the node
+func (r *RemediationReconciler) setOutOfService(ctx context.Context, node *corev1.Node, tainted bool) error {
+	index := -1
+	for i := range node.Spec.Taints {
+		if node.Spec.Taints[i].Key == outOfServiceTaintKey {
+			index = i
+			break
+		}
+	}
+	if tainted == (index >= 0) {
+		return nil
+	}
+	patch := client.MergeFromWithOptions(node.DeepCopy(), client.MergeFromWithOptimisticLock{})
+	if tainted {
+		node.Spec.Taints = append(node.Spec.Taints, corev1.Taint{
+			Key:    outOfServiceTaintKey,
+			Value:  outOfServiceTaintValue,
+			Effect: corev1.TaintEffectNoExecute,
+		})
+	} else {
+		node.Spec.Taints = append(node.Spec.Taints[:index], node.Spec.Taints[index+1:]...)
+	}
+	return r.Patch(ctx, node, patch)
+}
+
+// save records the remediation in the HostStatus and the HostRemediations
+func (r *RemediationReconciler) save(ctx context.Context, hostStatus *bmcv1beta1.HostStatus, rem *bmcv1beta1.RemediationStatus,
+	requests []bmcv1beta1.HostRemediation, logger *zap.SugaredLogger) error {
+	r.syncRequests(ctx, requests, hostStatus.Name, rem.Step, rem.Message, logger)
+	return r.setRemediation(ctx, hostStatus, rem)
+}
+
+func (r *RemediationReconciler) setRemediation(ctx context.Context, hostStatus *bmcv1beta1.HostStatus, rem *bmcv1beta1.RemediationStatus) error {
+	patch := client.MergeFrom(hostStatus.DeepCopy())
+	hostStatus.Status.Remediation = rem
+	return r.Status().Patch(ctx, hostStatus, patch)
+}
+
+// syncRequests reports the progress to the HostRemediations with the conditions of the external remediation contract,
+// the node becomes Ready when the step is empty
+func (r *RemediationReconciler) syncRequests(ctx context.Context, requests []bmcv1beta1.HostRemediation, hostStatusName, step, message string,
+	logger *zap.SugaredLogger) {
+	for i := range requests {
+		item := &requests[i]
+		patch := client.MergeFrom(item.DeepCopy())
+		item.Status.HostStatusName = hostStatusName
+		item.Status.Step = step
+		item.Status.Message = message
+		processing, succeeded := metav1.ConditionFalse, metav1.ConditionUnknown
+		reason := "Remediating"
+		switch step {
+		case "":
+			succeeded, reason = metav1.ConditionTrue, "NodeReady"
+		case stepWaiting:
+			reason = stepWaiting
+		case bmcv1beta1.RemediationStepPoweredOff:
+			succeeded, reason = metav1.ConditionTrue, "PoweredOff"
+		case bmcv1beta1.RemediationStepFailed:
+			succeeded, reason = metav1.ConditionFalse, "Failed"
+		default:
+			processing = metav1.ConditionTrue
+		}
+		meta.SetStatusCondition(&item.Status.Conditions, metav1.Condition{
+			Type: bmcv1beta1.HostRemediationConditionProcessing, Status: processing, Reason: reason, Message: message,
+		})
+		meta.SetStatusCondition(&item.Status.Conditions, metav1.Condition{
+			Type: bmcv1beta1.HostRemediationConditionSucceeded, Status: succeeded, Reason: reason, Message: message,
+		})
+		if err := r.Status().Patch(ctx, item, patch); err != nil && !errors.IsNotFound(err) {
+			logger.Errorf("failed to update HostRemediation %s/%s: %v", item.Namespace, item.Name, err)
+		}
+	}
+}
+
+// event records the event on both the node and the HostStatus
+func (r *RemediationReconciler) event(hostStatus *bmcv1beta1.HostStatus, node *corev1.Node, eventType, reason, msg string) {
+	r.Recorder.Event(node, eventType, reason, msg)
+	r.Recorder.Event(&corev1.ObjectReference{
+		Kind:       bmcv1beta1.KindHostStatus,
+		Name:       hostStatus.Name,
+		Namespace:  r.Namespace,
+		APIVersion: bmcv1beta1.APIVersion,
+	}, eventType, reason, msg)
+}
+
+// enqueueNodeHost triggers the HostStatus annotated on the node
+func (r *RemediationReconciler) enqueueNodeHost(ctx context.Context, obj client.Object) []reconcile.Request {
+	if name := obj.GetAnnotations()[bmcv1beta1.AnnotationHostStatus]; name != "" {
+		return []reconcile.Request{{NamespacedName: client.ObjectKey{Name: name}}}
+	}
+	return nil
+}
+
+// enqueueOperationHost triggers the HostStatus remediated by the HostOperation
+func (r *RemediationReconciler) enqueueOperationHost(ctx context.Context, obj client.Object) []reconcile.Request {
+	op, ok := obj.(*bmcv1beta1.HostOperation)
+	if !ok {
+		return nil
+	}
+	if _, ok := op.Labels[bmcv1beta1.LabelRemediation]; ok {
+		return []reconcile.Request{{NamespacedName: client.ObjectKey{Name: op.Spec.HostStatusName}}}
+	}
+	return nil
+}
+
+// enqueueRequestHost triggers the HostStatus of the node requested by the HostRemediation
+func (r *RemediationReconciler) enqueueRequestHost(ctx context.Context, obj client.Object) []reconcile.Request {
+	node := &corev1.Node{}
+	if err := r.Get(ctx, client.ObjectKey{Name: obj.GetName()}, node); err != nil {
+		return nil
+	}
+	return r.enqueueNodeHost(ctx, node)
+}
+
+// readinessChanged ignores the updates of the node which do not affect the remediation
+func readinessChanged(e event.UpdateEvent) bool {
+	oldNode, ok := e.ObjectOld.(*corev1.Node)
+	if !ok {
+		return true
+	}
+	newNode, ok := e.ObjectNew.(*corev1.Node)
+	if !ok {
+		return true
+	}
+	oldReady, _ := nodeReadiness(oldNode)
+	newReady, _ := nodeReadiness(newNode)
+	return oldReady != newReady ||
+		oldNode.Annotations[bmcv1beta1.AnnotationHostStatus] != newNode.Annotations[bmcv1beta1.AnnotationHostStatus]
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *RemediationReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	r.apiReader = mgr.GetAPIReader()
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("remediation").
+		For(&bmcv1beta1.HostStatus{}).
+		Watches(&corev1.Node{}, handler.EnqueueRequestsFromMapFunc(r.enqueueNodeHost),
+			builder.WithPredicates(predicate.Funcs{UpdateFunc: readinessChanged})).
+		Watches(&bmcv1beta1.HostOperation{}, handler.EnqueueRequestsFromMapFunc(r.enqueueOperationHost)).
+		Watches(&bmcv1beta1.HostRemediation{}, handler.EnqueueRequestsFromMapFunc(r.enqueueRequestHost)).
+		Complete(r)
+}
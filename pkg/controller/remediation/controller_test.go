@@ -0,0 +1,209 @@
+package remediation_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spidernet-io/bmc/pkg/controller/remediation"
+	bmcv1beta1 "github.com/spidernet-io/bmc/pkg/k8s/apis/bmc.spidernet.io/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+var _ = Describe("Remediation", Label("unitest"), func() {
+	var (
+		ctx    context.Context
+		scheme *runtime.Scheme
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		scheme = runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(bmcv1beta1.AddToScheme(scheme)).To(Succeed())
+	})
+
+	node := func(name string, ready bool) *corev1.Node {
+		status := corev1.ConditionFalse
+		if ready {
+			status = corev1.ConditionTrue
+		}
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status: corev1.NodeStatus{Conditions: []corev1.NodeCondition{
+				{Type: corev1.NodeReady, Status: status},
+			}},
+		}
+	}
+	host := func(name, nodeName string, rem *bmcv1beta1.RemediationStatus) *bmcv1beta1.HostStatus {
+		return &bmcv1beta1.HostStatus{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status: bmcv1beta1.HostStatusStatus{
+				Healthy:     true,
+				Info:        map[string]string{"PowerState": "On"},
+				NodeName:    nodeName,
+				Remediation: rem,
+			},
+		}
+	}
+	request := func(nodeName string) *bmcv1beta1.HostRemediation {
+		return &bmcv1beta1.HostRemediation{ObjectMeta: metav1.ObjectMeta{Name: nodeName, Namespace: "default"}}
+	}
+
+	newReconciler := func(funcs *interceptor.Funcs, objs ...client.Object) (*remediation.RemediationReconciler, client.Client) {
+		builder := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).
+			WithStatusSubresource(&bmcv1beta1.HostStatus{}, &bmcv1beta1.HostOperation{}, &bmcv1beta1.HostRemediation{})
+		if funcs != nil {
+			builder = builder.WithInterceptorFuncs(*funcs)
+		}
+		c := builder.Build()
+		r := &remediation.RemediationReconciler{
+			Client:    c,
+			Scheme:    scheme,
+			Config:    remediation.Config{MaxConcurrent: 1, MaxNotReadyPercent: 50},
+			Recorder:  record.NewFakeRecorder(100),
+			Namespace: "bmc",
+		}
+		r.SetAPIReader(c)
+		return r, c
+	}
+	reconcile := func(r *remediation.RemediationReconciler, name string) error {
+		_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKey{Name: name}})
+		return err
+	}
+	remediationOf := func(c client.Client, name string) *bmcv1beta1.RemediationStatus {
+		hs := &bmcv1beta1.HostStatus{}
+		Expect(c.Get(ctx, client.ObjectKey{Name: name}, hs)).To(Succeed())
+		return hs.Status.Remediation
+	}
+	finishOperation := func(c client.Client, name, status string) {
+		op := &bmcv1beta1.HostOperation{}
+		Expect(c.Get(ctx, client.ObjectKey{Name: name}, op)).To(Succeed())
+		op.Status.Status = status
+		Expect(c.Status().Update(ctx, op)).To(Succeed())
+	}
+
+	It("escalates the steps until the host is powered off, and untaints the node once it is Ready", func() {
+		r, c := newReconciler(nil, node("node1", false), node("node2", true), node("node3", true),
+			host("host1", "node1", nil), request("node1"))
+
+		Expect(reconcile(r, "host1")).To(Succeed())
+		rem := remediationOf(c, "host1")
+		Expect(rem.Step).To(Equal(bmcv1beta1.RemediationStepGracefulRestart))
+		op := &bmcv1beta1.HostOperation{}
+		Expect(c.Get(ctx, client.ObjectKey{Name: rem.HostOperation}, op)).To(Succeed())
+		Expect(op.Spec.Action).To(Equal(bmcv1beta1.BootCmdGracefulRestart))
+		Expect(op.Spec.HostStatusName).To(Equal("host1"))
+
+		finishOperation(c, rem.HostOperation, bmcv1beta1.HostOperationStatusFailed)
+		Expect(reconcile(r, "host1")).To(Succeed())
+		rem = remediationOf(c, "host1")
+		Expect(rem.Step).To(Equal(bmcv1beta1.RemediationStepForceRestart))
+		Expect(c.Get(ctx, client.ObjectKey{Name: rem.HostOperation}, op)).To(Succeed())
+		Expect(op.Spec.Action).To(Equal(bmcv1beta1.BootCmdForceRestart))
+
+		finishOperation(c, rem.HostOperation, bmcv1beta1.HostOperationStatusFailed)
+		Expect(reconcile(r, "host1")).To(Succeed())
+		rem = remediationOf(c, "host1")
+		Expect(rem.Step).To(Equal(bmcv1beta1.RemediationStepPowerOff))
+
+		finishOperation(c, rem.HostOperation, bmcv1beta1.HostOperationStatusSuccess)
+		Expect(reconcile(r, "host1")).To(Succeed())
+		Expect(remediationOf(c, "host1").Step).To(Equal(bmcv1beta1.RemediationStepPoweredOff))
+		n := &corev1.Node{}
+		Expect(c.Get(ctx, client.ObjectKey{Name: "node1"}, n)).To(Succeed())
+		Expect(n.Spec.Taints).To(ContainElement(HaveField("Key", "node.kubernetes.io/out-of-service")))
+
+		n.Status.Conditions[0].Status = corev1.ConditionTrue
+		Expect(c.Status().Update(ctx, n)).To(Succeed())
+		Expect(reconcile(r, "host1")).To(Succeed())
+		Expect(remediationOf(c, "host1")).To(BeNil())
+		Expect(c.Get(ctx, client.ObjectKey{Name: "node1"}, n)).To(Succeed())
+		Expect(n.Spec.Taints).To(BeEmpty())
+		req := &bmcv1beta1.HostRemediation{}
+		Expect(c.Get(ctx, client.ObjectKey{Name: "node1", Namespace: "default"}, req)).To(Succeed())
+		Expect(meta.IsStatusConditionTrue(req.Status.Conditions, bmcv1beta1.HostRemediationConditionSucceeded)).To(BeTrue())
+	})
+
+	It("waits for the step timeout when the HostOperation of the step is deleted", func() {
+		r, c := newReconciler(nil, node("node1", false), node("node2", true), node("node3", true),
+			host("host1", "node1", nil), request("node1"))
+		Expect(reconcile(r, "host1")).To(Succeed())
+		rem := remediationOf(c, "host1")
+		Expect(c.Delete(ctx, &bmcv1beta1.HostOperation{ObjectMeta: metav1.ObjectMeta{Name: rem.HostOperation}})).To(Succeed())
+
+		result, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKey{Name: "host1"}})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).To(BeNumerically(">", 0))
+		Expect(remediationOf(c, "host1")).To(Equal(rem))
+	})
+
+	It("skips the step denied by the webhook", func() {
+		funcs := &interceptor.Funcs{Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+			if op, ok := obj.(*bmcv1beta1.HostOperation); ok && op.Spec.Action == bmcv1beta1.BootCmdGracefulRestart {
+				return apierrors.NewForbidden(schema.GroupResource{Resource: "hostoperations"}, op.Name, nil)
+			}
+			return c.Create(ctx, obj, opts...)
+		}}
+		r, c := newReconciler(funcs, node("node1", false), node("node2", true), node("node3", true),
+			host("host1", "node1", nil), request("node1"))
+
+		Expect(reconcile(r, "host1")).To(Succeed())
+		Expect(remediationOf(c, "host1").Step).To(Equal(bmcv1beta1.RemediationStepForceRestart))
+	})
+
+	It("retries the step when the HostOperation fails to be created for the other errors", func() {
+		funcs := &interceptor.Funcs{Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+			return apierrors.NewServerTimeout(schema.GroupResource{Resource: "hostoperations"}, "create", 1)
+		}}
+		r, c := newReconciler(funcs, node("node1", false), node("node2", true), node("node3", true),
+			host("host1", "node1", nil), request("node1"))
+
+		Expect(reconcile(r, "host1")).NotTo(Succeed())
+		Expect(remediationOf(c, "host1")).To(BeNil())
+	})
+
+	It("waits while the other hosts reach the concurrency limit", func() {
+		r, c := newReconciler(nil, node("node1", false), node("node2", true), node("node3", true),
+			host("host1", "node1", nil), request("node1"),
+			host("host2", "node2", &bmcv1beta1.RemediationStatus{Step: bmcv1beta1.RemediationStepForceRestart}))
+
+		Expect(reconcile(r, "host1")).To(Succeed())
+		Expect(remediationOf(c, "host1")).To(BeNil())
+		req := &bmcv1beta1.HostRemediation{}
+		Expect(c.Get(ctx, client.ObjectKey{Name: "node1", Namespace: "default"}, req)).To(Succeed())
+		Expect(req.Status.Step).To(Equal("Waiting"))
+		Expect(req.Status.Message).To(ContainSubstring("reaches the limit"))
+	})
+
+	It("does not count the hosts which finished the steps against the concurrency limit", func() {
+		r, c := newReconciler(nil, node("node1", false), node("node2", true), node("node3", true),
+			host("host1", "node1", nil), request("node1"),
+			host("host2", "node2", &bmcv1beta1.RemediationStatus{Step: bmcv1beta1.RemediationStepPoweredOff}))
+
+		Expect(reconcile(r, "host1")).To(Succeed())
+		Expect(remediationOf(c, "host1").Step).To(Equal(bmcv1beta1.RemediationStepGracefulRestart))
+	})
+
+	It("waits during a cluster-wide outage", func() {
+		r, c := newReconciler(nil, node("node1", false), node("node2", false), node("node3", true),
+			host("host1", "node1", nil), request("node1"))
+
+		Expect(reconcile(r, "host1")).To(Succeed())
+		Expect(remediationOf(c, "host1")).To(BeNil())
+		req := &bmcv1beta1.HostRemediation{}
+		Expect(c.Get(ctx, client.ObjectKey{Name: "node1", Namespace: "default"}, req)).To(Succeed())
+		Expect(req.Status.Message).To(ContainSubstring("cluster-wide outage"))
+	})
+})
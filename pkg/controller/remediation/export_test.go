@@ -0,0 +1,8 @@
+package remediation
+
+import "sigs.k8s.io/controller-runtime/pkg/client"
+
+// SetAPIReader sets the reader of the HostStatuses, which is set from the manager otherwise
+func (r *RemediationReconciler) SetAPIReader(reader client.Reader) {
+	r.apiReader = reader
+}
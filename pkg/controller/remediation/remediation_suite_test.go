@@ -0,0 +1,19 @@
+package remediation_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spidernet-io/bmc/pkg/log"
+	"go.uber.org/zap"
+)
+
+func TestRemediation(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Remediation Suite")
+}
+
+var _ = BeforeSuite(func() {
+	log.Logger = zap.NewNop().Sugar()
+})
@@ -0,0 +1,174 @@
+// Package hardware detects the changes of the hardware components of the host between two inventory collections
+package hardware
+
+import (
+	"fmt"
+	"sort"
+
+	bmcv1beta1 "github.com/spidernet-io/bmc/pkg/k8s/apis/bmc.spidernet.io/v1beta1"
+)
+
+// key identifies the slot of the component in the host by its type and location.
+// Several components could share a slot when the BMC reports no distinct location for them
+func key(c *bmcv1beta1.HardwareComponent) string {
+	return c.Type + "/" + c.Location
+}
+
+// Sort orders the components by type, location and serial number, so that their order does not depend on the BMC
+func Sort(components []bmcv1beta1.HardwareComponent) {
+	sort.SliceStable(components, func(i, j int) bool {
+		if components[i].Type != components[j].Type {
+			return components[i].Type < components[j].Type
+		}
+		if components[i].Location != components[j].Location {
+			return components[i].Location < components[j].Location
+		}
+		return components[i].SerialNumber < components[j].SerialNumber
+	})
+}
+
+// group returns the components of every slot, ordered by their serial numbers
+func group(components []bmcv1beta1.HardwareComponent) map[string][]*bmcv1beta1.HardwareComponent {
+	result := map[string][]*bmcv1beta1.HardwareComponent{}
+	for i := range components {
+		k := key(&components[i])
+		result[k] = append(result[k], &components[i])
+	}
+	for _, list := range result {
+		sort.SliceStable(list, func(i, j int) bool {
+			return list[i].SerialNumber < list[j].SerialNumber
+		})
+	}
+	return result
+}
+
+// pair matches the old and current components in the same slot. The components with the same
+// serial number are matched first, and the others are matched in order. A nil old component means
+// the current one is added, and a nil current component means the old one is removed
+func pair(old, cur []*bmcv1beta1.HardwareComponent) [][2]*bmcv1beta1.HardwareComponent {
+	result := [][2]*bmcv1beta1.HardwareComponent{}
+	matched := make([]bool, len(old))
+	rest := []*bmcv1beta1.HardwareComponent{}
+	for _, c := range cur {
+		found := false
+		if c.SerialNumber != "" {
+			for i, o := range old {
+				if !matched[i] && o.SerialNumber == c.SerialNumber {
+					matched[i] = true
+					found = true
+					result = append(result, [2]*bmcv1beta1.HardwareComponent{o, c})
+					break
+				}
+			}
+		}
+		if !found {
+			rest = append(rest, c)
+		}
+	}
+	for i, o := range old {
+		if matched[i] {
+			continue
+		}
+		if len(rest) > 0 {
+			result = append(result, [2]*bmcv1beta1.HardwareComponent{o, rest[0]})
+			rest = rest[1:]
+			continue
+		}
+		result = append(result, [2]*bmcv1beta1.HardwareComponent{o, nil})
+	}
+	for _, c := range rest {
+		result = append(result, [2]*bmcv1beta1.HardwareComponent{nil, c})
+	}
+	return result
+}
+
+// describe returns the name of the component used in the messages
+func describe(c *bmcv1beta1.HardwareComponent) string {
+	if c.Model != "" {
+		return fmt.Sprintf("%s %s", c.Type, c.Model)
+	}
+	return c.Type
+}
+
+func change(c *bmcv1beta1.HardwareComponent, kind, message string) bmcv1beta1.HardwareChange {
+	return bmcv1beta1.HardwareChange{
+		Type:     c.Type,
+		Location: c.Location,
+		Change:   kind,
+		Message:  message,
+	}
+}
+
+// compare returns the change of the component in the same location, or nil when it does not change
+func compare(old, cur *bmcv1beta1.HardwareComponent) *bmcv1beta1.HardwareChange {
+	switch {
+	case old.SerialNumber != "" && cur.SerialNumber != "" && old.SerialNumber != cur.SerialNumber:
+		t := change(cur, bmcv1beta1.HardwareChangeReplaced,
+			fmt.Sprintf("%s %s serial %s replaced by %s", cur.Type, cur.Location, old.SerialNumber, cur.SerialNumber))
+		return &t
+	case old.SerialNumber == "" && cur.SerialNumber == "" && old.Model != cur.Model:
+		t := change(cur, bmcv1beta1.HardwareChangeReplaced,
+			fmt.Sprintf("%s %s model %s replaced by %s", cur.Type, cur.Location, old.Model, cur.Model))
+		return &t
+	case old.FirmwareVersion != cur.FirmwareVersion:
+		var msg string
+		switch cur.Type {
+		case bmcv1beta1.HardwareTypeBIOS, bmcv1beta1.HardwareTypeBMC:
+			msg = fmt.Sprintf("%s version changed %s→%s", cur.Type, old.FirmwareVersion, cur.FirmwareVersion)
+		default:
+			msg = fmt.Sprintf("%s %s firmware changed %s→%s", cur.Type, cur.Location, old.FirmwareVersion, cur.FirmwareVersion)
+		}
+		t := change(cur, bmcv1beta1.HardwareChangeFirmwareChanged, msg)
+		return &t
+	}
+	return nil
+}
+
+// Diff returns the changes from the old components to the current ones, ordered by type and location
+func Diff(old, cur []bmcv1beta1.HardwareComponent) []bmcv1beta1.HardwareChange {
+	previous := group(old)
+	current := group(cur)
+	keys := []string{}
+	for k := range current {
+		keys = append(keys, k)
+	}
+	for k := range previous {
+		if _, ok := current[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	result := []bmcv1beta1.HardwareChange{}
+	for _, k := range keys {
+		for _, p := range pair(previous[k], current[k]) {
+			o, c := p[0], p[1]
+			switch {
+			case o == nil:
+				msg := fmt.Sprintf("%s added to %s", describe(c), c.Location)
+				if c.SerialNumber != "" {
+					msg += fmt.Sprintf(" with serial %s", c.SerialNumber)
+				}
+				result = append(result, change(c, bmcv1beta1.HardwareChangeAdded, msg))
+			case c == nil:
+				msg := fmt.Sprintf("%s removed from %s", describe(o), o.Location)
+				if o.SerialNumber != "" {
+					msg += fmt.Sprintf(", its serial was %s", o.SerialNumber)
+				}
+				result = append(result, change(o, bmcv1beta1.HardwareChangeRemoved, msg))
+			default:
+				if t := compare(o, c); t != nil {
+					result = append(result, *t)
+				}
+			}
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].Type != result[j].Type {
+			return result[i].Type < result[j].Type
+		}
+		return result[i].Location < result[j].Location
+	})
+	return result
+}
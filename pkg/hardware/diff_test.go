@@ -0,0 +1,90 @@
+package hardware_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spidernet-io/bmc/pkg/hardware"
+	bmcv1beta1 "github.com/spidernet-io/bmc/pkg/k8s/apis/bmc.spidernet.io/v1beta1"
+)
+
+var _ = Describe("Hardware", Label("unitest"), func() {
+
+	old := []bmcv1beta1.HardwareComponent{
+		{Type: bmcv1beta1.HardwareTypeBIOS, Location: "System", FirmwareVersion: "2.1"},
+		{Type: bmcv1beta1.HardwareTypeMemory, Location: "A1", SerialNumber: "S1"},
+		{Type: bmcv1beta1.HardwareTypeMemory, Location: "A3", SerialNumber: "X"},
+		{Type: bmcv1beta1.HardwareTypePCIeDevice, Location: "Slot 4", Model: "A100", SerialNumber: "G1"},
+	}
+
+	It("ignores the order of the components", func() {
+		reordered := []bmcv1beta1.HardwareComponent{old[3], old[2], old[1], old[0]}
+		Expect(hardware.Diff(old, reordered)).To(BeEmpty())
+	})
+
+	It("reports the replaced, removed and upgraded components", func() {
+		cur := []bmcv1beta1.HardwareComponent{
+			{Type: bmcv1beta1.HardwareTypeBIOS, Location: "System", FirmwareVersion: "2.3"},
+			{Type: bmcv1beta1.HardwareTypeMemory, Location: "A1", SerialNumber: "S1"},
+			{Type: bmcv1beta1.HardwareTypeMemory, Location: "A3", SerialNumber: "Y"},
+			{Type: bmcv1beta1.HardwareTypeCPU, Location: "CPU2", SerialNumber: "C2"},
+		}
+		changes := hardware.Diff(old, cur)
+		Expect(changes).To(HaveLen(4))
+
+		Expect(changes[0].Change).To(Equal(bmcv1beta1.HardwareChangeFirmwareChanged))
+		Expect(changes[0].Message).To(Equal("BIOS version changed 2.1→2.3"))
+		Expect(changes[1].Change).To(Equal(bmcv1beta1.HardwareChangeAdded))
+		Expect(changes[1].Message).To(Equal("CPU added to CPU2 with serial C2"))
+		Expect(changes[2].Change).To(Equal(bmcv1beta1.HardwareChangeReplaced))
+		Expect(changes[2].Message).To(Equal("Memory A3 serial X replaced by Y"))
+		Expect(changes[3].Change).To(Equal(bmcv1beta1.HardwareChangeRemoved))
+		Expect(changes[3].Message).To(Equal("PCIeDevice A100 removed from Slot 4, its serial was G1"))
+	})
+
+	Context("components sharing a location", func() {
+		// the BMC reports the same location for the disks behind a controller
+		disks := []bmcv1beta1.HardwareComponent{
+			{Type: bmcv1beta1.HardwareTypeDisk, Location: "RAID.Integrated.1-1", SerialNumber: "D1", Model: "PM9A3", FirmwareVersion: "GDC5302Q"},
+			{Type: bmcv1beta1.HardwareTypeDisk, Location: "RAID.Integrated.1-1", SerialNumber: "D2", Model: "PM9A3", FirmwareVersion: "GDC5302Q"},
+			{Type: bmcv1beta1.HardwareTypeDisk, Location: "RAID.Integrated.1-1", SerialNumber: "D3", Model: "PM9A3", FirmwareVersion: "GDC5302Q"},
+		}
+
+		It("ignores the order of the components", func() {
+			reordered := []bmcv1beta1.HardwareComponent{disks[2], disks[0], disks[1]}
+			Expect(hardware.Diff(disks, reordered)).To(BeEmpty())
+		})
+
+		It("reports a removed component", func() {
+			changes := hardware.Diff(disks, []bmcv1beta1.HardwareComponent{disks[0], disks[2]})
+			Expect(changes).To(HaveLen(1))
+			Expect(changes[0].Change).To(Equal(bmcv1beta1.HardwareChangeRemoved))
+			Expect(changes[0].Message).To(Equal("Disk PM9A3 removed from RAID.Integrated.1-1, its serial was D2"))
+		})
+
+		It("reports an added component", func() {
+			added := bmcv1beta1.HardwareComponent{Type: bmcv1beta1.HardwareTypeDisk, Location: "RAID.Integrated.1-1", SerialNumber: "D0", Model: "PM9A3"}
+			changes := hardware.Diff(disks, append([]bmcv1beta1.HardwareComponent{added}, disks...))
+			Expect(changes).To(HaveLen(1))
+			Expect(changes[0].Change).To(Equal(bmcv1beta1.HardwareChangeAdded))
+			Expect(changes[0].Message).To(Equal("Disk PM9A3 added to RAID.Integrated.1-1 with serial D0"))
+		})
+
+		It("reports a replaced component and the firmware change of another one", func() {
+			cur := []bmcv1beta1.HardwareComponent{disks[0], disks[2], disks[1]}
+			cur[0].FirmwareVersion = "GDC5A02Q"
+			cur[2].SerialNumber = "D9"
+			changes := hardware.Diff(disks, cur)
+			Expect(changes).To(HaveLen(2))
+			Expect(changes[0].Change).To(Equal(bmcv1beta1.HardwareChangeFirmwareChanged))
+			Expect(changes[0].Message).To(Equal("Disk RAID.Integrated.1-1 firmware changed GDC5302Q→GDC5A02Q"))
+			Expect(changes[1].Change).To(Equal(bmcv1beta1.HardwareChangeReplaced))
+			Expect(changes[1].Message).To(Equal("Disk RAID.Integrated.1-1 serial D2 replaced by D9"))
+		})
+
+		It("sorts the components by their serial numbers", func() {
+			reordered := []bmcv1beta1.HardwareComponent{disks[2], disks[0], disks[1]}
+			hardware.Sort(reordered)
+			Expect(reordered).To(Equal(disks))
+		})
+	})
+})
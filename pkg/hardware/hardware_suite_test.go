@@ -0,0 +1,13 @@
+package hardware_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestHardware(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Hardware Suite")
+}
@@ -0,0 +1,207 @@
+// Package hostpolicy evaluates the HostPolicy which restricts the destructive actions on the hosts
+package hostpolicy
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	bmcv1beta1 "github.com/spidernet-io/bmc/pkg/k8s/apis/bmc.spidernet.io/v1beta1"
+	"github.com/spidernet-io/bmc/pkg/schedule"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// DefaultDestructiveActions is the actions restricted by a policy without spec.destructiveActions
+var DefaultDestructiveActions = []string{
+	bmcv1beta1.BootCmdForceOff,
+	bmcv1beta1.BootCmdForceRestart,
+	bmcv1beta1.BootCmdResetPxeOnce,
+	bmcv1beta1.ActionBmcResetToDefaults,
+}
+
+// Selects returns whether the policy applies to the host
+func Selects(policy *bmcv1beta1.HostPolicy, hostStatus *bmcv1beta1.HostStatus) (bool, error) {
+	if policy.Spec.Selector == nil && len(policy.Spec.HostStatusNames) == 0 {
+		return true, nil
+	}
+	for _, name := range policy.Spec.HostStatusNames {
+		if name == hostStatus.Name {
+			return true, nil
+		}
+	}
+	if policy.Spec.Selector == nil {
+		return false, nil
+	}
+	selector, err := metav1.LabelSelectorAsSelector(policy.Spec.Selector)
+	if err != nil {
+		return false, fmt.Errorf("invalid selector of HostPolicy %s: %v", policy.Name, err)
+	}
+	return selector.Matches(labels.Set(hostStatus.Labels)), nil
+}
+
+// IsDestructive returns whether the action is restricted by the policy
+func IsDestructive(policy *bmcv1beta1.HostPolicy, action string) bool {
+	actions := policy.Spec.DestructiveActions
+	if len(actions) == 0 {
+		actions = DefaultDestructiveActions
+	}
+	for _, a := range actions {
+		if a == action {
+			return true
+		}
+	}
+	return false
+}
+
+// InWindow returns whether the time is in the window, and the start of the next window
+func InWindow(w *bmcv1beta1.MaintenanceWindow, now time.Time) (bool, time.Time, error) {
+	s, err := schedule.Parse(w.Schedule)
+	if err != nil {
+		return false, time.Time{}, fmt.Errorf("invalid schedule %q: %v", w.Schedule, err)
+	}
+	loc, err := schedule.LoadLocation(w.TimeZone)
+	if err != nil {
+		return false, time.Time{}, fmt.Errorf("invalid time zone %q: %v", w.TimeZone, err)
+	}
+	now = now.In(loc)
+	duration := time.Duration(w.DurationMinutes) * time.Minute
+	// the latest window started within the duration
+	start := s.Next(now.Add(-duration))
+	if !start.IsZero() && !start.After(now) {
+		return true, start, nil
+	}
+	return false, s.Next(now), nil
+}
+
+// Check returns the reason why the policy denies the action on the host at the time, or "" when it is allowed
+func Check(policy *bmcv1beta1.HostPolicy, action string, now time.Time) (string, error) {
+	if !IsDestructive(policy, action) {
+		return "", nil
+	}
+	if policy.Spec.Protected {
+		return fmt.Sprintf("the host is protected by HostPolicy %s", policy.Name), nil
+	}
+	if len(policy.Spec.MaintenanceWindows) == 0 {
+		return "", nil
+	}
+	var next time.Time
+	for i := range policy.Spec.MaintenanceWindows {
+		in, start, err := InWindow(&policy.Spec.MaintenanceWindows[i], now)
+		if err != nil {
+			return "", fmt.Errorf("HostPolicy %s: %v", policy.Name, err)
+		}
+		if in {
+			return "", nil
+		}
+		if !start.IsZero() && (next.IsZero() || start.Before(next)) {
+			next = start
+		}
+	}
+	reason := fmt.Sprintf("it is out of the maintenance windows of HostPolicy %s", policy.Name)
+	if !next.IsZero() {
+		reason += fmt.Sprintf(", the next window starts at %s", next.Format(time.RFC3339))
+	}
+	return reason, nil
+}
+
+// Evaluate checks the action on the host against all the policies, and returns the reasons of the denial
+func Evaluate(policies []bmcv1beta1.HostPolicy, hostStatus *bmcv1beta1.HostStatus, action string, now time.Time) ([]string, error) {
+	var reasons []string
+	for i := range policies {
+		ok, err := Selects(&policies[i], hostStatus)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+		reason, err := Check(&policies[i], action, now)
+		if err != nil {
+			return nil, err
+		}
+		if reason != "" {
+			reasons = append(reasons, reason)
+		}
+	}
+	return reasons, nil
+}
+
+// Overridden returns whether the annotations of the operation override the policies
+func Overridden(annotations map[string]string) bool {
+	return strings.TrimSpace(annotations[bmcv1beta1.AnnotationOverridePolicy]) != ""
+}
+
+// DesiredPower returns the desired power state of the host and the policy declaring it, nil when the power state is unmanaged.
+// the policies are checked in the order of name, and the ones declaring a different state are reported as a conflict
+func DesiredPower(policies []bmcv1beta1.HostPolicy, hostStatus *bmcv1beta1.HostStatus) (*bmcv1beta1.DesiredPowerSpec, string, error) {
+	sort.Slice(policies, func(i, j int) bool { return policies[i].Name < policies[j].Name })
+	var desired *bmcv1beta1.DesiredPowerSpec
+	name := ""
+	for i := range policies {
+		p := &policies[i]
+		if p.Spec.DesiredPower == nil || p.Spec.DesiredPower.State == bmcv1beta1.DesiredPowerStateUnmanaged {
+			continue
+		}
+		ok, err := Selects(p, hostStatus)
+		if err != nil {
+			return nil, "", err
+		}
+		if !ok {
+			continue
+		}
+		if desired == nil {
+			desired, name = p.Spec.DesiredPower, p.Name
+			continue
+		}
+		if desired.State != p.Spec.DesiredPower.State {
+			return nil, "", fmt.Errorf("HostPolicy %s desires %s while HostPolicy %s desires %s", name, desired.State, p.Name, p.Spec.DesiredPower.State)
+		}
+	}
+	return desired, name, nil
+}
+
+// PollInterval returns the shortest poll interval of the policies selecting the host and the policy declaring it,
+// 0 when no policy declares it
+func PollInterval(policies []bmcv1beta1.HostPolicy, hostStatus *bmcv1beta1.HostStatus) (int32, string, error) {
+	var interval int32
+	name := ""
+	for i := range policies {
+		p := &policies[i]
+		if p.Spec.PollIntervalSeconds == nil {
+			continue
+		}
+		ok, err := Selects(p, hostStatus)
+		if err != nil {
+			return 0, "", err
+		}
+		if !ok {
+			continue
+		}
+		if interval == 0 || *p.Spec.PollIntervalSeconds < interval {
+			interval, name = *p.Spec.PollIntervalSeconds, p.Name
+		}
+	}
+	return interval, name, nil
+}
+
+// Remediation returns the remediation of the host and the policy declaring it, nil when the host is not remediated.
+// the first policy in the order of name applies when several policies select the host
+func Remediation(policies []bmcv1beta1.HostPolicy, hostStatus *bmcv1beta1.HostStatus) (*bmcv1beta1.RemediationSpec, string, error) {
+	sort.Slice(policies, func(i, j int) bool { return policies[i].Name < policies[j].Name })
+	for i := range policies {
+		p := &policies[i]
+		if p.Spec.Remediation == nil {
+			continue
+		}
+		ok, err := Selects(p, hostStatus)
+		if err != nil {
+			return nil, "", err
+		}
+		if ok {
+			return p.Spec.Remediation, p.Name, nil
+		}
+	}
+	return nil, "", nil
+}
@@ -0,0 +1,130 @@
+package hostpolicy_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spidernet-io/bmc/pkg/hostpolicy"
+	bmcv1beta1 "github.com/spidernet-io/bmc/pkg/k8s/apis/bmc.spidernet.io/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("HostPolicy", Label("unitest"), func() {
+
+	// the window opens at 02:00 every Saturday in Shanghai and lasts for 2 hours
+	window := bmcv1beta1.MaintenanceWindow{Schedule: "0 2 * * sat", DurationMinutes: 120, TimeZone: "Asia/Shanghai"}
+	shanghai, _ := time.LoadLocation("Asia/Shanghai")
+
+	DescribeTable("maintenance window",
+		func(now time.Time, expected bool) {
+			in, _, err := hostpolicy.InWindow(&window, now)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(in).To(Equal(expected))
+		},
+		Entry("at the start", time.Date(2024, 3, 16, 2, 0, 0, 0, shanghai), true),
+		Entry("inside", time.Date(2024, 3, 16, 3, 59, 0, 0, shanghai), true),
+		Entry("at the end", time.Date(2024, 3, 16, 4, 0, 0, 0, shanghai), false),
+		Entry("before", time.Date(2024, 3, 16, 1, 59, 0, 0, shanghai), false),
+		Entry("in UTC", time.Date(2024, 3, 15, 18, 30, 0, 0, time.UTC), true),
+	)
+
+	It("opens the window in the time zones with a half-hour offset", func() {
+		kolkata, _ := time.LoadLocation("Asia/Kolkata")
+		w := bmcv1beta1.MaintenanceWindow{Schedule: "0 2 * * *", DurationMinutes: 60, TimeZone: "Asia/Kolkata"}
+		in, _, err := hostpolicy.InWindow(&w, time.Date(2024, 3, 16, 2, 30, 0, 0, kolkata))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(in).To(BeTrue())
+		in, next, err := hostpolicy.InWindow(&w, time.Date(2024, 3, 16, 3, 0, 0, 0, kolkata))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(in).To(BeFalse())
+		Expect(next.Equal(time.Date(2024, 3, 17, 2, 0, 0, 0, kolkata))).To(BeTrue())
+	})
+
+	It("denies the destructive actions on the protected host", func() {
+		policy := &bmcv1beta1.HostPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "db"},
+			Spec: bmcv1beta1.HostPolicySpec{
+				Selector:  &metav1.LabelSelector{MatchLabels: map[string]string{"role": "db"}},
+				Protected: true,
+			},
+		}
+		db := &bmcv1beta1.HostStatus{ObjectMeta: metav1.ObjectMeta{Name: "db1", Labels: map[string]string{"role": "db"}}}
+		web := &bmcv1beta1.HostStatus{ObjectMeta: metav1.ObjectMeta{Name: "web1"}}
+		now := time.Now()
+
+		reasons, err := hostpolicy.Evaluate([]bmcv1beta1.HostPolicy{*policy}, db, bmcv1beta1.BootCmdForceOff, now)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(reasons).To(HaveLen(1))
+
+		reasons, err = hostpolicy.Evaluate([]bmcv1beta1.HostPolicy{*policy}, db, bmcv1beta1.BootCmdGracefulShutdown, now)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(reasons).To(BeEmpty())
+
+		reasons, err = hostpolicy.Evaluate([]bmcv1beta1.HostPolicy{*policy}, web, bmcv1beta1.BootCmdForceOff, now)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(reasons).To(BeEmpty())
+	})
+
+	It("allows the destructive actions during the maintenance windows only", func() {
+		policy := &bmcv1beta1.HostPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "weekend"},
+			Spec:       bmcv1beta1.HostPolicySpec{MaintenanceWindows: []bmcv1beta1.MaintenanceWindow{window}},
+		}
+		reason, err := hostpolicy.Check(policy, bmcv1beta1.BootCmdResetPxeOnce, time.Date(2024, 3, 16, 3, 0, 0, 0, shanghai))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(reason).To(BeEmpty())
+
+		reason, err = hostpolicy.Check(policy, bmcv1beta1.BootCmdResetPxeOnce, time.Date(2024, 3, 18, 3, 0, 0, 0, shanghai))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(reason).To(ContainSubstring("the next window starts at 2024-03-23T02:00:00+08:00"))
+	})
+
+	It("polls the host at the shortest interval of the policies", func() {
+		fast, slow := int32(15), int32(120)
+		policies := []bmcv1beta1.HostPolicy{
+			{ObjectMeta: metav1.ObjectMeta{Name: "all"}, Spec: bmcv1beta1.HostPolicySpec{PollIntervalSeconds: &slow}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "db"}, Spec: bmcv1beta1.HostPolicySpec{
+				Selector:            &metav1.LabelSelector{MatchLabels: map[string]string{"role": "db"}},
+				PollIntervalSeconds: &fast,
+			}},
+		}
+		db := &bmcv1beta1.HostStatus{ObjectMeta: metav1.ObjectMeta{Name: "db1", Labels: map[string]string{"role": "db"}}}
+		web := &bmcv1beta1.HostStatus{ObjectMeta: metav1.ObjectMeta{Name: "web1"}}
+
+		interval, policy, err := hostpolicy.PollInterval(policies, db)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(interval).To(Equal(fast))
+		Expect(policy).To(Equal("db"))
+
+		interval, policy, err = hostpolicy.PollInterval(policies, web)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(interval).To(Equal(slow))
+		Expect(policy).To(Equal("all"))
+	})
+	It("remediates the host by the first policy in the order of name", func() {
+		policies := []bmcv1beta1.HostPolicy{
+			{ObjectMeta: metav1.ObjectMeta{Name: "b"}, Spec: bmcv1beta1.HostPolicySpec{Remediation: &bmcv1beta1.RemediationSpec{}}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "a"}, Spec: bmcv1beta1.HostPolicySpec{
+				Selector:    &metav1.LabelSelector{MatchLabels: map[string]string{"role": "db"}},
+				Remediation: &bmcv1beta1.RemediationSpec{},
+			}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "c"}, Spec: bmcv1beta1.HostPolicySpec{Protected: true}},
+		}
+		db := &bmcv1beta1.HostStatus{ObjectMeta: metav1.ObjectMeta{Name: "db1", Labels: map[string]string{"role": "db"}}}
+		web := &bmcv1beta1.HostStatus{ObjectMeta: metav1.ObjectMeta{Name: "web1"}}
+
+		spec, policy, err := hostpolicy.Remediation(policies, db)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(spec).NotTo(BeNil())
+		Expect(policy).To(Equal("a"))
+
+		_, policy, err = hostpolicy.Remediation(policies, web)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(policy).To(Equal("b"))
+
+		spec, _, err = hostpolicy.Remediation(policies[2:], web)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(spec).To(BeNil())
+	})
+})
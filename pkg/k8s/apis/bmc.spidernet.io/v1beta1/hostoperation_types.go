@@ -7,8 +7,28 @@ import (
 
 const (
 	HostOperationStatusPending = "pending"
+	// the BMC has accepted the action, and the agent is tracking the task of the BMC
+	HostOperationStatusRunning = "running"
 	HostOperationStatusSuccess = "success"
 	HostOperationStatusFailed  = "failed"
+	// the operation is cancelled by spec.cancel or the deletion before it finishes
+	HostOperationStatusCancelled = "cancelled"
+)
+
+const (
+	// wait for the operations ahead of it on the same host
+	HostOperationQueuePolicyQueue = "Queue"
+	// reject the operation when another operation of the same host is pending or running
+	HostOperationQueuePolicyReject = "Reject"
+
+	// HostOperationHostStatusNameIndex is the field index of HostOperations by spec.hostStatusName
+	HostOperationHostStatusNameIndex = "spec.hostStatusName"
+)
+
+const (
+	// HostOperationFinalizer is added by the agent while the operation is pending or running,
+	// so that the tracked task of the BMC can be cancelled when the HostOperation is deleted
+	HostOperationFinalizer = GroupName + "/hostoperation"
 )
 
 const (
@@ -28,6 +48,52 @@ const (
 	BootCmdGracefulRestart = string(redfish.GracefulRestartResetType)
 	// "PxeReboot"
 	BootCmdResetPxeOnce string = "PxeReboot"
+
+	// power capping
+	// set the power limit of the chassis with spec.powerLimit
+	ActionSetPowerLimit = "SetPowerLimit"
+	// remove the power limit of the chassis
+	ActionClearPowerLimit = "ClearPowerLimit"
+
+	// bmc
+	// gracefully restart the BMC
+	ActionBmcGracefulRestart = "BmcGracefulRestart"
+	// force restart the BMC
+	ActionBmcForceRestart = "BmcForceRestart"
+	// reset the BMC settings to factory defaults with spec.resetToDefaultsType
+	ActionBmcResetToDefaults = "BmcResetToDefaults"
+
+	// locate indicator
+	// light the indicator of the system and chassis
+	ActionLocateOn = "LocateOn"
+	// blink the indicator of the system and chassis
+	ActionLocateBlink = "LocateBlink"
+	// turn off the indicator of the system and chassis
+	ActionLocateOff = "LocateOff"
+)
+
+const (
+	// the host is not mapped to a node, nothing is drained
+	DrainPhaseSkipped = "Skipped"
+	// the node is cordoned, and the pods are being evicted
+	DrainPhaseDraining = "Draining"
+	// the pods are evicted, the action is performed
+	DrainPhaseDrained = "Drained"
+	// the action is done, waiting for the node to rejoin and become Ready
+	DrainPhaseWaitingForReady = "WaitingForReady"
+	// the node is uncordoned
+	DrainPhaseUncordoned = "Uncordoned"
+	// the node does not become Ready in time after the action, it is left cordoned
+	DrainPhaseTimedOut = "TimedOut"
+)
+
+const (
+	// reset all settings of the BMC to factory defaults
+	ResetToDefaultsAll = "ResetAll"
+	// reset all settings except network and local users to factory defaults
+	ResetToDefaultsPreserveNetworkAndUsers = "PreserveNetworkAndUsers"
+	// reset all settings except network to factory defaults
+	ResetToDefaultsPreserveNetwork = "PreserveNetwork"
 )
 
 // +genclient
@@ -49,16 +115,133 @@ type HostOperation struct {
 }
 
 type HostOperationSpec struct {
-	// +kubebuilder:validation:Enum=ForceOn;On;ForceOff;GracefulShutdown;ForceRestart;GracefulRestart;PxeReboot
+	HostOperationActionSpec `json:",inline"`
+
+	// +kubebuilder:validation:Required
+	HostStatusName string `json:"hostStatusName"`
+
+	// TTLSecondsAfterFinished is the time to keep the HostOperation after it finishes,
+	// then the controller records it in the operation history of the HostStatus and deletes it.
+	// the default of the controller applies when it is not set
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	TTLSecondsAfterFinished *int32 `json:"ttlSecondsAfterFinished,omitempty"`
+
+	// Cancel aborts the pending or running operation, and the tracked task of the BMC if the BMC supports it.
+	// it is the only field of the spec allowed to be modified
+	// +optional
+	Cancel bool `json:"cancel,omitempty"`
+
+	// QueuePolicy decides what happens when another operation of the same host is pending or running.
+	// the operations of a host are executed one by one in the order of creation
+	// +optional
+	// +kubebuilder:default=Queue
+	// +kubebuilder:validation:Enum=Queue;Reject
+	QueuePolicy string `json:"queuePolicy,omitempty"`
+
+	// Reason explains why the operation is requested, it is recorded in the events and the operation history
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// Requester is the user who creates the HostOperation, it is set by the webhook and could not be modified
+	// +optional
+	Requester *Requester `json:"requester,omitempty"`
+}
+
+// Requester is the identity of the user from the admission request
+type Requester struct {
+	Username string `json:"username"`
+	// +optional
+	Groups []string `json:"groups,omitempty"`
+}
+
+// HostOperationActionSpec defines the action applied to a host, it is shared by HostOperation and HostOperationSet
+type HostOperationActionSpec struct {
+	// +kubebuilder:validation:Enum=ForceOn;On;ForceOff;GracefulShutdown;ForceRestart;GracefulRestart;PxeReboot;SetPowerLimit;ClearPowerLimit;BmcGracefulRestart;BmcForceRestart;BmcResetToDefaults;LocateOn;LocateBlink;LocateOff
 	// +kubebuilder:validation:Required
 	Action string `json:"action"`
 
+	// PowerLimit is the power cap applied by the SetPowerLimit action
+	// +optional
+	PowerLimit *PowerLimitSpec `json:"powerLimit,omitempty"`
+
+	// ResetToDefaultsType is the type of the BmcResetToDefaults action, default to ResetAll
+	// +optional
+	// +kubebuilder:validation:Enum=ResetAll;PreserveNetworkAndUsers;PreserveNetwork
+	ResetToDefaultsType string `json:"resetToDefaultsType,omitempty"`
+
+	// LocateDurationMinutes is the duration of the LocateOn and LocateBlink action,
+	// the agent turns off the indicator automatically after it. The indicator keeps on when it is not set
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	LocateDurationMinutes *int32 `json:"locateDurationMinutes,omitempty"`
+
+	// TimeoutSeconds is the maximum time for the operation to finish, including the retries,
+	// the task of the BMC and the verification of the power state. no timeout when it is not set
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+
+	// Retry is the retry policy for the transient errors, such as the BMC is busy or the connection is reset
+	// +optional
+	Retry *RetryPolicy `json:"retry,omitempty"`
+
+	// DrainPolicy drains the Kubernetes Node running on the host before the GracefulShutdown, ForceRestart and PxeReboot action,
+	// and uncordons it once it rejoins and becomes Ready. The node is not drained when it is not set
+	// +optional
+	DrainPolicy *DrainPolicy `json:"drainPolicy,omitempty"`
+}
+
+// DrainPolicy defines how to drain the node before the action
+type DrainPolicy struct {
+	// TimeoutSeconds is the maximum time to evict the pods, the PodDisruptionBudgets are respected meanwhile
+	// +optional
+	// +kubebuilder:default=600
+	// +kubebuilder:validation:Minimum=1
+	TimeoutSeconds int32 `json:"timeoutSeconds,omitempty"`
+
+	// Force performs the action even though some pods are not evicted before the timeout,
+	// otherwise the operation fails and the node is uncordoned
+	// +optional
+	Force bool `json:"force,omitempty"`
+
+	// ReadyTimeoutSeconds is the maximum time to wait for the node to become Ready after the action,
+	// the node is left cordoned after it
+	// +optional
+	// +kubebuilder:default=1800
+	// +kubebuilder:validation:Minimum=1
+	ReadyTimeoutSeconds int32 `json:"readyTimeoutSeconds,omitempty"`
+}
+
+// RetryPolicy defines how to retry the action after a transient error
+type RetryPolicy struct {
+	// Limit is the maximum number of retries
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=10
+	Limit int32 `json:"limit"`
+
+	// BackoffSeconds is the delay before the first retry, it doubles for every retry, up to 300 seconds
+	// +optional
+	// +kubebuilder:default=10
+	// +kubebuilder:validation:Minimum=1
+	BackoffSeconds int32 `json:"backoffSeconds,omitempty"`
+}
+
+// PowerLimitSpec defines the power cap of the chassis
+type PowerLimitSpec struct {
+	// LimitInWatts is the power cap in watts
 	// +kubebuilder:validation:Required
-	HostStatusName string `json:"hostStatusName"`
+	// +kubebuilder:validation:Minimum=1
+	LimitInWatts int32 `json:"limitInWatts"`
+
+	// LimitException is the action taken by the BMC when the power cap is exceeded
+	// +optional
+	// +kubebuilder:validation:Enum=NoAction;HardPowerOff;LogEventOnly;Oem
+	LimitException string `json:"limitException,omitempty"`
 }
 
 type HostOperationStatus struct {
-	// +kubebuilder:validation:Enum=pending;success;failure
+	// +kubebuilder:validation:Enum=pending;running;success;failed;cancelled
 	Status string `json:"status,omitempty"`
 
 	Message string `json:"message,omitempty"`
@@ -68,6 +251,153 @@ type HostOperationStatus struct {
 	ClusterAgent string `json:"clusterAgent,omitempty"`
 
 	IpAddr string `json:"ipAddr,omitempty"`
+
+	// StartTime is the time when the agent starts to process the operation
+	// +optional
+	StartTime string `json:"startTime,omitempty"`
+
+	// Attempts is the number of attempts of the action
+	// +optional
+	Attempts int32 `json:"attempts,omitempty"`
+
+	// NextRetryTime is the time of the next attempt after a transient error
+	// +optional
+	NextRetryTime string `json:"nextRetryTime,omitempty"`
+
+	// QueuePosition is the number of the operations ahead of it on the same host, 0 when it is not waiting
+	// +optional
+	QueuePosition int32 `json:"queuePosition,omitempty"`
+
+	// LocateOffTime is the time when the agent turns off the indicator for a timed LocateOn or LocateBlink action
+	// +optional
+	LocateOffTime string `json:"locateOffTime,omitempty"`
+
+	// Task is the progress of the task created by the BMC for a long-running action
+	// +optional
+	Task *TaskStatus `json:"task,omitempty"`
+
+	// PowerVerification is the progress of verifying the power state of the host after a power action
+	// +optional
+	PowerVerification *PowerVerificationStatus `json:"powerVerification,omitempty"`
+
+	// Drain is the progress of draining the node before the action and uncordoning it after
+	// +optional
+	Drain *DrainStatus `json:"drain,omitempty"`
+}
+
+// DrainStatus records the drain of the node running on the host
+type DrainStatus struct {
+	// +kubebuilder:validation:Enum=Skipped;Draining;Drained;WaitingForReady;Uncordoned;TimedOut
+	Phase string `json:"phase"`
+
+	// +optional
+	NodeName string `json:"nodeName,omitempty"`
+
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// StartTime is the time when the node is cordoned
+	// +optional
+	StartTime string `json:"startTime,omitempty"`
+
+	// ActionTime is the time when the action is done, from which the agent waits for the node to become Ready
+	// +optional
+	ActionTime string `json:"actionTime,omitempty"`
+
+	// PendingPods is the number of the pods not evicted yet
+	// +optional
+	PendingPods int32 `json:"pendingPods,omitempty"`
+
+	// Pods are some of the pods not evicted yet
+	// +optional
+	Pods []string `json:"pods,omitempty"`
+
+	// BootID is the boot ID of the node before the action, a new one tells the node has restarted
+	// +optional
+	BootID string `json:"bootID,omitempty"`
+
+	// WasUnschedulable indicates the node was cordoned before the operation, so it is not uncordoned by the agent
+	// +optional
+	WasUnschedulable bool `json:"wasUnschedulable,omitempty"`
+}
+
+// PowerVerificationStatus records the power states observed after a power action
+type PowerVerificationStatus struct {
+	// TargetPowerState is the power state expected after the action, On or Off
+	TargetPowerState string `json:"targetPowerState"`
+
+	// RestartObserved indicates whether the host has been observed powering off or booting again, for the restart actions
+	// +optional
+	RestartObserved bool `json:"restartObserved,omitempty"`
+
+	// Unverified indicates the restart action finished without the restart observed, the BMC reports no boot progress
+	// and the host was not observed powering off, so it is unknown whether the BMC has performed the restart
+	// +optional
+	Unverified bool `json:"unverified,omitempty"`
+
+	// BootProgressUnsupported is recorded at the first sample of a restart action when the BMC reports no boot progress,
+	// the restart could then only be observed by a power off, and it is resolved as unverified after ObservationSeconds
+	// instead of waiting until the timeout
+	// +optional
+	BootProgressUnsupported bool `json:"bootProgressUnsupported,omitempty"`
+
+	// ObservationSeconds is the time to observe the restart on a BMC reporting no boot progress
+	// +optional
+	ObservationSeconds int32 `json:"observationSeconds,omitempty"`
+
+	// InitialBootProgressTime is the time of the last boot progress before the action
+	// +optional
+	InitialBootProgressTime string `json:"initialBootProgressTime,omitempty"`
+
+	// StartTime is the time when the agent starts to verify the power state
+	// +optional
+	StartTime string `json:"startTime,omitempty"`
+
+	// TimeoutSeconds is the time to wait for the target power state
+	// +optional
+	TimeoutSeconds int32 `json:"timeoutSeconds,omitempty"`
+
+	// Transitions are the power states observed by the agent, only the latest ones are kept
+	// +optional
+	Transitions []PowerStateRecord `json:"transitions,omitempty"`
+}
+
+// PowerStateRecord is the power state and boot progress of the host observed at a time
+type PowerStateRecord struct {
+	Time string `json:"time"`
+
+	PowerState string `json:"powerState"`
+
+	// +optional
+	BootProgress string `json:"bootProgress,omitempty"`
+
+	// +optional
+	BootProgressTime string `json:"bootProgressTime,omitempty"`
+}
+
+// TaskStatus is the progress of an asynchronous Redfish task
+type TaskStatus struct {
+	// MonitorURI is the task monitor returned by the BMC
+	MonitorURI string `json:"monitorURI"`
+
+	// TaskState is the state of the task, such as New, Running, Completed, Exception
+	// +optional
+	TaskState string `json:"taskState,omitempty"`
+
+	// TaskStatus is the health of the task once it completes, such as OK, Warning, Critical
+	// +optional
+	TaskStatus string `json:"taskStatus,omitempty"`
+
+	// +optional
+	PercentComplete int32 `json:"percentComplete,omitempty"`
+
+	// Messages are the messages reported by the task
+	// +optional
+	Messages []string `json:"messages,omitempty"`
+
+	// StartTime is the time when the agent starts to track the task
+	// +optional
+	StartTime string `json:"startTime,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -0,0 +1,138 @@
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// LabelHostOperationSchedule is set on the HostOperationSets created by a HostOperationSchedule,
+	// its value is LabelValue of the schedule name
+	LabelHostOperationSchedule = GroupName + "/hostoperationschedule"
+	// AnnotationHostOperationSchedule records the name of the HostOperationSchedule creating the object
+	AnnotationHostOperationSchedule = GroupName + "/hostoperationschedule"
+)
+
+const (
+	// run the operations concurrently
+	ConcurrencyPolicyAllow = "Allow"
+	// skip the new run if the previous one is still running
+	ConcurrencyPolicyForbid = "Forbid"
+	// delete the running one and start the new run
+	ConcurrencyPolicyReplace = "Replace"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="SCHEDULE",type="string",JSONPath=".spec.schedule"
+// +kubebuilder:printcolumn:name="TIMEZONE",type="string",JSONPath=".spec.timeZone"
+// +kubebuilder:printcolumn:name="ACTION",type="string",JSONPath=".spec.template.action"
+// +kubebuilder:printcolumn:name="SUSPEND",type="boolean",JSONPath=".spec.suspend"
+// +kubebuilder:printcolumn:name="LASTSCHEDULE",type="string",JSONPath=".status.lastScheduleTime"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+
+// HostOperationSchedule creates a HostOperationSet on schedule, like a CronJob
+type HostOperationSchedule struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   HostOperationScheduleSpec   `json:"spec,omitempty"`
+	Status HostOperationScheduleStatus `json:"status,omitempty"`
+}
+
+type HostOperationScheduleSpec struct {
+	// Schedule is the cron expression with 5 fields: minute hour day-of-month month day-of-week,
+	// or a macro such as @daily, @weekly, @monthly
+	// +kubebuilder:validation:Required
+	Schedule string `json:"schedule"`
+
+	// TimeZone is the IANA time zone of the schedule, such as Asia/Shanghai, default to UTC
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+
+	// StartingDeadlineSeconds is the deadline for starting a run which misses its scheduled time,
+	// such as the controller is down. the missed run is skipped after the deadline. no deadline when it is not set
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	StartingDeadlineSeconds *int64 `json:"startingDeadlineSeconds,omitempty"`
+
+	// ConcurrencyPolicy specifies how to treat a run when the previous one is still running
+	// +optional
+	// +kubebuilder:default=Allow
+	// +kubebuilder:validation:Enum=Allow;Forbid;Replace
+	ConcurrencyPolicy string `json:"concurrencyPolicy,omitempty"`
+
+	// Suspend stops scheduling new runs, the running ones are not affected
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+
+	// SuccessfulHistoryLimit is the number of succeeded HostOperationSets to keep
+	// +optional
+	// +kubebuilder:default=3
+	// +kubebuilder:validation:Minimum=0
+	SuccessfulHistoryLimit *int32 `json:"successfulHistoryLimit,omitempty"`
+
+	// FailedHistoryLimit is the number of failed HostOperationSets to keep
+	// +optional
+	// +kubebuilder:default=1
+	// +kubebuilder:validation:Minimum=0
+	FailedHistoryLimit *int32 `json:"failedHistoryLimit,omitempty"`
+
+	// Selector selects the HostStatus by labels
+	// +optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+
+	// HostStatusNames is the explicit list of HostStatus
+	// +optional
+	HostStatusNames []string `json:"hostStatusNames,omitempty"`
+
+	// Template is the action applied to every host
+	// +kubebuilder:validation:Required
+	Template HostOperationActionSpec `json:"template"`
+
+	// MaxConcurrent is the maximum number of hosts operated at the same time in a run
+	// +optional
+	// +kubebuilder:default=1
+	// +kubebuilder:validation:Minimum=1
+	MaxConcurrent int32 `json:"maxConcurrent,omitempty"`
+
+	// MaxFailures is the number of failed hosts tolerated in a run
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MaxFailures int32 `json:"maxFailures,omitempty"`
+
+	// Reason explains why the operation is requested, it is recorded in the HostOperations of the hosts
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// Requester is the user who creates the HostOperationSchedule, it is set by the webhook and could not be modified
+	// +optional
+	Requester *Requester `json:"requester,omitempty"`
+}
+
+type HostOperationScheduleStatus struct {
+	// LastScheduleTime is the scheduled time of the last run
+	// +optional
+	LastScheduleTime string `json:"lastScheduleTime,omitempty"`
+
+	// NextScheduleTime is the scheduled time of the next run
+	// +optional
+	NextScheduleTime string `json:"nextScheduleTime,omitempty"`
+
+	// Active is the names of the running HostOperationSets
+	// +optional
+	Active []string `json:"active,omitempty"`
+
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type HostOperationScheduleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []HostOperationSchedule `json:"items"`
+}
@@ -0,0 +1,154 @@
+package v1beta1
+
+import (
+	"fmt"
+	"hash/fnv"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+const (
+	// LabelHostOperationSet is set on the HostOperations created by a HostOperationSet, its value is LabelValue of the set name
+	LabelHostOperationSet = GroupName + "/hostoperationset"
+	// AnnotationHostOperationSet records the name of the HostOperationSet creating the HostOperation
+	AnnotationHostOperationSet = GroupName + "/hostoperationset"
+	// LabelHostStatusName is set on the HostOperations created for a host, its value is LabelValue of the HostStatus name
+	LabelHostStatusName = GroupName + "/hoststatus"
+)
+
+// LabelValue returns the label value referring to the object name. the name longer than
+// validation.LabelValueMaxLength is truncated with its hash, the exact name is kept in the annotation
+func LabelValue(name string) string {
+	if len(name) <= validation.LabelValueMaxLength {
+		return name
+	}
+	h := fnv.New32a()
+	h.Write([]byte(name))
+	return fmt.Sprintf("%s-%08x", name[:validation.LabelValueMaxLength-9], h.Sum32())
+}
+
+const (
+	HostOperationSetPhasePending   = "Pending"
+	HostOperationSetPhaseRunning   = "Running"
+	HostOperationSetPhasePaused    = "Paused"
+	HostOperationSetPhaseSucceeded = "Succeeded"
+	HostOperationSetPhaseFailed    = "Failed"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="ACTION",type="string",JSONPath=".spec.template.action"
+// +kubebuilder:printcolumn:name="PHASE",type="string",JSONPath=".status.phase"
+// +kubebuilder:printcolumn:name="TOTAL",type="integer",JSONPath=".status.total"
+// +kubebuilder:printcolumn:name="SUCCEEDED",type="integer",JSONPath=".status.succeeded"
+// +kubebuilder:printcolumn:name="FAILED",type="integer",JSONPath=".status.failed"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+
+// HostOperationSet runs one action across many hosts, by creating a HostOperation for each host
+type HostOperationSet struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   HostOperationSetSpec   `json:"spec,omitempty"`
+	Status HostOperationSetStatus `json:"status,omitempty"`
+}
+
+type HostOperationSetSpec struct {
+	// Selector selects the HostStatus by labels, such as bmc.spidernet.io/ipAddr, bmc.spidernet.io/mode and the labels of users
+	// +optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+
+	// HostStatusNames is the explicit list of HostStatus, it is merged with the hosts selected by the selector
+	// +optional
+	HostStatusNames []string `json:"hostStatusNames,omitempty"`
+
+	// Template is the action applied to every host
+	// +kubebuilder:validation:Required
+	Template HostOperationActionSpec `json:"template"`
+
+	// MaxConcurrent is the maximum number of hosts operated at the same time
+	// +optional
+	// +kubebuilder:default=1
+	// +kubebuilder:validation:Minimum=1
+	MaxConcurrent int32 `json:"maxConcurrent,omitempty"`
+
+	// MaxFailures is the number of failed hosts tolerated, no more host is operated once it is exceeded
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MaxFailures int32 `json:"maxFailures,omitempty"`
+
+	// PauseOnFailure pauses the set by setting spec.paused to true once a host fails
+	// +optional
+	PauseOnFailure bool `json:"pauseOnFailure,omitempty"`
+
+	// Paused stops operating more hosts, the running operations are not affected
+	// +optional
+	Paused bool `json:"paused,omitempty"`
+
+	// Reason explains why the operation is requested, it is recorded in the HostOperations of the hosts
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// Requester is the user who creates the HostOperationSet, it is set by the webhook and could not be modified
+	// +optional
+	Requester *Requester `json:"requester,omitempty"`
+}
+
+type HostOperationSetStatus struct {
+	// +kubebuilder:validation:Enum=Pending;Running;Paused;Succeeded;Failed
+	// +optional
+	Phase string `json:"phase,omitempty"`
+
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// +optional
+	Total int32 `json:"total,omitempty"`
+	// +optional
+	Running int32 `json:"running,omitempty"`
+	// +optional
+	Succeeded int32 `json:"succeeded,omitempty"`
+	// +optional
+	Failed int32 `json:"failed,omitempty"`
+
+	// PausedFailures is the number of failed hosts when the set is paused on failure,
+	// the set is paused again only when more hosts fail after it is resumed
+	// +optional
+	PausedFailures int32 `json:"pausedFailures,omitempty"`
+
+	// +optional
+	StartTime string `json:"startTime,omitempty"`
+	// +optional
+	CompletionTime string `json:"completionTime,omitempty"`
+
+	// Hosts is the result of each host, the hosts are resolved once when the set starts
+	// +optional
+	Hosts []HostOperationSetHostStatus `json:"hosts,omitempty"`
+}
+
+type HostOperationSetHostStatus struct {
+	HostStatusName string `json:"hostStatusName"`
+
+	// HostOperationName is the name of the HostOperation created for the host
+	// +optional
+	HostOperationName string `json:"hostOperationName,omitempty"`
+
+	// Status is the status of the HostOperation, empty when it is not created yet
+	// +optional
+	Status string `json:"status,omitempty"`
+
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type HostOperationSetList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []HostOperationSet `json:"items"`
+}
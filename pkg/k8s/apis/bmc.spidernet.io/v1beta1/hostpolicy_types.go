@@ -0,0 +1,135 @@
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// AnnotationOverridePolicy on a HostOperation or HostOperationSet allows the destructive actions denied by the HostPolicy,
+	// the value is the reason of the override and should not be empty
+	AnnotationOverridePolicy = GroupName + "/override-policy"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="PROTECTED",type="boolean",JSONPath=".spec.protected"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+
+// HostPolicy applies to the selected hosts. It restricts the destructive actions with Protected and MaintenanceWindows,
+// keeps the power state of the hosts with DesiredPower, sets the interval of polling their BMCs with PollIntervalSeconds,
+// and restarts the hosts whose nodes stay NotReady with Remediation
+type HostPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec HostPolicySpec `json:"spec,omitempty"`
+}
+
+type HostPolicySpec struct {
+	// Selector selects the HostStatus by labels.
+	// the policy applies to all hosts when neither selector nor hostStatusNames is set
+	// +optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+
+	// HostStatusNames is the explicit list of HostStatus
+	// +optional
+	HostStatusNames []string `json:"hostStatusNames,omitempty"`
+
+	// Protected denies the destructive actions on the hosts
+	// +optional
+	Protected bool `json:"protected,omitempty"`
+
+	// DestructiveActions is the actions restricted by the policy,
+	// default to ForceOff, ForceRestart, PxeReboot and BmcResetToDefaults
+	// +optional
+	// +kubebuilder:validation:items:Enum=ForceOn;On;ForceOff;GracefulShutdown;ForceRestart;GracefulRestart;PxeReboot;SetPowerLimit;ClearPowerLimit;BmcGracefulRestart;BmcForceRestart;BmcResetToDefaults;LocateOn;LocateBlink;LocateOff
+	DestructiveActions []string `json:"destructiveActions,omitempty"`
+
+	// MaintenanceWindows only allows the destructive actions during the windows.
+	// no restriction of time when it is empty
+	// +optional
+	MaintenanceWindows []MaintenanceWindow `json:"maintenanceWindows,omitempty"`
+
+	// DesiredPower keeps the power state of the hosts, the agent powers on or off the host when it is changed outside
+	// +optional
+	DesiredPower *DesiredPowerSpec `json:"desiredPower,omitempty"`
+
+	// PollIntervalSeconds is the interval of polling the BMC of the hosts, the shortest one applies when
+	// several policies select the same host
+	// +kubebuilder:validation:Minimum=10
+	// +optional
+	PollIntervalSeconds *int32 `json:"pollIntervalSeconds,omitempty"`
+
+	// Remediation restarts and then powers off the hosts whose nodes stay NotReady, through the BMC
+	// +optional
+	Remediation *RemediationSpec `json:"remediation,omitempty"`
+}
+
+// RemediationSpec defines when to remediate the NotReady node of the host
+type RemediationSpec struct {
+	// NotReadySeconds is the time the node stays NotReady before the remediation starts
+	// +optional
+	// +kubebuilder:default=300
+	// +kubebuilder:validation:Minimum=60
+	NotReadySeconds *int32 `json:"notReadySeconds,omitempty"`
+
+	// StepTimeoutSeconds is the time to wait for the node to become Ready after each step,
+	// before the next step: GracefulRestart, ForceRestart, then PowerOff
+	// +optional
+	// +kubebuilder:default=600
+	// +kubebuilder:validation:Minimum=60
+	StepTimeoutSeconds *int32 `json:"stepTimeoutSeconds,omitempty"`
+}
+
+const (
+	DesiredPowerStateOn        = "On"
+	DesiredPowerStateOff       = "Off"
+	DesiredPowerStateUnmanaged = "Unmanaged"
+)
+
+type DesiredPowerSpec struct {
+	// State is the desired power state, Unmanaged stops enforcing it
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Enum=On;Off;Unmanaged
+	State string `json:"state"`
+
+	// GracePeriodSeconds is the time the mismatch lasts before the agent acts on it, and the time between the attempts.
+	// it leaves time for the restart of the host
+	// +optional
+	// +kubebuilder:default=300
+	// +kubebuilder:validation:Minimum=0
+	GracePeriodSeconds *int32 `json:"gracePeriodSeconds,omitempty"`
+
+	// MaxAttempts is the maximum number of the power actions before the host reaches the desired state,
+	// then the agent gives up until the host reaches the desired state again
+	// +optional
+	// +kubebuilder:default=3
+	// +kubebuilder:validation:Minimum=1
+	MaxAttempts *int32 `json:"maxAttempts,omitempty"`
+}
+
+// MaintenanceWindow is a recurring period of time
+type MaintenanceWindow struct {
+	// Schedule is the cron expression of the start of the window, with 5 fields: minute hour day-of-month month day-of-week
+	// +kubebuilder:validation:Required
+	Schedule string `json:"schedule"`
+
+	// DurationMinutes is the length of the window
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Minimum=1
+	DurationMinutes int32 `json:"durationMinutes"`
+
+	// TimeZone is the IANA time zone of the schedule, such as Asia/Shanghai, default to UTC
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type HostPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []HostPolicy `json:"items"`
+}
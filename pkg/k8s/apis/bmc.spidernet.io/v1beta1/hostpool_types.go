@@ -0,0 +1,115 @@
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// LabelHostPool is set on the HostOperations created by a HostPool, its value is LabelValue of the pool name
+	LabelHostPool = GroupName + "/hostpool"
+	// AnnotationHostPool records the name of the HostPool creating the HostOperation
+	AnnotationHostPool = GroupName + "/hostpool"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:subresource:status
+// +kubebuilder:subresource:scale:specpath=.spec.replicas,statuspath=.status.replicas,selectorpath=.status.selector
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="DESIRED",type="integer",JSONPath=".spec.replicas"
+// +kubebuilder:printcolumn:name="ON",type="integer",JSONPath=".status.replicas"
+// +kubebuilder:printcolumn:name="TOTAL",type="integer",JSONPath=".status.total"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+
+// HostPool keeps the desired number of its hosts powered on, the spare hosts are powered off.
+// the scale subresource allows the autoscalers to request powering on or off the hosts by changing spec.replicas
+type HostPool struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   HostPoolSpec   `json:"spec,omitempty"`
+	Status HostPoolStatus `json:"status,omitempty"`
+}
+
+type HostPoolSpec struct {
+	// Selector selects the HostStatus of the pool by labels, a host should not belong to more than one pool
+	// +kubebuilder:validation:Required
+	Selector *metav1.LabelSelector `json:"selector"`
+
+	// Replicas is the number of the hosts kept powered on. the hosts are not powered on or off when it is not set
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// MinOnSeconds is the minimum time a host stays on before it is powered off by the pool
+	// +optional
+	// +kubebuilder:default=600
+	// +kubebuilder:validation:Minimum=0
+	MinOnSeconds int32 `json:"minOnSeconds,omitempty"`
+
+	// CooldownSeconds is the minimum time between two scalings of the pool
+	// +optional
+	// +kubebuilder:default=300
+	// +kubebuilder:validation:Minimum=0
+	CooldownSeconds int32 `json:"cooldownSeconds,omitempty"`
+
+	// DrainPolicy drains the node of the host before it is powered off. the node of a host is always drained, with the
+	// default drain policy when it is not set, so a host whose pods could not be evicted is not powered off. the hosts
+	// without a node are powered off without draining
+	// +optional
+	DrainPolicy *DrainPolicy `json:"drainPolicy,omitempty"`
+}
+
+type HostPoolStatus struct {
+	// Replicas is the number of the hosts powered on, including the ones being powered on
+	// +optional
+	Replicas int32 `json:"replicas,omitempty"`
+
+	// Total is the number of the hosts in the pool
+	// +optional
+	Total int32 `json:"total,omitempty"`
+
+	// Selector is the selector of the pool in the string form, for the scale subresource
+	// +optional
+	Selector string `json:"selector,omitempty"`
+
+	// LastScaleTime is the time when the pool powered on or off hosts last time
+	// +optional
+	LastScaleTime string `json:"lastScaleTime,omitempty"`
+
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// Hosts are the hosts of the pool
+	// +optional
+	Hosts []HostPoolMember `json:"hosts,omitempty"`
+}
+
+// HostPoolMember is the power state of a host of the pool
+type HostPoolMember struct {
+	Name string `json:"name"`
+
+	// PowerState is the power state reported by the BMC, it is empty when the BMC is unreachable
+	// +optional
+	PowerState string `json:"powerState,omitempty"`
+
+	// +optional
+	NodeName string `json:"nodeName,omitempty"`
+
+	// PowerOnTime is the time when the host is observed on by the pool
+	// +optional
+	PowerOnTime string `json:"powerOnTime,omitempty"`
+
+	// HostOperation is the operation powering on or off the host
+	// +optional
+	HostOperation string `json:"hostOperation,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type HostPoolList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []HostPool `json:"items"`
+}
@@ -0,0 +1,103 @@
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// the steps of the remediation of a NotReady node, one after another until the node becomes Ready
+	RemediationStepGracefulRestart = "GracefulRestart"
+	RemediationStepForceRestart    = "ForceRestart"
+	RemediationStepPowerOff        = "PowerOff"
+	// the host is powered off and the node is tainted out-of-service, until the node becomes Ready again
+	RemediationStepPoweredOff = "PoweredOff"
+	// all the steps failed, until the node becomes Ready again
+	RemediationStepFailed = "Failed"
+
+	// LabelRemediation is set on the HostOperations created by the remediation, its value is LabelValue of the HostStatus name
+	LabelRemediation = GroupName + "/remediation"
+
+	// the conditions of the HostRemediation defined by the external remediation contract of NodeHealthCheck
+	HostRemediationConditionProcessing = "Processing"
+	HostRemediationConditionSucceeded  = "Succeeded"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="HOSTSTATUS",type="string",JSONPath=".status.hostStatusName"
+// +kubebuilder:printcolumn:name="STEP",type="string",JSONPath=".status.step"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+
+// HostRemediation requests the remediation of the node with the same name, regardless of the HostPolicy.
+// it is created from a HostRemediationTemplate by NodeHealthCheck, and deleted once the node is healthy
+type HostRemediation struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   HostRemediationSpec   `json:"spec,omitempty"`
+	Status HostRemediationStatus `json:"status,omitempty"`
+}
+
+type HostRemediationSpec struct {
+	// StepTimeoutSeconds is the time to wait for the node to become Ready after each step,
+	// it overrides the one of the HostPolicy
+	// +optional
+	// +kubebuilder:validation:Minimum=60
+	StepTimeoutSeconds *int32 `json:"stepTimeoutSeconds,omitempty"`
+}
+
+type HostRemediationStatus struct {
+	// HostStatusName is the HostStatus of the host running the node
+	// +optional
+	HostStatusName string `json:"hostStatusName,omitempty"`
+
+	// Step is the current step of the remediation
+	// +optional
+	Step string `json:"step,omitempty"`
+
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// Conditions are Processing and Succeeded
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type HostRemediationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []HostRemediation `json:"items"`
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+
+// HostRemediationTemplate is referred by the remediationTemplate of NodeHealthCheck, which creates HostRemediations from it
+type HostRemediationTemplate struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec HostRemediationTemplateSpec `json:"spec,omitempty"`
+}
+
+type HostRemediationTemplateSpec struct {
+	Template HostRemediationTemplateResource `json:"template"`
+}
+
+type HostRemediationTemplateResource struct {
+	Spec HostRemediationSpec `json:"spec"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type HostRemediationTemplateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []HostRemediationTemplate `json:"items"`
+}
@@ -8,6 +8,69 @@ const (
 	LabelIPAddr       = GroupName + "/ipAddr"
 	LabelClientMode   = GroupName + "/mode"
 	LabelClientActive = GroupName + "/dhcp-ip-active"
+
+	// AnnotationPowerLimitWatts declares the power cap of the host, the agent keeps the BMC in line with it.
+	// the value is the power cap in watts, or "none" to make sure no power cap is set
+	AnnotationPowerLimitWatts = GroupName + "/power-limit-watts"
+	PowerLimitNone            = "none"
+
+	// AnnotationPauseDesiredPower stops enforcing the desired power state of the HostPolicy on the host when it is "true"
+	AnnotationPauseDesiredPower = GroupName + "/pause-desired-power"
+
+	// AnnotationPollIntervalSeconds overrides the interval in seconds of polling the BMC of the host,
+	// it takes precedence over the HostPolicy and the interval of the agent
+	AnnotationPollIntervalSeconds = GroupName + "/poll-interval-seconds"
+
+	// AnnotationRefreshInventory requests the agent to collect the inventory of the host at once,
+	// each new value of it requests one collection, such as a timestamp
+	AnnotationRefreshInventory = GroupName + "/refresh-inventory"
+
+	// AnnotationHostStatus is set on the Node which runs on the host, its value is the name of the HostStatus
+	AnnotationHostStatus = GroupName + "/hoststatus"
+
+	// AnnotationNodeMacAddresses is set on the Node by the provisioning, its value is the comma-separated MAC addresses
+	// of the NICs of the node. The Node is mapped to the HostStatus by them when the system UUID does not match
+	AnnotationNodeMacAddresses = GroupName + "/mac-addresses"
+)
+
+const (
+	// condition types of the HostStatus
+	// the BMC answers the redfish requests
+	HostStatusConditionReachable = "Reachable"
+	// the BMC accepts the credentials
+	HostStatusConditionAuthenticated = "Authenticated"
+	// the agent collects the inventory of the host
+	HostStatusConditionInventoryCollected = "InventoryCollected"
+	// the agent collects the logs of the BMC
+	HostStatusConditionLogsCollected = "LogsCollected"
+	// the system is powered on
+	HostStatusConditionPowerOn = "PowerOn"
+	// another hostStatus reports the same system UUID, chassis serial or BMC MAC
+	HostStatusConditionDuplicated = "Duplicated"
+)
+
+const (
+	// condition reasons of the HostStatus
+	HostStatusReasonSucceeded = "Succeeded"
+	// the BMC is not reachable, such as the connection is refused or times out
+	HostStatusReasonNetworkUnreachable = "NetworkUnreachable"
+	// the TLS handshake with the BMC fails
+	HostStatusReasonTLSError = "TLSError"
+	// the BMC refuses the credentials
+	HostStatusReasonUnauthorized = "Unauthorized"
+	// the response of the BMC does not match the redfish schema
+	HostStatusReasonSchemaError = "SchemaError"
+	// the BMC answers with an error
+	HostStatusReasonRequestFailed = "RequestFailed"
+	// the check is skipped because a previous check fails
+	HostStatusReasonNotConnected = "NotConnected"
+	HostStatusReasonPowerOn      = "PowerOn"
+	HostStatusReasonPowerOff     = "PowerOff"
+	// the BMC does not report the power state
+	HostStatusReasonPowerUnknown = "PowerStateUnknown"
+	// another hostStatus reports the same identity
+	HostStatusReasonDuplicateIdentity = "DuplicateIdentity"
+	HostStatusReasonUniqueIdentity    = "UniqueIdentity"
 )
 
 // +genclient
@@ -19,6 +82,7 @@ const (
 // +kubebuilder:printcolumn:name="HEALTHY",type="boolean",JSONPath=".status.healthy"
 // +kubebuilder:printcolumn:name="IPADDR",type="string",JSONPath=".status.basic.ipAddr"
 // +kubebuilder:printcolumn:name="TYPE",type="string",JSONPath=".status.basic.type"
+// +kubebuilder:printcolumn:name="NODE",type="string",JSONPath=".status.nodeName"
 // +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
 
 type HostStatus struct {
@@ -35,6 +99,269 @@ type HostStatusStatus struct {
 	Basic          BasicInfo         `json:"basic"`
 	Info           map[string]string `json:"info"`
 	Log            LogStruct         `json:"log"`
+	// Power records the power consumption and power cap of the host
+	// +optional
+	Power *PowerStatus `json:"power,omitempty"`
+	// BmcResettingUntil is the time until which the BMC is expected to be offline after a BMC reset,
+	// the host is not marked as unhealthy when the BMC is unreachable before that time
+	// +optional
+	BmcResettingUntil string `json:"bmcResettingUntil,omitempty"`
+	// Location is the physical location of the host reported by the chassis
+	// +optional
+	Location *LocationStatus `json:"location,omitempty"`
+	// OperationHistory is the records of the latest finished HostOperations, the newest is the last
+	// +optional
+	OperationHistory []OperationRecord `json:"operationHistory,omitempty"`
+	// DesiredPower is the enforcement of the desired power state declared by the HostPolicy
+	// +optional
+	DesiredPower *DesiredPowerStatus `json:"desiredPower,omitempty"`
+	// Capabilities is what the BMC advertises, the webhook refuses the HostOperations which are not supported
+	// +optional
+	Capabilities *CapabilitiesStatus `json:"capabilities,omitempty"`
+	// Inventory is the collection of the slow-changing inventory in status.info
+	// +optional
+	Inventory *InventoryStatus `json:"inventory,omitempty"`
+	// Identity is what identifies the machine regardless of its IP
+	// +optional
+	Identity *IdentityStatus `json:"identity,omitempty"`
+	// NodeName is the Kubernetes Node running on the host, which is matched by the system UUID or the MAC addresses of the NICs
+	// +optional
+	NodeName string `json:"nodeName,omitempty"`
+	// Remediation is the progress of remediating the NotReady node through the BMC
+	// +optional
+	Remediation *RemediationStatus `json:"remediation,omitempty"`
+	// Hardware is the hardware components of the last inventory collection, identified by their location.
+	// it is kept while the BMC is unreachable, so that the components swapped meanwhile are detected
+	// +optional
+	Hardware []HardwareComponent `json:"hardware,omitempty"`
+	// HardwareChanges is the latest changes of the hardware components, the newest is the last
+	// +optional
+	HardwareChanges []HardwareChange `json:"hardwareChanges,omitempty"`
+	// Polling is the schedule of polling the BMC, the interval backs off while the BMC is not healthy
+	// +optional
+	Polling *PollingStatus `json:"polling,omitempty"`
+	// Conditions is the detail of the health, such as Reachable, Authenticated, InventoryCollected, LogsCollected and PowerOn
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+type InventoryStatus struct {
+	// LastCollectTime is the time of the last collection of the inventory
+	LastCollectTime string `json:"lastCollectTime"`
+	// RefreshRequest is the value of the annotation bmc.spidernet.io/refresh-inventory handled by the last collection
+	// +optional
+	RefreshRequest string `json:"refreshRequest,omitempty"`
+}
+
+const (
+	// types of the hardware components
+	HardwareTypeBIOS       = "BIOS"
+	HardwareTypeBMC        = "BMC"
+	HardwareTypeCPU        = "CPU"
+	HardwareTypeMemory     = "Memory"
+	HardwareTypeDisk       = "Disk"
+	HardwareTypePCIeDevice = "PCIeDevice"
+)
+
+const (
+	// changes of the hardware components
+	HardwareChangeAdded           = "Added"
+	HardwareChangeRemoved         = "Removed"
+	HardwareChangeReplaced        = "Replaced"
+	HardwareChangeFirmwareChanged = "FirmwareChanged"
+)
+
+// RemediationStatus records the remediation of the node running on the host
+type RemediationStatus struct {
+	// +kubebuilder:validation:Enum=GracefulRestart;ForceRestart;PowerOff;PoweredOff;Failed
+	Step string `json:"step"`
+
+	// Reason is why the remediation starts, the NotReady node or the HostRemediation
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// StartTime is the time when the remediation starts
+	// +optional
+	StartTime string `json:"startTime,omitempty"`
+
+	// StepStartTime is the time when the current step starts
+	// +optional
+	StepStartTime string `json:"stepStartTime,omitempty"`
+
+	// StepTimeoutSeconds is the time to wait for the node to become Ready after each step
+	// +optional
+	StepTimeoutSeconds int32 `json:"stepTimeoutSeconds,omitempty"`
+
+	// HostOperation is the operation of the current step
+	// +optional
+	HostOperation string `json:"hostOperation,omitempty"`
+
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+type IdentityStatus struct {
+	// SystemUUID is the UUID of the computer system
+	// +optional
+	SystemUUID string `json:"systemUUID,omitempty"`
+	// ChassisSerial is the serial number of the chassis
+	// +optional
+	ChassisSerial string `json:"chassisSerial,omitempty"`
+	// BmcMac is the MAC address of the network interface of the BMC
+	// +optional
+	BmcMac string `json:"bmcMac,omitempty"`
+	// HostMacs is the MAC addresses of the network interfaces of the computer system
+	// +optional
+	HostMacs []string `json:"hostMacs,omitempty"`
+}
+
+type HardwareComponent struct {
+	// Type is the type of the component, such as CPU, Memory, Disk and PCIeDevice
+	Type string `json:"type"`
+	// Location is the slot of the component, such as the socket of the CPU and the locator of the DIMM
+	Location string `json:"location"`
+	// +optional
+	SerialNumber string `json:"serialNumber,omitempty"`
+	// +optional
+	Model string `json:"model,omitempty"`
+	// +optional
+	Manufacturer string `json:"manufacturer,omitempty"`
+	// +optional
+	FirmwareVersion string `json:"firmwareVersion,omitempty"`
+}
+
+type HardwareChange struct {
+	// Time is when the change is detected
+	Time string `json:"time"`
+	// Type is the type of the component
+	Type string `json:"type"`
+	// Location is the slot of the component
+	Location string `json:"location"`
+	// Change is Added, Removed, Replaced or FirmwareChanged
+	Change string `json:"change"`
+	// Message describes the change, such as the serial numbers of the replaced component
+	Message string `json:"message"`
+}
+
+type PollingStatus struct {
+	// IntervalSeconds is the interval of polling the healthy BMC, which may be overridden by the annotation or the HostPolicy
+	IntervalSeconds int32 `json:"intervalSeconds"`
+	// ConsecutiveFailures is the number of the polls failing in a row
+	ConsecutiveFailures int32 `json:"consecutiveFailures"`
+	// NextPollTime is the time of the next poll. it is only set while the BMC is backed off,
+	// the healthy BMC is polled every IntervalSeconds and the status is not rewritten at every poll
+	// +optional
+	NextPollTime string `json:"nextPollTime,omitempty"`
+}
+
+type CapabilitiesStatus struct {
+	// ResetTypes is the reset types supported by the system, such as On, ForceOff and GracefulShutdown
+	// +optional
+	ResetTypes []string `json:"resetTypes,omitempty"`
+	// BootSourceOverrideTargets is the allowed boot targets, such as Pxe, Cd and Hdd
+	// +optional
+	BootSourceOverrideTargets []string `json:"bootSourceOverrideTargets,omitempty"`
+	// BmcResetTypes is the reset types supported by the BMC
+	// +optional
+	BmcResetTypes []string `json:"bmcResetTypes,omitempty"`
+	// BmcResetToDefaults is whether the BMC supports resetting to factory defaults
+	BmcResetToDefaults bool `json:"bmcResetToDefaults"`
+	// PowerLimit is whether the chassis supports the power cap
+	PowerLimit bool `json:"powerLimit"`
+	// LocateIndicator is whether the system or chassis has a locate indicator
+	LocateIndicator bool `json:"locateIndicator"`
+}
+
+type DesiredPowerStatus struct {
+	// State is the desired power state
+	State string `json:"state"`
+	// Policy is the HostPolicy which declares the desired power state
+	Policy string `json:"policy"`
+	// MismatchSince is the time when the power state of the host is found different from the desired one
+	// +optional
+	MismatchSince string `json:"mismatchSince,omitempty"`
+	// Attempts is the number of the power actions made since the mismatch
+	// +optional
+	Attempts int32 `json:"attempts,omitempty"`
+	// +optional
+	LastAttemptTime string `json:"lastAttemptTime,omitempty"`
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// OperationRecord is the compact record of a finished HostOperation
+type OperationRecord struct {
+	Name   string `json:"name"`
+	Action string `json:"action"`
+	// Requester is the user who requests the HostOperation
+	// +optional
+	Requester string `json:"requester,omitempty"`
+	// +optional
+	Reason string `json:"reason,omitempty"`
+	// Owner is the object which creates the HostOperation, such as a HostOperationSet, in the form of Kind/Name
+	// +optional
+	Owner string `json:"owner,omitempty"`
+	// Result is the final status of the HostOperation: success, failed or cancelled
+	Result string `json:"result"`
+	// +optional
+	Message      string `json:"message,omitempty"`
+	CreationTime string `json:"creationTime"`
+	// +optional
+	FinishTime string `json:"finishTime,omitempty"`
+}
+
+type LocationStatus struct {
+	// +optional
+	Building string `json:"building,omitempty"`
+	// +optional
+	Room string `json:"room,omitempty"`
+	// +optional
+	Row string `json:"row,omitempty"`
+	// +optional
+	Rack string `json:"rack,omitempty"`
+	// RackOffset is the vertical location in the rack, measured from bottom to top starting with 0
+	// +optional
+	RackOffset *int32 `json:"rackOffset,omitempty"`
+	// RackOffsetUnits is the type of the rack units, such as OpenU or EIA_310
+	// +optional
+	RackOffsetUnits string `json:"rackOffsetUnits,omitempty"`
+	// Slot is the label of the slot in the enclosure
+	// +optional
+	Slot string `json:"slot,omitempty"`
+	// Info is the free-form location description of the chassis
+	// +optional
+	Info string `json:"info,omitempty"`
+	// IndicatorLED is the state of the locate indicator: Lit, Blinking or Off
+	// +optional
+	IndicatorLED string `json:"indicatorLED,omitempty"`
+}
+
+type PowerStatus struct {
+	// ConsumedWatts is the current power consumption
+	ConsumedWatts int32 `json:"consumedWatts"`
+	// CapacityWatts is the total power capacity that can be allocated
+	// +optional
+	CapacityWatts int32 `json:"capacityWatts,omitempty"`
+	// AverageConsumedWatts is the average power consumption over the metric interval
+	// +optional
+	AverageConsumedWatts int32 `json:"averageConsumedWatts,omitempty"`
+	// MinConsumedWatts is the lowest power consumption over the metric interval
+	// +optional
+	MinConsumedWatts int32 `json:"minConsumedWatts,omitempty"`
+	// MaxConsumedWatts is the highest power consumption over the metric interval
+	// +optional
+	MaxConsumedWatts int32 `json:"maxConsumedWatts,omitempty"`
+	// IntervalInMin is the time interval over which the power metrics are measured
+	// +optional
+	IntervalInMin int32 `json:"intervalInMin,omitempty"`
+	// LimitInWatts is the power cap, it is empty when no power cap is set
+	// +optional
+	LimitInWatts *int32 `json:"limitInWatts,omitempty"`
+	// LimitException is the action taken by the BMC when the power cap is exceeded
+	// +optional
+	LimitException string `json:"limitException,omitempty"`
 }
 
 type LogStruct struct {
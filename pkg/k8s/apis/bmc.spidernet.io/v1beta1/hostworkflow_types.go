@@ -0,0 +1,186 @@
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// LabelHostWorkflow is set on the HostOperations created by a HostWorkflow, its value is LabelValue of the workflow name
+	LabelHostWorkflow = GroupName + "/hostworkflow"
+)
+
+const (
+	HostWorkflowPhasePending   = "Pending"
+	HostWorkflowPhaseRunning   = "Running"
+	HostWorkflowPhaseSucceeded = "Succeeded"
+	HostWorkflowPhaseFailed    = "Failed"
+)
+
+const (
+	WorkflowStepPhasePending   = "Pending"
+	WorkflowStepPhaseRunning   = "Running"
+	WorkflowStepPhaseSucceeded = "Succeeded"
+	WorkflowStepPhaseFailed    = "Failed"
+)
+
+const (
+	// stop the workflow when the step fails
+	StepOnFailureAbort = "Abort"
+	// ignore the failure and go on with the next step
+	StepOnFailureContinue = "Continue"
+	// run the rollback operation of the step, then stop the workflow
+	StepOnFailureRollback = "Rollback"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="HOST",type="string",JSONPath=".spec.hostStatusName"
+// +kubebuilder:printcolumn:name="PHASE",type="string",JSONPath=".status.phase"
+// +kubebuilder:printcolumn:name="STEP",type="string",JSONPath=".status.currentStep"
+// +kubebuilder:printcolumn:name="CLUSTERAGENT",type="string",JSONPath=".status.clusterAgent"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+
+// HostWorkflow runs ordered steps on a host, it is executed by the agent which owns the host
+type HostWorkflow struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   HostWorkflowSpec   `json:"spec,omitempty"`
+	Status HostWorkflowStatus `json:"status,omitempty"`
+}
+
+type HostWorkflowSpec struct {
+	// +kubebuilder:validation:Required
+	HostStatusName string `json:"hostStatusName"`
+
+	// Steps are executed one by one in order
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	Steps []HostWorkflowStep `json:"steps"`
+
+	// Reason explains why the workflow is requested, it is recorded in the HostOperations of the steps
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// Requester is the user who creates the HostWorkflow, it is set by the webhook and could not be modified
+	// +optional
+	Requester *Requester `json:"requester,omitempty"`
+}
+
+// HostWorkflowStep is one of: an operation, a wait for the power state, a delay, or a wait for a Redfish property
+type HostWorkflowStep struct {
+	// Name is unique in the workflow
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Operation is executed by a HostOperation created for the step
+	// +optional
+	Operation *HostOperationActionSpec `json:"operation,omitempty"`
+
+	// WaitPowerState waits for the power state of the host
+	// +optional
+	// +kubebuilder:validation:Enum=On;Off
+	WaitPowerState string `json:"waitPowerState,omitempty"`
+
+	// DelaySeconds waits for a while
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	DelaySeconds *int32 `json:"delaySeconds,omitempty"`
+
+	// WaitProperty waits for a property of a Redfish resource to match the value
+	// +optional
+	WaitProperty *WaitPropertySpec `json:"waitProperty,omitempty"`
+
+	// TimeoutSeconds is the maximum time of the step, the webhook sets 600 seconds for the waits by default
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+
+	// OnFailure decides what to do when the step fails or times out
+	// +optional
+	// +kubebuilder:default=Abort
+	// +kubebuilder:validation:Enum=Abort;Continue;Rollback
+	OnFailure string `json:"onFailure,omitempty"`
+
+	// Rollback is the operation executed when the step fails with the Rollback strategy
+	// +optional
+	Rollback *HostOperationActionSpec `json:"rollback,omitempty"`
+}
+
+type WaitPropertySpec struct {
+	// URI is the path of the Redfish resource, such as /redfish/v1/Systems/1
+	// +kubebuilder:validation:Required
+	URI string `json:"uri"`
+
+	// Property is the path of the property separated by dots, such as Boot.BootSourceOverrideTarget
+	// +kubebuilder:validation:Required
+	Property string `json:"property"`
+
+	// Value is the expected value, numbers and booleans are compared in their text form
+	// +kubebuilder:validation:Required
+	Value string `json:"value"`
+}
+
+type HostWorkflowStatus struct {
+	// +optional
+	// +kubebuilder:validation:Enum=Pending;Running;Succeeded;Failed
+	Phase string `json:"phase,omitempty"`
+
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// CurrentStep is the index of the running step
+	// +optional
+	CurrentStep int32 `json:"currentStep,omitempty"`
+
+	// +optional
+	ClusterAgent string `json:"clusterAgent,omitempty"`
+
+	// +optional
+	StartTime string `json:"startTime,omitempty"`
+
+	// +optional
+	CompletionTime string `json:"completionTime,omitempty"`
+
+	// +optional
+	Steps []HostWorkflowStepStatus `json:"steps,omitempty"`
+}
+
+type HostWorkflowStepStatus struct {
+	Name string `json:"name"`
+
+	// +kubebuilder:validation:Enum=Pending;Running;Succeeded;Failed
+	Phase string `json:"phase"`
+
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// +optional
+	StartTime string `json:"startTime,omitempty"`
+
+	// +optional
+	FinishTime string `json:"finishTime,omitempty"`
+
+	// HostOperationName is the HostOperation created for the operation of the step
+	// +optional
+	HostOperationName string `json:"hostOperationName,omitempty"`
+
+	// RollbackHostOperationName is the HostOperation created for the rollback of the step
+	// +optional
+	RollbackHostOperationName string `json:"rollbackHostOperationName,omitempty"`
+
+	// RollbackStatus is the final status of the rollback operation
+	// +optional
+	RollbackStatus string `json:"rollbackStatus,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type HostWorkflowList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []HostWorkflow `json:"items"`
+}
@@ -29,6 +29,20 @@ const (
 	KindClusterAgent = "ClusterAgent"
 	// KindHostOperation is the kind name for HostOperation resource
 	KindHostOperation = "HostOperation"
+	// KindHostOperationSet is the kind name for HostOperationSet resource
+	KindHostOperationSet = "HostOperationSet"
+	// KindHostOperationSchedule is the kind name for HostOperationSchedule resource
+	KindHostOperationSchedule = "HostOperationSchedule"
+	// KindHostPolicy is the kind name for HostPolicy resource
+	KindHostPolicy = "HostPolicy"
+	// KindHostWorkflow is the kind name for HostWorkflow resource
+	KindHostWorkflow = "HostWorkflow"
+	// KindHostRemediation is the kind name for HostRemediation resource
+	KindHostRemediation = "HostRemediation"
+	// KindHostRemediationTemplate is the kind name for HostRemediationTemplate resource
+	KindHostRemediationTemplate = "HostRemediationTemplate"
+	// KindHostPool is the kind name for HostPool resource
+	KindHostPool = "HostPool"
 )
 
 var SchemeGroupVersion = schema.GroupVersion{Group: GroupName, Version: Version}
@@ -58,4 +72,11 @@ func init() {
 	SchemeBuilder.Register(&HostEndpoint{}, &HostEndpointList{})
 	SchemeBuilder.Register(&HostStatus{}, &HostStatusList{})
 	SchemeBuilder.Register(&HostOperation{}, &HostOperationList{})
+	SchemeBuilder.Register(&HostOperationSet{}, &HostOperationSetList{})
+	SchemeBuilder.Register(&HostOperationSchedule{}, &HostOperationScheduleList{})
+	SchemeBuilder.Register(&HostPolicy{}, &HostPolicyList{})
+	SchemeBuilder.Register(&HostWorkflow{}, &HostWorkflowList{})
+	SchemeBuilder.Register(&HostRemediation{}, &HostRemediationList{})
+	SchemeBuilder.Register(&HostRemediationTemplate{}, &HostRemediationTemplateList{})
+	SchemeBuilder.Register(&HostPool{}, &HostPoolList{})
 }
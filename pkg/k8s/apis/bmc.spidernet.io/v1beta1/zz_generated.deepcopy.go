@@ -6,6 +6,7 @@ package v1beta1
 
 import (
 	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -49,6 +50,36 @@ func (in *BasicInfo) DeepCopy() *BasicInfo {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CapabilitiesStatus) DeepCopyInto(out *CapabilitiesStatus) {
+	*out = *in
+	if in.ResetTypes != nil {
+		in, out := &in.ResetTypes, &out.ResetTypes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.BootSourceOverrideTargets != nil {
+		in, out := &in.BootSourceOverrideTargets, &out.BootSourceOverrideTargets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.BmcResetTypes != nil {
+		in, out := &in.BmcResetTypes, &out.BmcResetTypes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CapabilitiesStatus.
+func (in *CapabilitiesStatus) DeepCopy() *CapabilitiesStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CapabilitiesStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterAgent) DeepCopyInto(out *ClusterAgent) {
 	*out = *in
@@ -149,6 +180,46 @@ func (in *ClusterAgentStatus) DeepCopy() *ClusterAgentStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DesiredPowerSpec) DeepCopyInto(out *DesiredPowerSpec) {
+	*out = *in
+	if in.GracePeriodSeconds != nil {
+		in, out := &in.GracePeriodSeconds, &out.GracePeriodSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxAttempts != nil {
+		in, out := &in.MaxAttempts, &out.MaxAttempts
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesiredPowerSpec.
+func (in *DesiredPowerSpec) DeepCopy() *DesiredPowerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DesiredPowerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DesiredPowerStatus) DeepCopyInto(out *DesiredPowerStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesiredPowerStatus.
+func (in *DesiredPowerStatus) DeepCopy() *DesiredPowerStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DesiredPowerStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DhcpServerConfig) DeepCopyInto(out *DhcpServerConfig) {
 	*out = *in
@@ -164,6 +235,41 @@ func (in *DhcpServerConfig) DeepCopy() *DhcpServerConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DrainPolicy) DeepCopyInto(out *DrainPolicy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DrainPolicy.
+func (in *DrainPolicy) DeepCopy() *DrainPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(DrainPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DrainStatus) DeepCopyInto(out *DrainStatus) {
+	*out = *in
+	if in.Pods != nil {
+		in, out := &in.Pods, &out.Pods
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DrainStatus.
+func (in *DrainStatus) DeepCopy() *DrainStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DrainStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EndpointConfig) DeepCopyInto(out *EndpointConfig) {
 	*out = *in
@@ -199,13 +305,43 @@ func (in *FeatureConfig) DeepCopy() *FeatureConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HardwareChange) DeepCopyInto(out *HardwareChange) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HardwareChange.
+func (in *HardwareChange) DeepCopy() *HardwareChange {
+	if in == nil {
+		return nil
+	}
+	out := new(HardwareChange)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HardwareComponent) DeepCopyInto(out *HardwareComponent) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HardwareComponent.
+func (in *HardwareComponent) DeepCopy() *HardwareComponent {
+	if in == nil {
+		return nil
+	}
+	out := new(HardwareComponent)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HostOperation) DeepCopyInto(out *HostOperation) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
-	out.Status = in.Status
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostOperation.
@@ -226,6 +362,46 @@ func (in *HostOperation) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostOperationActionSpec) DeepCopyInto(out *HostOperationActionSpec) {
+	*out = *in
+	if in.PowerLimit != nil {
+		in, out := &in.PowerLimit, &out.PowerLimit
+		*out = new(PowerLimitSpec)
+		**out = **in
+	}
+	if in.LocateDurationMinutes != nil {
+		in, out := &in.LocateDurationMinutes, &out.LocateDurationMinutes
+		*out = new(int32)
+		**out = **in
+	}
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Retry != nil {
+		in, out := &in.Retry, &out.Retry
+		*out = new(RetryPolicy)
+		**out = **in
+	}
+	if in.DrainPolicy != nil {
+		in, out := &in.DrainPolicy, &out.DrainPolicy
+		*out = new(DrainPolicy)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostOperationActionSpec.
+func (in *HostOperationActionSpec) DeepCopy() *HostOperationActionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(HostOperationActionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HostOperationList) DeepCopyInto(out *HostOperationList) {
 	*out = *in
@@ -259,55 +435,151 @@ func (in *HostOperationList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *HostOperationSpec) DeepCopyInto(out *HostOperationSpec) {
+func (in *HostOperationSchedule) DeepCopyInto(out *HostOperationSchedule) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostOperationSpec.
-func (in *HostOperationSpec) DeepCopy() *HostOperationSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostOperationSchedule.
+func (in *HostOperationSchedule) DeepCopy() *HostOperationSchedule {
 	if in == nil {
 		return nil
 	}
-	out := new(HostOperationSpec)
+	out := new(HostOperationSchedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *HostOperationSchedule) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostOperationScheduleList) DeepCopyInto(out *HostOperationScheduleList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]HostOperationSchedule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostOperationScheduleList.
+func (in *HostOperationScheduleList) DeepCopy() *HostOperationScheduleList {
+	if in == nil {
+		return nil
+	}
+	out := new(HostOperationScheduleList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *HostOperationScheduleList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *HostOperationStatus) DeepCopyInto(out *HostOperationStatus) {
+func (in *HostOperationScheduleSpec) DeepCopyInto(out *HostOperationScheduleSpec) {
+	*out = *in
+	if in.StartingDeadlineSeconds != nil {
+		in, out := &in.StartingDeadlineSeconds, &out.StartingDeadlineSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.SuccessfulHistoryLimit != nil {
+		in, out := &in.SuccessfulHistoryLimit, &out.SuccessfulHistoryLimit
+		*out = new(int32)
+		**out = **in
+	}
+	if in.FailedHistoryLimit != nil {
+		in, out := &in.FailedHistoryLimit, &out.FailedHistoryLimit
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HostStatusNames != nil {
+		in, out := &in.HostStatusNames, &out.HostStatusNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.Template.DeepCopyInto(&out.Template)
+	if in.Requester != nil {
+		in, out := &in.Requester, &out.Requester
+		*out = new(Requester)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostOperationScheduleSpec.
+func (in *HostOperationScheduleSpec) DeepCopy() *HostOperationScheduleSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(HostOperationScheduleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostOperationScheduleStatus) DeepCopyInto(out *HostOperationScheduleStatus) {
 	*out = *in
+	if in.Active != nil {
+		in, out := &in.Active, &out.Active
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostOperationStatus.
-func (in *HostOperationStatus) DeepCopy() *HostOperationStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostOperationScheduleStatus.
+func (in *HostOperationScheduleStatus) DeepCopy() *HostOperationScheduleStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(HostOperationStatus)
+	out := new(HostOperationScheduleStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *HostStatus) DeepCopyInto(out *HostStatus) {
+func (in *HostOperationSet) DeepCopyInto(out *HostOperationSet) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostStatus.
-func (in *HostStatus) DeepCopy() *HostStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostOperationSet.
+func (in *HostOperationSet) DeepCopy() *HostOperationSet {
 	if in == nil {
 		return nil
 	}
-	out := new(HostStatus)
+	out := new(HostOperationSet)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *HostStatus) DeepCopyObject() runtime.Object {
+func (in *HostOperationSet) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -315,31 +587,46 @@ func (in *HostStatus) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *HostStatusList) DeepCopyInto(out *HostStatusList) {
+func (in *HostOperationSetHostStatus) DeepCopyInto(out *HostOperationSetHostStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostOperationSetHostStatus.
+func (in *HostOperationSetHostStatus) DeepCopy() *HostOperationSetHostStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(HostOperationSetHostStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostOperationSetList) DeepCopyInto(out *HostOperationSetList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]HostStatus, len(*in))
+		*out = make([]HostOperationSet, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostStatusList.
-func (in *HostStatusList) DeepCopy() *HostStatusList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostOperationSetList.
+func (in *HostOperationSetList) DeepCopy() *HostOperationSetList {
 	if in == nil {
 		return nil
 	}
-	out := new(HostStatusList)
+	out := new(HostOperationSetList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *HostStatusList) DeepCopyObject() runtime.Object {
+func (in *HostOperationSetList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -347,65 +634,1156 @@ func (in *HostStatusList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *HostStatusStatus) DeepCopyInto(out *HostStatusStatus) {
+func (in *HostOperationSetSpec) DeepCopyInto(out *HostOperationSetSpec) {
 	*out = *in
-	out.Basic = in.Basic
-	if in.Info != nil {
-		in, out := &in.Info, &out.Info
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HostStatusNames != nil {
+		in, out := &in.HostStatusNames, &out.HostStatusNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.Template.DeepCopyInto(&out.Template)
+	if in.Requester != nil {
+		in, out := &in.Requester, &out.Requester
+		*out = new(Requester)
+		(*in).DeepCopyInto(*out)
 	}
-	in.Log.DeepCopyInto(&out.Log)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostStatusStatus.
-func (in *HostStatusStatus) DeepCopy() *HostStatusStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostOperationSetSpec.
+func (in *HostOperationSetSpec) DeepCopy() *HostOperationSetSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(HostStatusStatus)
+	out := new(HostOperationSetSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LogEntry) DeepCopyInto(out *LogEntry) {
+func (in *HostOperationSetStatus) DeepCopyInto(out *HostOperationSetStatus) {
 	*out = *in
+	if in.Hosts != nil {
+		in, out := &in.Hosts, &out.Hosts
+		*out = make([]HostOperationSetHostStatus, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogEntry.
-func (in *LogEntry) DeepCopy() *LogEntry {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostOperationSetStatus.
+func (in *HostOperationSetStatus) DeepCopy() *HostOperationSetStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(LogEntry)
+	out := new(HostOperationSetStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LogStruct) DeepCopyInto(out *LogStruct) {
+func (in *HostOperationSpec) DeepCopyInto(out *HostOperationSpec) {
 	*out = *in
-	if in.LastestLog != nil {
-		in, out := &in.LastestLog, &out.LastestLog
-		*out = new(LogEntry)
+	in.HostOperationActionSpec.DeepCopyInto(&out.HostOperationActionSpec)
+	if in.TTLSecondsAfterFinished != nil {
+		in, out := &in.TTLSecondsAfterFinished, &out.TTLSecondsAfterFinished
+		*out = new(int32)
 		**out = **in
 	}
-	if in.LastestWarningLog != nil {
-		in, out := &in.LastestWarningLog, &out.LastestWarningLog
-		*out = new(LogEntry)
-		**out = **in
+	if in.Requester != nil {
+		in, out := &in.Requester, &out.Requester
+		*out = new(Requester)
+		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogStruct.
-func (in *LogStruct) DeepCopy() *LogStruct {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostOperationSpec.
+func (in *HostOperationSpec) DeepCopy() *HostOperationSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(LogStruct)
+	out := new(HostOperationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostOperationStatus) DeepCopyInto(out *HostOperationStatus) {
+	*out = *in
+	if in.Task != nil {
+		in, out := &in.Task, &out.Task
+		*out = new(TaskStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PowerVerification != nil {
+		in, out := &in.PowerVerification, &out.PowerVerification
+		*out = new(PowerVerificationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Drain != nil {
+		in, out := &in.Drain, &out.Drain
+		*out = new(DrainStatus)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostOperationStatus.
+func (in *HostOperationStatus) DeepCopy() *HostOperationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(HostOperationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostPolicy) DeepCopyInto(out *HostPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostPolicy.
+func (in *HostPolicy) DeepCopy() *HostPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(HostPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *HostPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostPolicyList) DeepCopyInto(out *HostPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]HostPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostPolicyList.
+func (in *HostPolicyList) DeepCopy() *HostPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(HostPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *HostPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostPolicySpec) DeepCopyInto(out *HostPolicySpec) {
+	*out = *in
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HostStatusNames != nil {
+		in, out := &in.HostStatusNames, &out.HostStatusNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DestructiveActions != nil {
+		in, out := &in.DestructiveActions, &out.DestructiveActions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MaintenanceWindows != nil {
+		in, out := &in.MaintenanceWindows, &out.MaintenanceWindows
+		*out = make([]MaintenanceWindow, len(*in))
+		copy(*out, *in)
+	}
+	if in.DesiredPower != nil {
+		in, out := &in.DesiredPower, &out.DesiredPower
+		*out = new(DesiredPowerSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PollIntervalSeconds != nil {
+		in, out := &in.PollIntervalSeconds, &out.PollIntervalSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Remediation != nil {
+		in, out := &in.Remediation, &out.Remediation
+		*out = new(RemediationSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostPolicySpec.
+func (in *HostPolicySpec) DeepCopy() *HostPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(HostPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostPool) DeepCopyInto(out *HostPool) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostPool.
+func (in *HostPool) DeepCopy() *HostPool {
+	if in == nil {
+		return nil
+	}
+	out := new(HostPool)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *HostPool) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostPoolList) DeepCopyInto(out *HostPoolList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]HostPool, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostPoolList.
+func (in *HostPoolList) DeepCopy() *HostPoolList {
+	if in == nil {
+		return nil
+	}
+	out := new(HostPoolList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *HostPoolList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostPoolMember) DeepCopyInto(out *HostPoolMember) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostPoolMember.
+func (in *HostPoolMember) DeepCopy() *HostPoolMember {
+	if in == nil {
+		return nil
+	}
+	out := new(HostPoolMember)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostPoolSpec) DeepCopyInto(out *HostPoolSpec) {
+	*out = *in
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.DrainPolicy != nil {
+		in, out := &in.DrainPolicy, &out.DrainPolicy
+		*out = new(DrainPolicy)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostPoolSpec.
+func (in *HostPoolSpec) DeepCopy() *HostPoolSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(HostPoolSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostPoolStatus) DeepCopyInto(out *HostPoolStatus) {
+	*out = *in
+	if in.Hosts != nil {
+		in, out := &in.Hosts, &out.Hosts
+		*out = make([]HostPoolMember, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostPoolStatus.
+func (in *HostPoolStatus) DeepCopy() *HostPoolStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(HostPoolStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostRemediation) DeepCopyInto(out *HostRemediation) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostRemediation.
+func (in *HostRemediation) DeepCopy() *HostRemediation {
+	if in == nil {
+		return nil
+	}
+	out := new(HostRemediation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *HostRemediation) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostRemediationList) DeepCopyInto(out *HostRemediationList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]HostRemediation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostRemediationList.
+func (in *HostRemediationList) DeepCopy() *HostRemediationList {
+	if in == nil {
+		return nil
+	}
+	out := new(HostRemediationList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *HostRemediationList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostRemediationSpec) DeepCopyInto(out *HostRemediationSpec) {
+	*out = *in
+	if in.StepTimeoutSeconds != nil {
+		in, out := &in.StepTimeoutSeconds, &out.StepTimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostRemediationSpec.
+func (in *HostRemediationSpec) DeepCopy() *HostRemediationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(HostRemediationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostRemediationStatus) DeepCopyInto(out *HostRemediationStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostRemediationStatus.
+func (in *HostRemediationStatus) DeepCopy() *HostRemediationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(HostRemediationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostRemediationTemplate) DeepCopyInto(out *HostRemediationTemplate) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostRemediationTemplate.
+func (in *HostRemediationTemplate) DeepCopy() *HostRemediationTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(HostRemediationTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *HostRemediationTemplate) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostRemediationTemplateList) DeepCopyInto(out *HostRemediationTemplateList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]HostRemediationTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostRemediationTemplateList.
+func (in *HostRemediationTemplateList) DeepCopy() *HostRemediationTemplateList {
+	if in == nil {
+		return nil
+	}
+	out := new(HostRemediationTemplateList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *HostRemediationTemplateList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostRemediationTemplateResource) DeepCopyInto(out *HostRemediationTemplateResource) {
+	*out = *in
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostRemediationTemplateResource.
+func (in *HostRemediationTemplateResource) DeepCopy() *HostRemediationTemplateResource {
+	if in == nil {
+		return nil
+	}
+	out := new(HostRemediationTemplateResource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostRemediationTemplateSpec) DeepCopyInto(out *HostRemediationTemplateSpec) {
+	*out = *in
+	in.Template.DeepCopyInto(&out.Template)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostRemediationTemplateSpec.
+func (in *HostRemediationTemplateSpec) DeepCopy() *HostRemediationTemplateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(HostRemediationTemplateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostStatus) DeepCopyInto(out *HostStatus) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostStatus.
+func (in *HostStatus) DeepCopy() *HostStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(HostStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *HostStatus) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostStatusList) DeepCopyInto(out *HostStatusList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]HostStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostStatusList.
+func (in *HostStatusList) DeepCopy() *HostStatusList {
+	if in == nil {
+		return nil
+	}
+	out := new(HostStatusList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *HostStatusList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostStatusStatus) DeepCopyInto(out *HostStatusStatus) {
+	*out = *in
+	out.Basic = in.Basic
+	if in.Info != nil {
+		in, out := &in.Info, &out.Info
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	in.Log.DeepCopyInto(&out.Log)
+	if in.Power != nil {
+		in, out := &in.Power, &out.Power
+		*out = new(PowerStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Location != nil {
+		in, out := &in.Location, &out.Location
+		*out = new(LocationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OperationHistory != nil {
+		in, out := &in.OperationHistory, &out.OperationHistory
+		*out = make([]OperationRecord, len(*in))
+		copy(*out, *in)
+	}
+	if in.DesiredPower != nil {
+		in, out := &in.DesiredPower, &out.DesiredPower
+		*out = new(DesiredPowerStatus)
+		**out = **in
+	}
+	if in.Capabilities != nil {
+		in, out := &in.Capabilities, &out.Capabilities
+		*out = new(CapabilitiesStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Inventory != nil {
+		in, out := &in.Inventory, &out.Inventory
+		*out = new(InventoryStatus)
+		**out = **in
+	}
+	if in.Identity != nil {
+		in, out := &in.Identity, &out.Identity
+		*out = new(IdentityStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Remediation != nil {
+		in, out := &in.Remediation, &out.Remediation
+		*out = new(RemediationStatus)
+		**out = **in
+	}
+	if in.Hardware != nil {
+		in, out := &in.Hardware, &out.Hardware
+		*out = make([]HardwareComponent, len(*in))
+		copy(*out, *in)
+	}
+	if in.HardwareChanges != nil {
+		in, out := &in.HardwareChanges, &out.HardwareChanges
+		*out = make([]HardwareChange, len(*in))
+		copy(*out, *in)
+	}
+	if in.Polling != nil {
+		in, out := &in.Polling, &out.Polling
+		*out = new(PollingStatus)
+		**out = **in
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostStatusStatus.
+func (in *HostStatusStatus) DeepCopy() *HostStatusStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(HostStatusStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostWorkflow) DeepCopyInto(out *HostWorkflow) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostWorkflow.
+func (in *HostWorkflow) DeepCopy() *HostWorkflow {
+	if in == nil {
+		return nil
+	}
+	out := new(HostWorkflow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *HostWorkflow) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostWorkflowList) DeepCopyInto(out *HostWorkflowList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]HostWorkflow, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostWorkflowList.
+func (in *HostWorkflowList) DeepCopy() *HostWorkflowList {
+	if in == nil {
+		return nil
+	}
+	out := new(HostWorkflowList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *HostWorkflowList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostWorkflowSpec) DeepCopyInto(out *HostWorkflowSpec) {
+	*out = *in
+	if in.Steps != nil {
+		in, out := &in.Steps, &out.Steps
+		*out = make([]HostWorkflowStep, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Requester != nil {
+		in, out := &in.Requester, &out.Requester
+		*out = new(Requester)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostWorkflowSpec.
+func (in *HostWorkflowSpec) DeepCopy() *HostWorkflowSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(HostWorkflowSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostWorkflowStatus) DeepCopyInto(out *HostWorkflowStatus) {
+	*out = *in
+	if in.Steps != nil {
+		in, out := &in.Steps, &out.Steps
+		*out = make([]HostWorkflowStepStatus, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostWorkflowStatus.
+func (in *HostWorkflowStatus) DeepCopy() *HostWorkflowStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(HostWorkflowStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostWorkflowStep) DeepCopyInto(out *HostWorkflowStep) {
+	*out = *in
+	if in.Operation != nil {
+		in, out := &in.Operation, &out.Operation
+		*out = new(HostOperationActionSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DelaySeconds != nil {
+		in, out := &in.DelaySeconds, &out.DelaySeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.WaitProperty != nil {
+		in, out := &in.WaitProperty, &out.WaitProperty
+		*out = new(WaitPropertySpec)
+		**out = **in
+	}
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Rollback != nil {
+		in, out := &in.Rollback, &out.Rollback
+		*out = new(HostOperationActionSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostWorkflowStep.
+func (in *HostWorkflowStep) DeepCopy() *HostWorkflowStep {
+	if in == nil {
+		return nil
+	}
+	out := new(HostWorkflowStep)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostWorkflowStepStatus) DeepCopyInto(out *HostWorkflowStepStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostWorkflowStepStatus.
+func (in *HostWorkflowStepStatus) DeepCopy() *HostWorkflowStepStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(HostWorkflowStepStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IdentityStatus) DeepCopyInto(out *IdentityStatus) {
+	*out = *in
+	if in.HostMacs != nil {
+		in, out := &in.HostMacs, &out.HostMacs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IdentityStatus.
+func (in *IdentityStatus) DeepCopy() *IdentityStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(IdentityStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InventoryStatus) DeepCopyInto(out *InventoryStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InventoryStatus.
+func (in *InventoryStatus) DeepCopy() *InventoryStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(InventoryStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LocationStatus) DeepCopyInto(out *LocationStatus) {
+	*out = *in
+	if in.RackOffset != nil {
+		in, out := &in.RackOffset, &out.RackOffset
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocationStatus.
+func (in *LocationStatus) DeepCopy() *LocationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(LocationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LogEntry) DeepCopyInto(out *LogEntry) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogEntry.
+func (in *LogEntry) DeepCopy() *LogEntry {
+	if in == nil {
+		return nil
+	}
+	out := new(LogEntry)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LogStruct) DeepCopyInto(out *LogStruct) {
+	*out = *in
+	if in.LastestLog != nil {
+		in, out := &in.LastestLog, &out.LastestLog
+		*out = new(LogEntry)
+		**out = **in
+	}
+	if in.LastestWarningLog != nil {
+		in, out := &in.LastestWarningLog, &out.LastestWarningLog
+		*out = new(LogEntry)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogStruct.
+func (in *LogStruct) DeepCopy() *LogStruct {
+	if in == nil {
+		return nil
+	}
+	out := new(LogStruct)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceWindow) DeepCopyInto(out *MaintenanceWindow) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindow.
+func (in *MaintenanceWindow) DeepCopy() *MaintenanceWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OperationRecord) DeepCopyInto(out *OperationRecord) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperationRecord.
+func (in *OperationRecord) DeepCopy() *OperationRecord {
+	if in == nil {
+		return nil
+	}
+	out := new(OperationRecord)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PollingStatus) DeepCopyInto(out *PollingStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PollingStatus.
+func (in *PollingStatus) DeepCopy() *PollingStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PollingStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PowerLimitSpec) DeepCopyInto(out *PowerLimitSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PowerLimitSpec.
+func (in *PowerLimitSpec) DeepCopy() *PowerLimitSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PowerLimitSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PowerStateRecord) DeepCopyInto(out *PowerStateRecord) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PowerStateRecord.
+func (in *PowerStateRecord) DeepCopy() *PowerStateRecord {
+	if in == nil {
+		return nil
+	}
+	out := new(PowerStateRecord)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PowerStatus) DeepCopyInto(out *PowerStatus) {
+	*out = *in
+	if in.LimitInWatts != nil {
+		in, out := &in.LimitInWatts, &out.LimitInWatts
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PowerStatus.
+func (in *PowerStatus) DeepCopy() *PowerStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PowerStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PowerVerificationStatus) DeepCopyInto(out *PowerVerificationStatus) {
+	*out = *in
+	if in.Transitions != nil {
+		in, out := &in.Transitions, &out.Transitions
+		*out = make([]PowerStateRecord, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PowerVerificationStatus.
+func (in *PowerVerificationStatus) DeepCopy() *PowerVerificationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PowerVerificationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemediationSpec) DeepCopyInto(out *RemediationSpec) {
+	*out = *in
+	if in.NotReadySeconds != nil {
+		in, out := &in.NotReadySeconds, &out.NotReadySeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.StepTimeoutSeconds != nil {
+		in, out := &in.StepTimeoutSeconds, &out.StepTimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemediationSpec.
+func (in *RemediationSpec) DeepCopy() *RemediationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RemediationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemediationStatus) DeepCopyInto(out *RemediationStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemediationStatus.
+func (in *RemediationStatus) DeepCopy() *RemediationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RemediationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Requester) DeepCopyInto(out *Requester) {
+	*out = *in
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Requester.
+func (in *Requester) DeepCopy() *Requester {
+	if in == nil {
+		return nil
+	}
+	out := new(Requester)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RetryPolicy) DeepCopyInto(out *RetryPolicy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RetryPolicy.
+func (in *RetryPolicy) DeepCopy() *RetryPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(RetryPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TaskStatus) DeepCopyInto(out *TaskStatus) {
+	*out = *in
+	if in.Messages != nil {
+		in, out := &in.Messages, &out.Messages
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskStatus.
+func (in *TaskStatus) DeepCopy() *TaskStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TaskStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WaitPropertySpec) DeepCopyInto(out *WaitPropertySpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WaitPropertySpec.
+func (in *WaitPropertySpec) DeepCopy() *WaitPropertySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(WaitPropertySpec)
 	in.DeepCopyInto(out)
 	return out
 }
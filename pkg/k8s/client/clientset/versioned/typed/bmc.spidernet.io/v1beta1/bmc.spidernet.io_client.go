@@ -18,7 +18,14 @@ type BmcV1beta1Interface interface {
 	ClusterAgentsGetter
 	HostEndpointsGetter
 	HostOperationsGetter
+	HostOperationSchedulesGetter
+	HostOperationSetsGetter
+	HostPoliciesGetter
+	HostPoolsGetter
+	HostRemediationsGetter
+	HostRemediationTemplatesGetter
 	HostStatusesGetter
+	HostWorkflowsGetter
 }
 
 // BmcV1beta1Client is used to interact with features provided by the bmc.spidernet.io group.
@@ -38,10 +45,38 @@ func (c *BmcV1beta1Client) HostOperations() HostOperationInterface {
 	return newHostOperations(c)
 }
 
+func (c *BmcV1beta1Client) HostOperationSchedules() HostOperationScheduleInterface {
+	return newHostOperationSchedules(c)
+}
+
+func (c *BmcV1beta1Client) HostOperationSets() HostOperationSetInterface {
+	return newHostOperationSets(c)
+}
+
+func (c *BmcV1beta1Client) HostPolicies() HostPolicyInterface {
+	return newHostPolicies(c)
+}
+
+func (c *BmcV1beta1Client) HostPools() HostPoolInterface {
+	return newHostPools(c)
+}
+
+func (c *BmcV1beta1Client) HostRemediations(namespace string) HostRemediationInterface {
+	return newHostRemediations(c, namespace)
+}
+
+func (c *BmcV1beta1Client) HostRemediationTemplates(namespace string) HostRemediationTemplateInterface {
+	return newHostRemediationTemplates(c, namespace)
+}
+
 func (c *BmcV1beta1Client) HostStatuses() HostStatusInterface {
 	return newHostStatuses(c)
 }
 
+func (c *BmcV1beta1Client) HostWorkflows() HostWorkflowInterface {
+	return newHostWorkflows(c)
+}
+
 // NewForConfig creates a new BmcV1beta1Client for the given config.
 // NewForConfig is equivalent to NewForConfigAndClient(c, httpClient),
 // where httpClient was generated with rest.HTTPClientFor(c).
@@ -27,10 +27,38 @@ func (c *FakeBmcV1beta1) HostOperations() v1beta1.HostOperationInterface {
 	return newFakeHostOperations(c)
 }
 
+func (c *FakeBmcV1beta1) HostOperationSchedules() v1beta1.HostOperationScheduleInterface {
+	return newFakeHostOperationSchedules(c)
+}
+
+func (c *FakeBmcV1beta1) HostOperationSets() v1beta1.HostOperationSetInterface {
+	return newFakeHostOperationSets(c)
+}
+
+func (c *FakeBmcV1beta1) HostPolicies() v1beta1.HostPolicyInterface {
+	return newFakeHostPolicies(c)
+}
+
+func (c *FakeBmcV1beta1) HostPools() v1beta1.HostPoolInterface {
+	return newFakeHostPools(c)
+}
+
+func (c *FakeBmcV1beta1) HostRemediations(namespace string) v1beta1.HostRemediationInterface {
+	return newFakeHostRemediations(c, namespace)
+}
+
+func (c *FakeBmcV1beta1) HostRemediationTemplates(namespace string) v1beta1.HostRemediationTemplateInterface {
+	return newFakeHostRemediationTemplates(c, namespace)
+}
+
 func (c *FakeBmcV1beta1) HostStatuses() v1beta1.HostStatusInterface {
 	return newFakeHostStatuses(c)
 }
 
+func (c *FakeBmcV1beta1) HostWorkflows() v1beta1.HostWorkflowInterface {
+	return newFakeHostWorkflows(c)
+}
+
 // RESTClient returns a RESTClient that is used to communicate
 // with API server by this client implementation.
 func (c *FakeBmcV1beta1) RESTClient() rest.Interface {
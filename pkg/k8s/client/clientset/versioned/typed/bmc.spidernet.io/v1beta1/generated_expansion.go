@@ -11,4 +11,18 @@ type HostEndpointExpansion interface{}
 
 type HostOperationExpansion interface{}
 
+type HostOperationScheduleExpansion interface{}
+
+type HostOperationSetExpansion interface{}
+
+type HostPolicyExpansion interface{}
+
+type HostPoolExpansion interface{}
+
+type HostRemediationExpansion interface{}
+
+type HostRemediationTemplateExpansion interface{}
+
 type HostStatusExpansion interface{}
+
+type HostWorkflowExpansion interface{}
@@ -17,8 +17,22 @@ type Interface interface {
 	HostEndpoints() HostEndpointInformer
 	// HostOperations returns a HostOperationInformer.
 	HostOperations() HostOperationInformer
+	// HostOperationSchedules returns a HostOperationScheduleInformer.
+	HostOperationSchedules() HostOperationScheduleInformer
+	// HostOperationSets returns a HostOperationSetInformer.
+	HostOperationSets() HostOperationSetInformer
+	// HostPolicies returns a HostPolicyInformer.
+	HostPolicies() HostPolicyInformer
+	// HostPools returns a HostPoolInformer.
+	HostPools() HostPoolInformer
+	// HostRemediations returns a HostRemediationInformer.
+	HostRemediations() HostRemediationInformer
+	// HostRemediationTemplates returns a HostRemediationTemplateInformer.
+	HostRemediationTemplates() HostRemediationTemplateInformer
 	// HostStatuses returns a HostStatusInformer.
 	HostStatuses() HostStatusInformer
+	// HostWorkflows returns a HostWorkflowInformer.
+	HostWorkflows() HostWorkflowInformer
 }
 
 type version struct {
@@ -47,7 +61,42 @@ func (v *version) HostOperations() HostOperationInformer {
 	return &hostOperationInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
 }
 
+// HostOperationSchedules returns a HostOperationScheduleInformer.
+func (v *version) HostOperationSchedules() HostOperationScheduleInformer {
+	return &hostOperationScheduleInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
+// HostOperationSets returns a HostOperationSetInformer.
+func (v *version) HostOperationSets() HostOperationSetInformer {
+	return &hostOperationSetInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
+// HostPolicies returns a HostPolicyInformer.
+func (v *version) HostPolicies() HostPolicyInformer {
+	return &hostPolicyInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
+// HostPools returns a HostPoolInformer.
+func (v *version) HostPools() HostPoolInformer {
+	return &hostPoolInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
+// HostRemediations returns a HostRemediationInformer.
+func (v *version) HostRemediations() HostRemediationInformer {
+	return &hostRemediationInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// HostRemediationTemplates returns a HostRemediationTemplateInformer.
+func (v *version) HostRemediationTemplates() HostRemediationTemplateInformer {
+	return &hostRemediationTemplateInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // HostStatuses returns a HostStatusInformer.
 func (v *version) HostStatuses() HostStatusInformer {
 	return &hostStatusInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
 }
+
+// HostWorkflows returns a HostWorkflowInformer.
+func (v *version) HostWorkflows() HostWorkflowInformer {
+	return &hostWorkflowInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
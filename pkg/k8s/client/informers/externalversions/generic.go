@@ -46,8 +46,22 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Bmc().V1beta1().HostEndpoints().Informer()}, nil
 	case v1beta1.SchemeGroupVersion.WithResource("hostoperations"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Bmc().V1beta1().HostOperations().Informer()}, nil
+	case v1beta1.SchemeGroupVersion.WithResource("hostoperationschedules"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Bmc().V1beta1().HostOperationSchedules().Informer()}, nil
+	case v1beta1.SchemeGroupVersion.WithResource("hostoperationsets"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Bmc().V1beta1().HostOperationSets().Informer()}, nil
+	case v1beta1.SchemeGroupVersion.WithResource("hostpolicies"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Bmc().V1beta1().HostPolicies().Informer()}, nil
+	case v1beta1.SchemeGroupVersion.WithResource("hostpools"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Bmc().V1beta1().HostPools().Informer()}, nil
+	case v1beta1.SchemeGroupVersion.WithResource("hostremediations"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Bmc().V1beta1().HostRemediations().Informer()}, nil
+	case v1beta1.SchemeGroupVersion.WithResource("hostremediationtemplates"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Bmc().V1beta1().HostRemediationTemplates().Informer()}, nil
 	case v1beta1.SchemeGroupVersion.WithResource("hoststatuses"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Bmc().V1beta1().HostStatuses().Informer()}, nil
+	case v1beta1.SchemeGroupVersion.WithResource("hostworkflows"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Bmc().V1beta1().HostWorkflows().Informer()}, nil
 
 	}
 
@@ -17,6 +17,42 @@ type HostEndpointListerExpansion interface{}
 // HostOperationLister.
 type HostOperationListerExpansion interface{}
 
+// HostOperationScheduleListerExpansion allows custom methods to be added to
+// HostOperationScheduleLister.
+type HostOperationScheduleListerExpansion interface{}
+
+// HostOperationSetListerExpansion allows custom methods to be added to
+// HostOperationSetLister.
+type HostOperationSetListerExpansion interface{}
+
+// HostPolicyListerExpansion allows custom methods to be added to
+// HostPolicyLister.
+type HostPolicyListerExpansion interface{}
+
+// HostPoolListerExpansion allows custom methods to be added to
+// HostPoolLister.
+type HostPoolListerExpansion interface{}
+
+// HostRemediationListerExpansion allows custom methods to be added to
+// HostRemediationLister.
+type HostRemediationListerExpansion interface{}
+
+// HostRemediationNamespaceListerExpansion allows custom methods to be added to
+// HostRemediationNamespaceLister.
+type HostRemediationNamespaceListerExpansion interface{}
+
+// HostRemediationTemplateListerExpansion allows custom methods to be added to
+// HostRemediationTemplateLister.
+type HostRemediationTemplateListerExpansion interface{}
+
+// HostRemediationTemplateNamespaceListerExpansion allows custom methods to be added to
+// HostRemediationTemplateNamespaceLister.
+type HostRemediationTemplateNamespaceListerExpansion interface{}
+
 // HostStatusListerExpansion allows custom methods to be added to
 // HostStatusLister.
 type HostStatusListerExpansion interface{}
+
+// HostWorkflowListerExpansion allows custom methods to be added to
+// HostWorkflowLister.
+type HostWorkflowListerExpansion interface{}
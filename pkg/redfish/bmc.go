@@ -0,0 +1,64 @@
+package redfish
+
+import (
+	"fmt"
+
+	bmcv1beta1 "github.com/spidernet-io/bmc/pkg/k8s/apis/bmc.spidernet.io/v1beta1"
+	"github.com/stmcginnis/gofish/redfish"
+)
+
+// getManager returns the manager of the BMC
+func (c *redfishClient) getManager() (*redfish.Manager, error) {
+	managers, err := c.client.Service.Managers()
+	if err != nil {
+		c.logger.Errorf("failed to Query the bmc : %+v", err)
+		return nil, err
+	} else if len(managers) == 0 {
+		c.logger.Errorf("failed to get bmc")
+		return nil, fmt.Errorf("failed to get bmc")
+	}
+	return managers[0], nil
+}
+
+// ResetBmc restarts the BMC
+func (c *redfishClient) ResetBmc(action string) error {
+	var resetType redfish.ResetType
+	switch action {
+	case bmcv1beta1.ActionBmcGracefulRestart:
+		resetType = redfish.GracefulRestartResetType
+	case bmcv1beta1.ActionBmcForceRestart:
+		resetType = redfish.ForceRestartResetType
+	default:
+		return fmt.Errorf("unknown bmc reset action: %s", action)
+	}
+
+	bmc, err := c.getManager()
+	if err != nil {
+		return err
+	}
+	c.logger.Infof("reset bmc %s of %s with %s, supported reset types: %+v", bmc.ID, c.config.Endpoint, resetType, bmc.SupportedResetTypes)
+	if err := bmc.Reset(resetType); err != nil {
+		c.logger.Errorf("failed to reset bmc: %+v", err)
+		return err
+	}
+	c.invalidate()
+	return nil
+}
+
+// ResetBmcToDefaults resets the settings of the BMC to factory defaults
+func (c *redfishClient) ResetBmcToDefaults(resetType string) error {
+	if resetType == "" {
+		resetType = bmcv1beta1.ResetToDefaultsAll
+	}
+	bmc, err := c.getManager()
+	if err != nil {
+		return err
+	}
+	c.logger.Infof("reset bmc %s of %s to defaults with %s", bmc.ID, c.config.Endpoint, resetType)
+	if err := bmc.ResetToDefaults(redfish.ResetToDefaultsType(resetType)); err != nil {
+		c.logger.Errorf("failed to reset bmc to defaults: %+v", err)
+		return err
+	}
+	c.invalidate()
+	return nil
+}
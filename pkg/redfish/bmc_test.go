@@ -0,0 +1,103 @@
+package redfish_test
+
+import (
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	bmcv1beta1 "github.com/spidernet-io/bmc/pkg/k8s/apis/bmc.spidernet.io/v1beta1"
+)
+
+var _ = Describe("BMC", Label("unitest"), func() {
+	const (
+		resetTarget    = "/redfish/v1/Managers/1/Actions/Manager.Reset"
+		defaultsTarget = "/redfish/v1/Managers/1/Actions/Manager.ResetToDefaults"
+	)
+	var bmc *fakeBMC
+
+	// manager serves the BMC with the reset action declaring the reset types
+	manager := func(reset map[string]interface{}) {
+		reset["target"] = resetTarget
+		bmc.set("/redfish/v1/Managers/1", map[string]interface{}{
+			"@odata.id": "/redfish/v1/Managers/1",
+			"Id":        "1",
+			"Actions": map[string]interface{}{
+				"#Manager.Reset":           reset,
+				"#Manager.ResetToDefaults": map[string]string{"target": defaultsTarget},
+			},
+		})
+	}
+	posted := func() []request {
+		return bmc.received(http.MethodPost)
+	}
+
+	BeforeEach(func() {
+		bmc = newFakeBMC()
+		DeferCleanup(bmc.Close)
+		bmc.set("/redfish/v1/Managers", map[string]interface{}{
+			"Members": []map[string]string{{"@odata.id": "/redfish/v1/Managers/1"}},
+		})
+		manager(map[string]interface{}{"ResetType@Redfish.AllowableValues": []string{"GracefulRestart", "ForceRestart"}})
+	})
+
+	Context("ResetBmc", func() {
+		It("restarts the BMC gracefully", func() {
+			Expect(bmc.client().ResetBmc(bmcv1beta1.ActionBmcGracefulRestart)).To(Succeed())
+			Expect(posted()).To(HaveLen(1))
+			Expect(posted()[0].Path).To(Equal(resetTarget))
+			Expect(posted()[0].Payload).To(Equal(map[string]interface{}{"ResetType": "GracefulRestart"}))
+		})
+
+		It("restarts the BMC by force", func() {
+			Expect(bmc.client().ResetBmc(bmcv1beta1.ActionBmcForceRestart)).To(Succeed())
+			Expect(posted()).To(HaveLen(1))
+			Expect(posted()[0].Payload).To(Equal(map[string]interface{}{"ResetType": "ForceRestart"}))
+		})
+
+		It("refuses the reset type the BMC does not support", func() {
+			manager(map[string]interface{}{"ResetType@Redfish.AllowableValues": []string{"GracefulRestart"}})
+			Expect(bmc.client().ResetBmc(bmcv1beta1.ActionBmcForceRestart)).NotTo(Succeed())
+			Expect(posted()).To(BeEmpty())
+		})
+
+		It("restarts the BMC without the reset type when the BMC declares none", func() {
+			manager(map[string]interface{}{})
+			Expect(bmc.client().ResetBmc(bmcv1beta1.ActionBmcGracefulRestart)).To(Succeed())
+			Expect(posted()).To(HaveLen(1))
+			Expect(posted()[0].Path).To(Equal(resetTarget))
+			Expect(posted()[0].Payload).To(BeEmpty())
+		})
+
+		It("refuses an unknown action", func() {
+			Expect(bmc.client().ResetBmc(bmcv1beta1.BootCmdForceRestart)).NotTo(Succeed())
+			Expect(posted()).To(BeEmpty())
+		})
+
+		It("returns the error of the BMC", func() {
+			bmc.handle(resetTarget, func(w http.ResponseWriter, req *http.Request) {
+				w.WriteHeader(http.StatusServiceUnavailable)
+			})
+			Expect(bmc.client().ResetBmc(bmcv1beta1.ActionBmcGracefulRestart)).NotTo(Succeed())
+		})
+
+		It("fails when the service has no BMC", func() {
+			bmc.set("/redfish/v1/Managers", map[string]interface{}{"Members": []map[string]string{}})
+			Expect(bmc.client().ResetBmc(bmcv1beta1.ActionBmcGracefulRestart)).To(MatchError("failed to get bmc"))
+		})
+	})
+
+	Context("ResetBmcToDefaults", func() {
+		It("resets all the settings by default", func() {
+			Expect(bmc.client().ResetBmcToDefaults("")).To(Succeed())
+			Expect(posted()).To(HaveLen(1))
+			Expect(posted()[0].Path).To(Equal(defaultsTarget))
+			Expect(posted()[0].Payload).To(Equal(map[string]interface{}{"ResetType": bmcv1beta1.ResetToDefaultsAll}))
+		})
+
+		It("resets the settings with the reset type", func() {
+			Expect(bmc.client().ResetBmcToDefaults(bmcv1beta1.ResetToDefaultsPreserveNetworkAndUsers)).To(Succeed())
+			Expect(posted()).To(HaveLen(1))
+			Expect(posted()[0].Payload).To(Equal(map[string]interface{}{"ResetType": bmcv1beta1.ResetToDefaultsPreserveNetworkAndUsers}))
+		})
+	})
+})
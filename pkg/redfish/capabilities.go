@@ -0,0 +1,69 @@
+package redfish
+
+import (
+	"encoding/json"
+	"fmt"
+
+	bmcv1beta1 "github.com/spidernet-io/bmc/pkg/k8s/apis/bmc.spidernet.io/v1beta1"
+	"github.com/stmcginnis/gofish/redfish"
+)
+
+// bootTargets returns the allowed boot source override targets reported in the raw data of the system
+func bootTargets(raw []byte) []string {
+	t := struct {
+		Boot struct {
+			Targets []string `json:"BootSourceOverrideTarget@Redfish.AllowableValues"`
+		}
+	}{}
+	if err := json.Unmarshal(raw, &t); err != nil {
+		return nil
+	}
+	return t.Boot.Targets
+}
+
+func resetTypes(types []redfish.ResetType) []string {
+	result := make([]string, 0, len(types))
+	for _, t := range types {
+		result = append(result, string(t))
+	}
+	return result
+}
+
+// GetCapabilities returns what the BMC advertises, an empty list means the BMC does not advertise it
+func (c *redfishClient) GetCapabilities() (*bmcv1beta1.CapabilitiesStatus, error) {
+	ss, err := c.client.Service.Systems()
+	if err != nil {
+		c.logger.Errorf("failed to Query the computer systems: %+v", err)
+		return nil, err
+	} else if len(ss) == 0 {
+		return nil, fmt.Errorf("no system found")
+	}
+	system := ss[0]
+	result := &bmcv1beta1.CapabilitiesStatus{
+		ResetTypes:                resetTypes(system.SupportedResetTypes),
+		BootSourceOverrideTargets: bootTargets(system.RawData),
+		LocateIndicator:           system.IndicatorLED != "" || hasProperty(system.RawData, "LocationIndicatorActive"),
+	}
+
+	cs, err := c.client.Service.Chassis()
+	if err != nil {
+		c.logger.Errorf("failed to get chassis: %+v", err)
+		return nil, err
+	}
+	for _, chassis := range cs {
+		if chassis.IndicatorLED != "" || hasProperty(chassis.RawData, "LocationIndicatorActive") {
+			result.LocateIndicator = true
+		}
+	}
+	if _, err := c.getPowerResource(); err == nil {
+		result.PowerLimit = true
+	}
+
+	bmc, err := c.getManager()
+	if err != nil {
+		return nil, err
+	}
+	result.BmcResetTypes = resetTypes(bmc.SupportedResetTypes)
+	result.BmcResetToDefaults = actionTarget(bmc.RawData, "#Manager.ResetToDefaults") != ""
+	return result, nil
+}
@@ -0,0 +1,105 @@
+package redfish
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"syscall"
+
+	bmcv1beta1 "github.com/spidernet-io/bmc/pkg/k8s/apis/bmc.spidernet.io/v1beta1"
+	"github.com/stmcginnis/gofish/common"
+)
+
+// IsTransientError returns whether the error is likely to disappear by retrying,
+// such as the BMC is busy, or the connection is reset or times out
+func IsTransientError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var rfErr *common.Error
+	if errors.As(err, &rfErr) {
+		switch rfErr.HTTPReturnedStatusCode {
+		case http.StatusRequestTimeout, http.StatusTooManyRequests, http.StatusBadGateway,
+			http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return true
+		}
+		return false
+	}
+
+	var opErr *net.OpError
+	if errors.As(err, &opErr) {
+		return true
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) {
+		return true
+	}
+
+	// some errors are wrapped as text
+	msg := strings.ToLower(err.Error())
+	for _, s := range []string{"connection reset", "connection refused", "i/o timeout", "timeout awaiting", "unexpected eof", "service unavailable"} {
+		if strings.Contains(msg, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// ClassifyError returns the condition reason of the HostStatus for the error of a redfish request
+func ClassifyError(err error) string {
+	if err == nil {
+		return bmcv1beta1.HostStatusReasonSucceeded
+	}
+
+	var rfErr *common.Error
+	if errors.As(err, &rfErr) {
+		switch rfErr.HTTPReturnedStatusCode {
+		case http.StatusUnauthorized, http.StatusForbidden:
+			return bmcv1beta1.HostStatusReasonUnauthorized
+		}
+		return bmcv1beta1.HostStatusReasonRequestFailed
+	}
+
+	var recordErr tls.RecordHeaderError
+	var certErr *tls.CertificateVerificationError
+	var authorityErr x509.UnknownAuthorityError
+	var invalidErr x509.CertificateInvalidError
+	var hostnameErr x509.HostnameError
+	if errors.As(err, &recordErr) || errors.As(err, &certErr) || errors.As(err, &authorityErr) ||
+		errors.As(err, &invalidErr) || errors.As(err, &hostnameErr) {
+		return bmcv1beta1.HostStatusReasonTLSError
+	}
+	msg := strings.ToLower(err.Error())
+	if strings.Contains(msg, "tls:") || strings.Contains(msg, "x509:") {
+		return bmcv1beta1.HostStatusReasonTLSError
+	}
+
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &syntaxErr) || errors.As(err, &typeErr) {
+		return bmcv1beta1.HostStatusReasonSchemaError
+	}
+
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) || IsTransientError(err) || errors.Is(err, syscall.EHOSTUNREACH) || errors.Is(err, syscall.ENETUNREACH) {
+		return bmcv1beta1.HostStatusReasonNetworkUnreachable
+	}
+	for _, s := range []string{"no route to host", "network is unreachable", "no such host"} {
+		if strings.Contains(msg, s) {
+			return bmcv1beta1.HostStatusReasonNetworkUnreachable
+		}
+	}
+
+	// the response is received but it does not contain what is expected, such as a missing system
+	return bmcv1beta1.HostStatusReasonSchemaError
+}
@@ -0,0 +1,68 @@
+package redfish_test
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"syscall"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	bmcv1beta1 "github.com/spidernet-io/bmc/pkg/k8s/apis/bmc.spidernet.io/v1beta1"
+	"github.com/spidernet-io/bmc/pkg/redfish"
+	"github.com/stmcginnis/gofish/common"
+)
+
+var _ = Describe("Errors", Label("unitest"), func() {
+	// the errors of the http client are wrapped in url.Error
+	request := func(err error) error {
+		return &url.Error{Op: "Get", URL: "https://192.168.0.10/redfish/v1/", Err: err}
+	}
+	status := func(code int) error {
+		return &common.Error{HTTPReturnedStatusCode: code}
+	}
+	refused := &net.OpError{Op: "dial", Net: "tcp", Err: os.NewSyscallError("connect", syscall.ECONNREFUSED)}
+
+	DescribeTable("classifies the error of a redfish request",
+		func(err error, reason string) {
+			Expect(redfish.ClassifyError(err)).To(Equal(reason))
+		},
+		Entry("no error", nil, bmcv1beta1.HostStatusReasonSucceeded),
+		Entry("401", status(401), bmcv1beta1.HostStatusReasonUnauthorized),
+		Entry("403", status(403), bmcv1beta1.HostStatusReasonUnauthorized),
+		Entry("wrapped 401", fmt.Errorf("failed to create the session: %w", status(401)), bmcv1beta1.HostStatusReasonUnauthorized),
+		Entry("500", status(500), bmcv1beta1.HostStatusReasonRequestFailed),
+		Entry("404", status(404), bmcv1beta1.HostStatusReasonRequestFailed),
+		Entry("TLS record header", request(tls.RecordHeaderError{Msg: "first record does not look like a TLS handshake"}), bmcv1beta1.HostStatusReasonTLSError),
+		Entry("unknown authority", request(&tls.CertificateVerificationError{Err: x509.UnknownAuthorityError{}}), bmcv1beta1.HostStatusReasonTLSError),
+		Entry("hostname mismatch", request(x509.HostnameError{Certificate: &x509.Certificate{}, Host: "192.168.0.10"}), bmcv1beta1.HostStatusReasonTLSError),
+		Entry("TLS error in text", errors.New("remote error: tls: handshake failure"), bmcv1beta1.HostStatusReasonTLSError),
+		Entry("DNS", request(&net.DNSError{Err: "no such host", Name: "bmc1.example.com", IsNotFound: true}), bmcv1beta1.HostStatusReasonNetworkUnreachable),
+		Entry("connection refused", request(refused), bmcv1beta1.HostStatusReasonNetworkUnreachable),
+		Entry("host unreachable", request(os.NewSyscallError("connect", syscall.EHOSTUNREACH)), bmcv1beta1.HostStatusReasonNetworkUnreachable),
+		Entry("network unreachable in text", errors.New("dial tcp 192.168.0.10:443: connect: network is unreachable"), bmcv1beta1.HostStatusReasonNetworkUnreachable),
+		Entry("JSON syntax", fmt.Errorf("failed to parse the service root: %w", &json.SyntaxError{Offset: 1}), bmcv1beta1.HostStatusReasonSchemaError),
+		Entry("JSON type", json.Unmarshal([]byte(`{"Members": "none"}`), &struct{ Members []string }{}), bmcv1beta1.HostStatusReasonSchemaError),
+		Entry("fallback", errors.New("no system found"), bmcv1beta1.HostStatusReasonSchemaError),
+	)
+
+	DescribeTable("tells the transient errors",
+		func(err error, transient bool) {
+			Expect(redfish.IsTransientError(err)).To(Equal(transient))
+		},
+		Entry("no error", nil, false),
+		Entry("503", status(503), true),
+		Entry("429", status(429), true),
+		Entry("401", status(401), false),
+		Entry("400", status(400), false),
+		Entry("connection refused", request(refused), true),
+		Entry("connection reset in text", errors.New("read tcp: connection reset by peer"), true),
+		Entry("TLS", request(tls.RecordHeaderError{}), false),
+		Entry("fallback", errors.New("no system found"), false),
+	)
+})
@@ -0,0 +1,26 @@
+package redfish
+
+import (
+	"github.com/stmcginnis/gofish"
+	"go.uber.org/zap"
+)
+
+var (
+	ActionTarget  = actionTarget
+	ToRelativeURI = toRelativeURI
+)
+
+// NewTestClient connects the endpoint without authentication and the client cache
+func NewTestClient(endpoint string) (RefishClient, error) {
+	config := gofish.ClientConfig{Endpoint: endpoint, Insecure: true}
+	client, err := gofish.Connect(config)
+	if err != nil {
+		return nil, err
+	}
+	return &redfishClient{
+		config:    config,
+		logger:    zap.NewNop().Sugar(),
+		client:    client,
+		resources: map[string]*cachedResource{},
+	}, nil
+}
@@ -0,0 +1,107 @@
+package redfish_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+
+	. "github.com/onsi/gomega"
+	"github.com/spidernet-io/bmc/pkg/redfish"
+)
+
+// request is a request received by the fake BMC
+type request struct {
+	Method  string
+	Path    string
+	Header  http.Header
+	Payload map[string]interface{}
+}
+
+// fakeBMC is a Redfish service which serves the resources in memory, and records the requests
+type fakeBMC struct {
+	*httptest.Server
+	lock sync.Mutex
+	// resources are the bodies of the GET requests by path
+	resources map[string]interface{}
+	// handlers overrides the response of the path
+	handlers map[string]http.HandlerFunc
+	requests []request
+}
+
+func newFakeBMC() *fakeBMC {
+	f := &fakeBMC{
+		resources: map[string]interface{}{
+			"/redfish/v1/": map[string]interface{}{
+				"@odata.id": "/redfish/v1/",
+				"Systems":   map[string]string{"@odata.id": "/redfish/v1/Systems"},
+				"Managers":  map[string]string{"@odata.id": "/redfish/v1/Managers"},
+				"Chassis":   map[string]string{"@odata.id": "/redfish/v1/Chassis"},
+			},
+		},
+		handlers: map[string]http.HandlerFunc{},
+	}
+	f.Server = httptest.NewServer(http.HandlerFunc(f.serve))
+	return f
+}
+
+// set serves the body on the path
+func (f *fakeBMC) set(path string, body interface{}) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.resources[path] = body
+}
+
+// handle overrides the response of the path
+func (f *fakeBMC) handle(path string, h http.HandlerFunc) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.handlers[path] = h
+}
+
+// received returns the requests with the method
+func (f *fakeBMC) received(method string) []request {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	result := []request{}
+	for _, r := range f.requests {
+		if r.Method == method {
+			result = append(result, r)
+		}
+	}
+	return result
+}
+
+func (f *fakeBMC) serve(w http.ResponseWriter, req *http.Request) {
+	body, _ := io.ReadAll(req.Body)
+	payload := map[string]interface{}{}
+	_ = json.Unmarshal(body, &payload)
+
+	f.lock.Lock()
+	f.requests = append(f.requests, request{Method: req.Method, Path: req.URL.Path, Header: req.Header.Clone(), Payload: payload})
+	h, ok := f.handlers[req.URL.Path]
+	resource, found := f.resources[req.URL.Path]
+	f.lock.Unlock()
+
+	if ok {
+		h(w, req)
+		return
+	}
+	switch {
+	case req.Method != http.MethodGet:
+		w.WriteHeader(http.StatusNoContent)
+	case found:
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(resource)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+// client connects the fake BMC
+func (f *fakeBMC) client() redfish.RefishClient {
+	c, err := redfish.NewTestClient(f.URL)
+	Expect(err).NotTo(HaveOccurred())
+	return c
+}
@@ -1,8 +1,15 @@
 package redfish
 
 import (
+	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
+
+	"github.com/spidernet-io/bmc/pkg/hardware"
+	bmcv1beta1 "github.com/spidernet-io/bmc/pkg/k8s/apis/bmc.spidernet.io/v1beta1"
+	"github.com/stmcginnis/gofish/common"
+	"github.com/stmcginnis/gofish/redfish"
 )
 
 func setData(result map[string]string, key, value string) {
@@ -20,54 +27,92 @@ const (
 	DeviceType_NIC     = "NIC"
 )
 
-func (c *redfishClient) GetInfo() (map[string]string, error) {
+// present returns whether the component is installed, the empty slots are reported as Absent
+func present(status common.Status) bool {
+	return status.State != common.AbsentState
+}
+
+// the location identifies the component in the host, it falls back to the Id of the resource
+func cpuLocation(cpu *redfish.Processor) string {
+	if cpu.Socket != "" {
+		return cpu.Socket
+	}
+	if cpu.Location.PartLocation.ServiceLabel != "" {
+		return cpu.Location.PartLocation.ServiceLabel
+	}
+	return cpu.ID
+}
+
+func memoryLocation(mm *redfish.Memory) string {
+	if mm.DeviceLocator != "" {
+		return mm.DeviceLocator
+	}
+	if mm.Location.PartLocation.ServiceLabel != "" {
+		return mm.Location.PartLocation.ServiceLabel
+	}
+	return mm.ID
+}
+
+func pcieLocation(d *redfish.PCIeDevice) string {
+	if d.Slot.Location.PartLocation.ServiceLabel != "" {
+		return d.Slot.Location.PartLocation.ServiceLabel
+	}
+	return d.ID
+}
+
+// GetInventory returns the slow-changing inventory of the host, the state of the host is returned by GetState.
+// the collections are expanded in one request and fetched with ETag when the BMC supports them
+func (c *redfishClient) GetInventory() (map[string]string, []bmcv1beta1.HardwareComponent, error) {
 
 	result := map[string]string{}
+	components := []bmcv1beta1.HardwareComponent{}
 
 	// Attached the client to service root
 	service := c.client.Service
+	systemURI, managerURI, err := c.discover()
+	if err != nil {
+		return nil, nil, err
+	}
 
-	// Query the managers for bmc
-	managers, err := service.Managers()
+	// Query the bmc
+	body, err := c.getResource(managerURI)
 	if err != nil {
 		c.logger.Errorf("failed to Query the bmc : %+v", err)
-		return nil, err
-	} else if len(managers) == 0 {
-		c.logger.Errorf("failed to get bmc")
-		return nil, fmt.Errorf("failed to get bmc")
+		return nil, nil, err
+	}
+	bmc := &redfish.Manager{}
+	if err := json.Unmarshal(body, bmc); err != nil {
+		return nil, nil, err
 	}
-	c.logger.Debugf("bmc amount: %d", len(managers))
-	// for n, t := range managers {
-	// 	c.logger.Debugf("bmc[%d]: %+v", n, *t)
-	// }
-
-	bmc := managers[0]
 	// bmc info
 	setData(result, "BmcFirmwareVersion", bmc.FirmwareVersion)
-	setData(result, "BmcStatus", string(bmc.Status.Health))
+	components = append(components, bmcv1beta1.HardwareComponent{
+		Type:            bmcv1beta1.HardwareTypeBMC,
+		Location:        "Manager",
+		Model:           bmc.Model,
+		FirmwareVersion: bmc.FirmwareVersion,
+	})
 
-	// Query the computer systems
-	ss, err := service.Systems()
+	// Query the computer system, for barel metal case, there is only one system
+	body, err = c.getResource(systemURI)
 	if err != nil {
 		c.logger.Errorf("failed to Query the computer systems: %+v", err)
-		return nil, err
-	} else if len(ss) == 0 {
-		c.logger.Errorf("failed to get system")
-		return nil, fmt.Errorf("failed to get system")
+		return nil, nil, err
+	}
+	system := &redfish.ComputerSystem{}
+	if err := json.Unmarshal(body, system); err != nil {
+		return nil, nil, err
 	}
-	c.logger.Debugf("system amount: %d", len(ss))
-	// for n, t := range ss {
-	// 	c.logger.Debugf("systems[%d]: %+v", n, *t)
-	// }
-
-	// for barel metal case,
-	system := ss[0]
 	// basic info
 	setData(result, "BiosVerison", system.BIOSVersion)
+	components = append(components, bmcv1beta1.HardwareComponent{
+		Type:            bmcv1beta1.HardwareTypeBIOS,
+		Location:        "System",
+		FirmwareVersion: system.BIOSVersion,
+	})
 	setData(result, "HostName", system.HostName)
 	setData(result, "Manufacturer", system.Manufacturer)
-	setData(result, "PowerState", string(system.PowerState))
-	setData(result, "SyatemStatus", string(system.Status.Health))
+	setData(result, "Model", system.Model)
 	setData(result, "RedfishVersion", service.RedfishVersion)
 	setData(result, "Vendor", service.Vendor)
 
@@ -76,13 +121,29 @@ func (c *redfishClient) GetInfo() (map[string]string, error) {
 	setData(result, "CpuLogicalCore", fmt.Sprintf("%d", system.ProcessorSummary.LogicalProcessorCount))
 	setData(result, "CpuModel", system.ProcessorSummary.Model)
 	setData(result, "CpuStatus", string(system.ProcessorSummary.Status.Health))
-	cpus, err := system.Processors()
+	members, err := c.getMembers(linkOf(body, "Processors"))
+	if err != nil {
+		c.logger.Errorf("failed to get processors: %+v", err)
+		return nil, nil, err
+	}
+	cpus, err := decodeMembers[redfish.Processor](members)
 	if err != nil {
 		c.logger.Errorf("failed to get processors: %+v", err)
-		return nil, err
+		return nil, nil, err
 	}
 	c.logger.Debugf("cpus amount: %d", len(cpus))
+	sort.SliceStable(cpus, func(i, j int) bool { return cpuLocation(cpus[i]) < cpuLocation(cpus[j]) })
 	for n, cpu := range cpus {
+		if present(cpu.Status) {
+			components = append(components, bmcv1beta1.HardwareComponent{
+				Type:            bmcv1beta1.HardwareTypeCPU,
+				Location:        cpuLocation(cpu),
+				SerialNumber:    cpu.SerialNumber,
+				Model:           cpu.Model,
+				Manufacturer:    cpu.Manufacturer,
+				FirmwareVersion: cpu.FirmwareVersion,
+			})
+		}
 		//c.logger.Debugf("Cpu[%d]: %+v", n, cpu)
 		setData(result, fmt.Sprintf("Cpu[%d].Manufacturer", n), string(cpu.Manufacturer))
 		setData(result, fmt.Sprintf("Cpu[%d].ProcessorType", n), string(cpu.ProcessorType))
@@ -99,14 +160,30 @@ func (c *redfishClient) GetInfo() (map[string]string, error) {
 	// memory info
 	setData(result, "MemoryTotalGiB", fmt.Sprintf("%.0f", system.MemorySummary.TotalSystemMemoryGiB))
 	setData(result, "MemoryStatus", string(system.MemorySummary.Status.Health))
-	mms, err := system.Memory()
+	members, err = c.getMembers(linkOf(body, "Memory"))
 	if err != nil {
 		c.logger.Errorf("failed to get memory: %+v", err)
-		return nil, err
+		return nil, nil, err
+	}
+	mms, err := decodeMembers[redfish.Memory](members)
+	if err != nil {
+		c.logger.Errorf("failed to get memory: %+v", err)
+		return nil, nil, err
 	}
 	setData(result, "MemoryChipsAccount", fmt.Sprintf("%d", len(mms)))
-	//在内存条不变时，有时数组的顺序的变换，导致 后续 hoststatus 会做无意义的更新，暂时 取消这些信息
+	// 在内存条不变时，BMC 返回的数组顺序有时会变化，按位置排序，避免 hoststatus 做无意义的更新
+	sort.SliceStable(mms, func(i, j int) bool { return memoryLocation(mms[i]) < memoryLocation(mms[j]) })
 	for n, mm := range mms {
+		if present(mm.Status) {
+			components = append(components, bmcv1beta1.HardwareComponent{
+				Type:            bmcv1beta1.HardwareTypeMemory,
+				Location:        memoryLocation(mm),
+				SerialNumber:    mm.SerialNumber,
+				Model:           mm.PartNumber,
+				Manufacturer:    mm.Manufacturer,
+				FirmwareVersion: mm.FirmwareRevision,
+			})
+		}
 		//c.logger.Debugf("Memory[%d]: %+v", n, mm)
 		setData(result, fmt.Sprintf("Memory[%d].Manufacturer", n), string(mm.Manufacturer))
 		setData(result, fmt.Sprintf("Memory[%d].MemoryType", n), string(mm.MemoryType))
@@ -125,14 +202,27 @@ func (c *redfishClient) GetInfo() (map[string]string, error) {
 	}
 
 	// storage info
-	stroages, err := system.SimpleStorages()
+	members, err = c.getMembers(linkOf(body, "SimpleStorage"))
 	if err != nil {
 		c.logger.Errorf("failed to get simple storage: %+v", err)
-		return nil, err
+		return nil, nil, err
+	}
+	stroages, err := decodeMembers[redfish.SimpleStorage](members)
+	if err != nil {
+		c.logger.Errorf("failed to get simple storage: %+v", err)
+		return nil, nil, err
 	}
 	c.logger.Debugf("simple storage amount: %d", len(stroages))
 	for n, st := range stroages {
 		for m, item := range st.Devices {
+			if present(item.Status) {
+				components = append(components, bmcv1beta1.HardwareComponent{
+					Type:         bmcv1beta1.HardwareTypeDisk,
+					Location:     st.ID + "/" + item.Name,
+					Model:        item.Model,
+					Manufacturer: item.Manufacturer,
+				})
+			}
 			c.logger.Debugf("Storage[%d][%d]: %+v", n, m, item)
 			setData(result, fmt.Sprintf("Storage[%d].Device[%d].Name", n, m), string(item.Name))
 			setData(result, fmt.Sprintf("Storage[%d].Device[%d].TotalGiB", n, m), fmt.Sprintf("%.2f", float64(item.CapacityBytes)/(1024*1024*1024)))
@@ -171,33 +261,56 @@ func (c *redfishClient) GetInfo() (map[string]string, error) {
 	// }
 
 	// pcie info
-	cs, err := service.Chassis()
+	cs, err := c.getMembers(chassisURI)
 	if err != nil {
 		c.logger.Errorf("failed to get chassis: %+v", err)
-		return nil, err
+		return nil, nil, err
 	}
 	c.logger.Debugf("chassis amount: %d", len(cs))
 	for count, chassis := range cs {
-		pcieList, err := chassis.PCIeDevices()
-		if err != nil {
-			c.logger.Errorf("failed to get pcie devices: %+v", err)
-			return nil, err
+		var pcieList []*redfish.PCIeDevice
+		if uri := linkOf(chassis, "PCIeDevices"); uri != "" {
+			members, err := c.getMembers(uri)
+			if err != nil {
+				c.logger.Errorf("failed to get pcie devices: %+v", err)
+				return nil, nil, err
+			}
+			if pcieList, err = decodeMembers[redfish.PCIeDevice](members); err != nil {
+				c.logger.Errorf("failed to get pcie devices: %+v", err)
+				return nil, nil, err
+			}
 		}
 		c.logger.Debugf("chassis[%d] pcie devices amount: %d", count, len(pcieList))
 		if len(pcieList) == 0 {
 			continue
 		}
 
+		sort.SliceStable(pcieList, func(i, j int) bool { return pcieLocation(pcieList[i]) < pcieLocation(pcieList[j]) })
+		for _, item := range pcieList {
+			if present(item.Status) {
+				components = append(components, bmcv1beta1.HardwareComponent{
+					Type:            bmcv1beta1.HardwareTypePCIeDevice,
+					Location:        pcieLocation(item),
+					SerialNumber:    item.SerialNumber,
+					Model:           item.Model,
+					Manufacturer:    item.Manufacturer,
+					FirmwareVersion: item.FirmwareVersion,
+				})
+			}
+		}
+
 	LOOP_PCIEDEVICE:
 		for m, item := range pcieList {
+			item.SetClient(c.client)
 			// c.logger.Debugf("PCIeDevices[%d]: %+v", m, item)
 
+			// the description is compared in lower case, the BMCs differ in the case of it
 			switch strings.ToLower(item.Description) {
-			case "GPU Device":
+			case "gpu device":
 				setData(result, fmt.Sprintf("PCIeDevices[%d].DeviceType", m), DeviceType_GPU)
-			case "NVMeSSD Device":
+			case "nvmessd device":
 				setData(result, fmt.Sprintf("PCIeDevices[%d].DeviceType", m), DeviceType_Storage)
-			case "NIC device":
+			case "nic device":
 				setData(result, fmt.Sprintf("PCIeDevices[%d].DeviceType", m), DeviceType_NIC)
 			default:
 				setData(result, fmt.Sprintf("PCIeDevices[%d].DeviceType", m), DeviceType_Unknown)
@@ -255,5 +368,6 @@ func (c *redfishClient) GetInfo() (map[string]string, error) {
 
 	// ?? 是否可以取出安装的 os 信息
 
-	return result, nil
+	hardware.Sort(components)
+	return result, components, nil
 }
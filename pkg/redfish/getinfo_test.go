@@ -0,0 +1,60 @@
+package redfish_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spidernet-io/bmc/pkg/controller/nodemapping"
+	bmcv1beta1 "github.com/spidernet-io/bmc/pkg/k8s/apis/bmc.spidernet.io/v1beta1"
+)
+
+// inventoryBMC serves a host with two GPUs and a NIC
+func inventoryBMC() *fakeBMC {
+	bmc := newFakeBMC()
+	member := func(uri string) map[string]interface{} {
+		return map[string]interface{}{"Members": []map[string]string{{"@odata.id": uri}}, "Members@odata.count": 1}
+	}
+	bmc.set("/redfish/v1/Systems", member("/redfish/v1/Systems/1"))
+	bmc.set("/redfish/v1/Systems/1", map[string]interface{}{
+		"@odata.id": "/redfish/v1/Systems/1", "Id": "1", "Manufacturer": "Dell Inc.", "Model": "PowerEdge XE9680",
+		"BiosVersion":      "2.1.0",
+		"ProcessorSummary": map[string]interface{}{"Count": 2, "Model": "Xeon"},
+		"MemorySummary":    map[string]interface{}{"TotalSystemMemoryGiB": 512},
+	})
+	bmc.set("/redfish/v1/Managers", member("/redfish/v1/Managers/1"))
+	bmc.set("/redfish/v1/Managers/1", map[string]interface{}{"@odata.id": "/redfish/v1/Managers/1", "Id": "1", "FirmwareVersion": "7.00"})
+	bmc.set("/redfish/v1/Chassis", member("/redfish/v1/Chassis/1"))
+	bmc.set("/redfish/v1/Chassis/1", map[string]interface{}{
+		"@odata.id": "/redfish/v1/Chassis/1", "Id": "1",
+		"PCIeDevices": map[string]string{"@odata.id": "/redfish/v1/Chassis/1/PCIeDevices"},
+	})
+	devices := []map[string]string{}
+	for id, d := range map[string][2]string{
+		"gpu1": {"GPU Device", "H100"},
+		"gpu2": {"gpu device", "H100"},
+		"nic1": {"NIC Device", "ConnectX-7"},
+	} {
+		uri := "/redfish/v1/Chassis/1/PCIeDevices/" + id
+		devices = append(devices, map[string]string{"@odata.id": uri})
+		bmc.set(uri, map[string]interface{}{"@odata.id": uri, "Id": id, "Description": d[0], "Model": d[1]})
+	}
+	bmc.set("/redfish/v1/Chassis/1/PCIeDevices", map[string]interface{}{"Members": devices, "Members@odata.count": len(devices)})
+	return bmc
+}
+
+var _ = Describe("GetInventory", Label("unitest"), func() {
+	It("classifies the PCIe devices by the description in any case", func() {
+		bmc := inventoryBMC()
+		DeferCleanup(bmc.Close)
+
+		info, components, err := bmc.client().GetInventory()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(info).To(HaveKeyWithValue("PCIeDevices[0].DeviceType", "GPU"))
+		Expect(info).To(HaveKeyWithValue("PCIeDevices[1].DeviceType", "GPU"))
+		Expect(info).To(HaveKeyWithValue("PCIeDevices[2].DeviceType", "NIC"))
+		Expect(components).To(ContainElement(HaveField("Type", bmcv1beta1.HardwareTypePCIeDevice)))
+
+		facts := nodemapping.Facts(info)
+		Expect(facts).To(HaveKeyWithValue(nodemapping.FactGPUCount, "2"))
+		Expect(facts).To(HaveKeyWithValue(nodemapping.FactGPUModel, "H100"))
+	})
+})
@@ -0,0 +1,89 @@
+package redfish
+
+import (
+	"encoding/json"
+
+	bmcv1beta1 "github.com/spidernet-io/bmc/pkg/k8s/apis/bmc.spidernet.io/v1beta1"
+)
+
+// GetIdentity returns the system UUID, the chassis serial and the BMC MAC, which identify the machine regardless of its IP
+func (c *redfishClient) GetIdentity() (*bmcv1beta1.IdentityStatus, error) {
+	systemURI, managerURI, err := c.discover()
+	if err != nil {
+		return nil, err
+	}
+	result := &bmcv1beta1.IdentityStatus{}
+
+	system := struct {
+		UUID string
+	}{}
+	if err := c.getSelected(systemURI, &system, "UUID"); err != nil {
+		c.logger.Errorf("failed to Query the computer systems: %+v", err)
+		return nil, err
+	}
+	result.SystemUUID = system.UUID
+
+	body, err := c.getResource(systemURI)
+	if err != nil {
+		c.logger.Errorf("failed to Query the computer systems: %+v", err)
+		return nil, err
+	}
+	nics, err := c.getMembers(linkOf(body, "EthernetInterfaces"))
+	if err != nil {
+		c.logger.Errorf("failed to get the network interfaces of the computer system: %+v", err)
+		return nil, err
+	}
+	for _, item := range nics {
+		if mac := macOf(item); mac != "" {
+			result.HostMacs = append(result.HostMacs, mac)
+		}
+	}
+
+	chassis, err := c.getMembers(chassisURI)
+	if err != nil {
+		c.logger.Errorf("failed to get chassis: %+v", err)
+		return nil, err
+	}
+	if len(chassis) > 0 {
+		// the first chassis is the enclosure of the bare metal
+		t := struct {
+			SerialNumber string
+		}{}
+		if err := json.Unmarshal(chassis[0], &t); err != nil {
+			return nil, err
+		}
+		result.ChassisSerial = t.SerialNumber
+	}
+
+	body, err = c.getResource(managerURI)
+	if err != nil {
+		c.logger.Errorf("failed to Query the bmc : %+v", err)
+		return nil, err
+	}
+	interfaces, err := c.getMembers(linkOf(body, "EthernetInterfaces"))
+	if err != nil {
+		c.logger.Errorf("failed to get the network interfaces of bmc: %+v", err)
+		return nil, err
+	}
+	for _, item := range interfaces {
+		if result.BmcMac = macOf(item); result.BmcMac != "" {
+			break
+		}
+	}
+	return result, nil
+}
+
+// macOf returns the permanent MAC address of the network interface, or the current one when it is not reported
+func macOf(raw json.RawMessage) string {
+	t := struct {
+		MACAddress          string
+		PermanentMACAddress string
+	}{}
+	if err := json.Unmarshal(raw, &t); err != nil {
+		return ""
+	}
+	if t.PermanentMACAddress != "" {
+		return t.PermanentMACAddress
+	}
+	return t.MACAddress
+}
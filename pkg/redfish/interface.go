@@ -4,17 +4,32 @@ import (
 	"fmt"
 	"github.com/stmcginnis/gofish/redfish"
 	"reflect"
+	"sync"
 
 	"github.com/spidernet-io/bmc/pkg/agent/hoststatus/data"
+	bmcv1beta1 "github.com/spidernet-io/bmc/pkg/k8s/apis/bmc.spidernet.io/v1beta1"
 	"github.com/stmcginnis/gofish"
 	"go.uber.org/zap"
 )
 
 // Client 定义了 Redfish 客户端接口
 type RefishClient interface {
-	Power(string) error
-	GetInfo() (map[string]string, error)
+	Power(string) (string, error)
+	GetTask(monitor string) (*bmcv1beta1.TaskStatus, error)
+	CancelTask(monitor string) error
+	GetPowerState() (*bmcv1beta1.PowerStateRecord, error)
+	GetState() (map[string]string, error)
+	GetInventory() (map[string]string, []bmcv1beta1.HardwareComponent, error)
+	GetIdentity() (*bmcv1beta1.IdentityStatus, error)
 	GetLog() ([]*redfish.LogEntry, error)
+	GetPower() (*bmcv1beta1.PowerStatus, error)
+	SetPowerLimit(limitInWatts *int32, limitException string) error
+	ResetBmc(action string) error
+	ResetBmcToDefaults(resetType string) error
+	SetLocateIndicator(action string) error
+	GetLocation() (*bmcv1beta1.LocationStatus, error)
+	GetProperty(uri, property string) (string, error)
+	GetCapabilities() (*bmcv1beta1.CapabilitiesStatus, error)
 }
 
 // redfishClient 实现了 Client 接口
@@ -22,12 +37,23 @@ type redfishClient struct {
 	config gofish.ClientConfig
 	logger *zap.SugaredLogger
 	client *gofish.APIClient
+	// the URI of the system and the BMC
+	systemURI  string
+	managerURI string
+	// the URI of the power resource of the chassis, which reports the power supplies
+	powerURI string
+	// the last responses with ETag, for the conditional requests
+	lock      sync.Mutex
+	resources map[string]*cachedResource
 }
 
 var _ RefishClient = (*redfishClient)(nil)
 
 var CacheClient = make(map[string]*redfishClient)
 
+// cacheLock guards CacheClient, which is shared by the controllers running concurrently
+var cacheLock sync.Mutex
+
 // NewClient 创建一个新的 Redfish 客户端
 func NewClient(hostCon data.HostConnectCon, log *zap.SugaredLogger) (RefishClient, error) {
 
@@ -40,9 +66,13 @@ func NewClient(hostCon data.HostConnectCon, log *zap.SugaredLogger) (RefishClien
 		ReuseConnections: true,
 	}
 
-	if c, ok := CacheClient[hostCon.Info.IpAddr]; ok {
+	cacheLock.Lock()
+	c, ok := CacheClient[hostCon.Info.IpAddr]
+	cacheLock.Unlock()
+	if ok {
 		if reflect.DeepEqual(config, c.config) {
-			_, err := c.client.Service.Systems()
+			// only the collection is fetched to check whether the session is still valid
+			_, err := c.getResource(systemsURI)
 			if err == nil {
 				log.Debugf("use cached redfish client for %s", hostCon.Info.IpAddr)
 				return c, nil
@@ -50,26 +80,40 @@ func NewClient(hostCon data.HostConnectCon, log *zap.SugaredLogger) (RefishClien
 		}
 		log.Debugf("logout invalid cached redfish client for %s", hostCon.Info.IpAddr)
 		c.client.Logout()
-		delete(CacheClient, hostCon.Info.IpAddr)
+		c.invalidate()
 	}
 
 	log.Debugf("create new redfish client for %s", hostCon.Info.IpAddr)
 	client, err := gofish.Connect(config)
 	if err != nil {
-		return nil, fmt.Errorf("failed to connect: %+v", err)
+		return nil, fmt.Errorf("failed to connect: %w", err)
 	}
-	c := &redfishClient{
+	c = &redfishClient{
 		config: config,
 		logger: log.Named("redfish").With(
 			zap.String("endpoint", url),
 		),
-		client: client,
+		client:    client,
+		resources: map[string]*cachedResource{},
 	}
 
+	cacheLock.Lock()
 	CacheClient[hostCon.Info.IpAddr] = c
+	cacheLock.Unlock()
 	return c, nil
 }
 
+// invalidate removes the client from the cache, the session is lost after the BMC restarts
+func (c *redfishClient) invalidate() {
+	cacheLock.Lock()
+	defer cacheLock.Unlock()
+	for ip, t := range CacheClient {
+		if t == c {
+			delete(CacheClient, ip)
+		}
+	}
+}
+
 // buildEndpoint 根据 HostConnectCon 构建 Redfish 服务的端点 URL
 func buildEndpoint(hostCon data.HostConnectCon) string {
 	protocol := "http"
@@ -0,0 +1,270 @@
+package redfish
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/stmcginnis/gofish/common"
+)
+
+const (
+	// the collections are at the fixed path of the redfish specification
+	systemsURI  = "/redfish/v1/Systems"
+	managersURI = "/redfish/v1/Managers"
+	chassisURI  = "/redfish/v1/Chassis"
+)
+
+// cachedResource is the last response of a resource with its ETag
+type cachedResource struct {
+	etag string
+	body []byte
+}
+
+// getResource GETs the resource, it sends the ETag of the last response and reuses the body when the BMC
+// answers 304 Not Modified
+func (c *redfishClient) getResource(uri string) ([]byte, error) {
+	c.lock.Lock()
+	cached := c.resources[uri]
+	c.lock.Unlock()
+
+	headers := map[string]string{}
+	if cached != nil {
+		headers["If-None-Match"] = cached.etag
+	}
+	resp, err := c.client.GetWithHeaders(uri, headers)
+	if err != nil {
+		var rfErr *common.Error
+		if cached != nil && errors.As(err, &rfErr) && rfErr.HTTPReturnedStatusCode == http.StatusNotModified {
+			c.logger.Debugf("%s is not modified", uri)
+			return cached.body, nil
+		}
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if etag := resp.Header.Get("ETag"); etag != "" {
+		c.resources[uri] = &cachedResource{etag: etag, body: body}
+	} else {
+		delete(c.resources, uri)
+	}
+	return body, nil
+}
+
+// getSelected GETs the properties of the resource, only the properties are returned when the BMC supports $select
+func (c *redfishClient) getSelected(uri string, v interface{}, properties ...string) error {
+	if c.client.Service.ProtocolFeaturesSupported.SelectQuery {
+		uri = uri + "?$select=" + strings.Join(properties, ",")
+	}
+	body, err := c.getResource(uri)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(body, v)
+}
+
+// linkOf returns the URI of the property linking to another resource, such as "Processors" of the system
+func linkOf(raw []byte, name string) string {
+	m := map[string]json.RawMessage{}
+	if err := json.Unmarshal(raw, &m); err != nil {
+		return ""
+	}
+	link := common.Link("")
+	if err := json.Unmarshal(m[name], &link); err != nil {
+		return ""
+	}
+	return link.String()
+}
+
+// getMembers returns the members of the collection, they are expanded in one request when the BMC supports $expand,
+// otherwise they are fetched one by one, and nothing is returned when the link is empty
+func (c *redfishClient) getMembers(uri string) ([]json.RawMessage, error) {
+	if uri == "" {
+		return nil, nil
+	}
+	collection := struct {
+		Members []json.RawMessage
+	}{}
+
+	expand := c.client.Service.ProtocolFeaturesSupported.ExpandQuery
+	if expand.ExpandAll || expand.NoLinks {
+		query := "?$expand=."
+		if !expand.NoLinks {
+			query = "?$expand=*"
+		}
+		body, err := c.getResource(uri + query)
+		if err == nil && json.Unmarshal(body, &collection) == nil {
+			if len(collection.Members) == 0 || hasProperty(collection.Members[0], "Id") {
+				return collection.Members, nil
+			}
+		}
+		c.logger.Debugf("failed to expand %s, fetch the members one by one: %v", uri, err)
+	}
+
+	body, err := c.getResource(uri)
+	if err != nil {
+		return nil, err
+	}
+	links := struct {
+		Members common.Links
+	}{}
+	if err := json.Unmarshal(body, &links); err != nil {
+		return nil, err
+	}
+	result := make([]json.RawMessage, 0, len(links.Members))
+	for _, link := range links.Members {
+		member, err := c.getResource(link.String())
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, member)
+	}
+	return result, nil
+}
+
+// decodeMembers decodes the members of a collection into the redfish resources
+func decodeMembers[T any](members []json.RawMessage) ([]*T, error) {
+	result := make([]*T, 0, len(members))
+	for _, m := range members {
+		t := new(T)
+		if err := json.Unmarshal(m, t); err != nil {
+			return nil, err
+		}
+		result = append(result, t)
+	}
+	return result, nil
+}
+
+// firstMember returns the URI of the first member of the collection
+func (c *redfishClient) firstMember(uri string) (string, error) {
+	body, err := c.getResource(uri)
+	if err != nil {
+		return "", err
+	}
+	links := struct {
+		Members common.Links
+	}{}
+	if err := json.Unmarshal(body, &links); err != nil {
+		return "", err
+	}
+	if len(links.Members) == 0 {
+		return "", fmt.Errorf("no member found in %s", uri)
+	}
+	return links.Members[0].String(), nil
+}
+
+// discover returns the URI of the system and the BMC, which do not change during the session.
+// the client is shared by the controllers, so the URIs are guarded by the lock
+func (c *redfishClient) discover() (string, string, error) {
+	c.lock.Lock()
+	system, manager := c.systemURI, c.managerURI
+	c.lock.Unlock()
+	if system != "" && manager != "" {
+		return system, manager, nil
+	}
+	system, err := c.firstMember(systemsURI)
+	if err != nil {
+		c.logger.Errorf("failed to Query the computer systems: %+v", err)
+		return "", "", err
+	}
+	manager, err = c.firstMember(managersURI)
+	if err != nil {
+		c.logger.Errorf("failed to Query the bmc : %+v", err)
+		return "", "", err
+	}
+	c.lock.Lock()
+	c.systemURI, c.managerURI = system, manager
+	c.lock.Unlock()
+	return system, manager, nil
+}
+
+// powerSupplyHealth returns the worst health of the present power supplies of the chassis,
+// it is empty when the BMC does not report the power supplies
+func (c *redfishClient) powerSupplyHealth() (string, error) {
+	c.lock.Lock()
+	powerURI := c.powerURI
+	c.lock.Unlock()
+	if powerURI == "" {
+		chassis, err := c.firstMember(chassisURI)
+		if err != nil {
+			return "", err
+		}
+		body, err := c.getResource(chassis)
+		if err != nil {
+			return "", err
+		}
+		if powerURI = linkOf(body, "Power"); powerURI == "" {
+			return "", nil
+		}
+		c.lock.Lock()
+		c.powerURI = powerURI
+		c.lock.Unlock()
+	}
+	power := struct {
+		PowerSupplies []struct {
+			Status common.Status
+		}
+	}{}
+	if err := c.getSelected(powerURI, &power, "PowerSupplies"); err != nil {
+		return "", err
+	}
+	rank := map[common.Health]int{common.OKHealth: 1, common.WarningHealth: 2, common.CriticalHealth: 3}
+	var worst common.Health
+	for _, psu := range power.PowerSupplies {
+		if present(psu.Status) && rank[psu.Status.Health] > rank[worst] {
+			worst = psu.Status.Health
+		}
+	}
+	return string(worst), nil
+}
+
+// GetState returns the fast-changing state of the host, which is the power state and the health rollup
+// of the system, the memory, the power supplies and the BMC
+func (c *redfishClient) GetState() (map[string]string, error) {
+	systemURI, managerURI, err := c.discover()
+	if err != nil {
+		return nil, err
+	}
+	result := map[string]string{}
+
+	system := struct {
+		PowerState    string
+		Status        common.Status
+		MemorySummary struct {
+			Status common.Status
+		}
+	}{}
+	if err := c.getSelected(systemURI, &system, "PowerState", "Status", "MemorySummary"); err != nil {
+		c.logger.Errorf("failed to get the state of system: %+v", err)
+		return nil, err
+	}
+	setData(result, "PowerState", system.PowerState)
+	setData(result, "SyatemStatus", string(system.Status.Health))
+	setData(result, "MemoryStatus", string(system.MemorySummary.Status.Health))
+
+	// the failure of a redundant power supply is not reported by the system on some BMCs
+	if health, err := c.powerSupplyHealth(); err != nil {
+		c.logger.Debugf("failed to get the power supplies: %+v", err)
+	} else {
+		setData(result, "PowerSupplyStatus", health)
+	}
+
+	bmc := struct {
+		Status common.Status
+	}{}
+	if err := c.getSelected(managerURI, &bmc, "Status"); err != nil {
+		c.logger.Errorf("failed to get the state of bmc: %+v", err)
+		return nil, err
+	}
+	setData(result, "BmcStatus", string(bmc.Status.Health))
+	return result, nil
+}
@@ -0,0 +1,113 @@
+package redfish_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Inventory", Label("unitest"), func() {
+	var (
+		bmc *fakeBMC
+		// the system served with an ETag, which changes with the power state
+		lock       sync.Mutex
+		powerState string
+		etag       string
+	)
+
+	BeforeEach(func() {
+		bmc = newFakeBMC()
+		DeferCleanup(bmc.Close)
+		bmc.set("/redfish/v1/Systems", map[string]interface{}{
+			"Members": []map[string]string{{"@odata.id": "/redfish/v1/Systems/1"}},
+		})
+		bmc.set("/redfish/v1/Managers", map[string]interface{}{
+			"Members": []map[string]string{{"@odata.id": "/redfish/v1/Managers/1"}},
+		})
+		bmc.set("/redfish/v1/Managers/1", map[string]interface{}{
+			"Id":     "1",
+			"Status": map[string]string{"Health": "OK"},
+		})
+		powerState, etag = "On", `"v1"`
+		bmc.handle("/redfish/v1/Systems/1", func(w http.ResponseWriter, req *http.Request) {
+			lock.Lock()
+			defer lock.Unlock()
+			if etag != "" && req.Header.Get("If-None-Match") == etag {
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+			if etag != "" {
+				w.Header().Set("ETag", etag)
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"Id":         "1",
+				"PowerState": powerState,
+				"Status":     map[string]string{"Health": "OK"},
+			})
+		})
+	})
+
+	change := func(state, tag string) {
+		lock.Lock()
+		defer lock.Unlock()
+		powerState, etag = state, tag
+	}
+	// systemRequests returns the If-None-Match headers of the requests of the system
+	systemRequests := func() []string {
+		result := []string{}
+		for _, r := range bmc.received(http.MethodGet) {
+			if r.Path == "/redfish/v1/Systems/1" {
+				result = append(result, r.Header.Get("If-None-Match"))
+			}
+		}
+		return result
+	}
+
+	It("reuses the last response when the BMC answers 304 Not Modified", func() {
+		c := bmc.client()
+		state, err := c.GetState()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(state["PowerState"]).To(Equal("On"))
+
+		state, err = c.GetState()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(state["PowerState"]).To(Equal("On"))
+		Expect(state["BmcStatus"]).To(Equal("OK"))
+		Expect(systemRequests()).To(Equal([]string{"", `"v1"`}))
+	})
+
+	It("reads the resource again when its ETag changes", func() {
+		c := bmc.client()
+		_, err := c.GetState()
+		Expect(err).NotTo(HaveOccurred())
+
+		change("Off", `"v2"`)
+		state, err := c.GetState()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(state["PowerState"]).To(Equal("Off"))
+
+		state, err = c.GetState()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(state["PowerState"]).To(Equal("Off"))
+		Expect(systemRequests()).To(Equal([]string{"", `"v1"`, `"v2"`}))
+	})
+
+	It("does not send a conditional request when the BMC stops sending the ETag", func() {
+		c := bmc.client()
+		_, err := c.GetState()
+		Expect(err).NotTo(HaveOccurred())
+
+		change("Off", "")
+		state, err := c.GetState()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(state["PowerState"]).To(Equal("Off"))
+
+		_, err = c.GetState()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(systemRequests()).To(Equal([]string{"", `"v1"`, ""}))
+	})
+})
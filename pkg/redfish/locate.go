@@ -0,0 +1,131 @@
+package redfish
+
+import (
+	"encoding/json"
+	"fmt"
+
+	bmcv1beta1 "github.com/spidernet-io/bmc/pkg/k8s/apis/bmc.spidernet.io/v1beta1"
+	"github.com/stmcginnis/gofish/common"
+)
+
+// hasProperty checks whether the raw data of a resource reports the property
+func hasProperty(raw []byte, name string) bool {
+	m := map[string]json.RawMessage{}
+	if err := json.Unmarshal(raw, &m); err != nil {
+		return false
+	}
+	_, ok := m[name]
+	return ok
+}
+
+// setIndicator updates the IndicatorLED when the resource reports it, and falls back to
+// LocationIndicatorActive which replaces the deprecated IndicatorLED since Redfish 2020.3
+// it returns false when the resource supports neither of them
+func setIndicator(raw []byte, led *common.IndicatorLED, active *bool, state common.IndicatorLED) bool {
+	if *led != "" {
+		*led = state
+		return true
+	}
+	if hasProperty(raw, "LocationIndicatorActive") {
+		*active = state != common.OffIndicatorLED
+		return true
+	}
+	return false
+}
+
+// SetLocateIndicator lights, blinks or turns off the locate indicator of the system and chassis
+func (c *redfishClient) SetLocateIndicator(action string) error {
+	var state common.IndicatorLED
+	switch action {
+	case bmcv1beta1.ActionLocateOn:
+		state = common.LitIndicatorLED
+	case bmcv1beta1.ActionLocateBlink:
+		state = common.BlinkingIndicatorLED
+	case bmcv1beta1.ActionLocateOff:
+		state = common.OffIndicatorLED
+	default:
+		return fmt.Errorf("unknown locate action: %s", action)
+	}
+
+	service := c.client.Service
+	updated := 0
+
+	ss, err := service.Systems()
+	if err != nil {
+		c.logger.Errorf("failed to Query the computer systems: %+v", err)
+		return err
+	}
+	if len(ss) > 0 {
+		system := ss[0]
+		if setIndicator(system.RawData, &system.IndicatorLED, &system.LocationIndicatorActive, state) {
+			c.logger.Infof("set locate indicator of system %s to %s", system.ID, state)
+			if err := system.Update(); err != nil {
+				c.logger.Errorf("failed to set locate indicator of system %s: %+v", system.ID, err)
+				return err
+			}
+			updated++
+		}
+	}
+
+	cs, err := service.Chassis()
+	if err != nil {
+		c.logger.Errorf("failed to get chassis: %+v", err)
+		return err
+	}
+	for _, chassis := range cs {
+		if !setIndicator(chassis.RawData, &chassis.IndicatorLED, &chassis.LocationIndicatorActive, state) {
+			continue
+		}
+		c.logger.Infof("set locate indicator of chassis %s to %s", chassis.ID, state)
+		if err := chassis.Update(); err != nil {
+			c.logger.Errorf("failed to set locate indicator of chassis %s: %+v", chassis.ID, err)
+			return err
+		}
+		updated++
+	}
+
+	if updated == 0 {
+		return fmt.Errorf("neither the system nor the chassis supports the locate indicator")
+	}
+	return nil
+}
+
+// GetLocation returns the physical location of the host and the state of its locate indicator
+func (c *redfishClient) GetLocation() (*bmcv1beta1.LocationStatus, error) {
+	cs, err := c.client.Service.Chassis()
+	if err != nil {
+		c.logger.Errorf("failed to get chassis: %+v", err)
+		return nil, err
+	} else if len(cs) == 0 {
+		return nil, fmt.Errorf("failed to get chassis")
+	}
+
+	// the first chassis is the enclosure of the bare metal
+	chassis := cs[0]
+	l := chassis.Location
+	result := &bmcv1beta1.LocationStatus{
+		Building:        l.PostalAddress.Building,
+		Room:            l.PostalAddress.Room,
+		Row:             l.Placement.Row,
+		Rack:            l.Placement.Rack,
+		RackOffsetUnits: string(l.Placement.RackOffsetUnits),
+		Slot:            l.PartLocation.ServiceLabel,
+		Info:            l.Info,
+	}
+	if hasProperty(chassis.RawData, "Location") && l.Placement.Rack != "" {
+		t := int32(l.Placement.RackOffset)
+		result.RackOffset = &t
+	}
+
+	switch {
+	case chassis.IndicatorLED != "":
+		result.IndicatorLED = string(chassis.IndicatorLED)
+	case hasProperty(chassis.RawData, "LocationIndicatorActive"):
+		if chassis.LocationIndicatorActive {
+			result.IndicatorLED = string(common.LitIndicatorLED)
+		} else {
+			result.IndicatorLED = string(common.OffIndicatorLED)
+		}
+	}
+	return result, nil
+}
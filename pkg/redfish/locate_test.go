@@ -0,0 +1,166 @@
+package redfish_test
+
+import (
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	bmcv1beta1 "github.com/spidernet-io/bmc/pkg/k8s/apis/bmc.spidernet.io/v1beta1"
+	"k8s.io/utils/ptr"
+)
+
+var _ = Describe("Locate", Label("unitest"), func() {
+	const (
+		systemPath  = "/redfish/v1/Systems/1"
+		chassisPath = "/redfish/v1/Chassis/1"
+	)
+	var bmc *fakeBMC
+
+	// system serves the system with the properties of the indicator
+	system := func(properties map[string]interface{}) {
+		properties["@odata.id"] = systemPath
+		properties["Id"] = "1"
+		bmc.set(systemPath, properties)
+	}
+	chassis := func(properties map[string]interface{}) {
+		properties["@odata.id"] = chassisPath
+		properties["Id"] = "1"
+		bmc.set(chassisPath, properties)
+	}
+	// patched returns the payloads patched to the path
+	patched := func(path string) []map[string]interface{} {
+		result := []map[string]interface{}{}
+		for _, r := range bmc.received(http.MethodPatch) {
+			if r.Path == path {
+				result = append(result, r.Payload)
+			}
+		}
+		return result
+	}
+
+	BeforeEach(func() {
+		bmc = newFakeBMC()
+		DeferCleanup(bmc.Close)
+		bmc.set("/redfish/v1/Systems", map[string]interface{}{
+			"Members": []map[string]string{{"@odata.id": systemPath}},
+		})
+		bmc.set("/redfish/v1/Chassis", map[string]interface{}{
+			"Members": []map[string]string{{"@odata.id": chassisPath}},
+		})
+		// the system reports the deprecated IndicatorLED, and the chassis reports LocationIndicatorActive
+		system(map[string]interface{}{"IndicatorLED": "Off"})
+		chassis(map[string]interface{}{"LocationIndicatorActive": false})
+	})
+
+	Context("SetLocateIndicator", func() {
+		It("lights the IndicatorLED and the LocationIndicatorActive", func() {
+			Expect(bmc.client().SetLocateIndicator(bmcv1beta1.ActionLocateOn)).To(Succeed())
+			Expect(patched(systemPath)).To(Equal([]map[string]interface{}{{"IndicatorLED": "Lit"}}))
+			Expect(patched(chassisPath)).To(Equal([]map[string]interface{}{{"LocationIndicatorActive": true}}))
+		})
+
+		It("blinks the IndicatorLED, and activates the LocationIndicatorActive which does not blink", func() {
+			Expect(bmc.client().SetLocateIndicator(bmcv1beta1.ActionLocateBlink)).To(Succeed())
+			Expect(patched(systemPath)).To(Equal([]map[string]interface{}{{"IndicatorLED": "Blinking"}}))
+			Expect(patched(chassisPath)).To(Equal([]map[string]interface{}{{"LocationIndicatorActive": true}}))
+		})
+
+		It("turns off the indicators", func() {
+			system(map[string]interface{}{"IndicatorLED": "Blinking"})
+			chassis(map[string]interface{}{"LocationIndicatorActive": true})
+			Expect(bmc.client().SetLocateIndicator(bmcv1beta1.ActionLocateOff)).To(Succeed())
+			Expect(patched(systemPath)).To(Equal([]map[string]interface{}{{"IndicatorLED": "Off"}}))
+			Expect(patched(chassisPath)).To(Equal([]map[string]interface{}{{"LocationIndicatorActive": false}}))
+		})
+
+		It("prefers the IndicatorLED when the resource reports both", func() {
+			chassis(map[string]interface{}{"IndicatorLED": "Off", "LocationIndicatorActive": false})
+			Expect(bmc.client().SetLocateIndicator(bmcv1beta1.ActionLocateOn)).To(Succeed())
+			Expect(patched(chassisPath)).To(Equal([]map[string]interface{}{{"IndicatorLED": "Lit"}}))
+		})
+
+		It("skips the resource without an indicator", func() {
+			system(map[string]interface{}{})
+			Expect(bmc.client().SetLocateIndicator(bmcv1beta1.ActionLocateOn)).To(Succeed())
+			Expect(patched(systemPath)).To(BeEmpty())
+			Expect(patched(chassisPath)).To(HaveLen(1))
+		})
+
+		It("fails when neither the system nor the chassis has an indicator", func() {
+			system(map[string]interface{}{})
+			chassis(map[string]interface{}{})
+			Expect(bmc.client().SetLocateIndicator(bmcv1beta1.ActionLocateOn)).To(
+				MatchError("neither the system nor the chassis supports the locate indicator"))
+			Expect(bmc.received(http.MethodPatch)).To(BeEmpty())
+		})
+
+		It("returns the error of the BMC", func() {
+			bmc.handle(systemPath, func(w http.ResponseWriter, req *http.Request) {
+				if req.Method == http.MethodPatch {
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(`{"@odata.id": "/redfish/v1/Systems/1", "Id": "1", "IndicatorLED": "Off"}`))
+			})
+			Expect(bmc.client().SetLocateIndicator(bmcv1beta1.ActionLocateOn)).NotTo(Succeed())
+		})
+
+		It("refuses an unknown action", func() {
+			Expect(bmc.client().SetLocateIndicator(bmcv1beta1.BootCmdOn)).NotTo(Succeed())
+			Expect(bmc.received(http.MethodPatch)).To(BeEmpty())
+		})
+	})
+
+	Context("GetLocation", func() {
+		It("returns the location of the first chassis and its IndicatorLED", func() {
+			chassis(map[string]interface{}{
+				"IndicatorLED": "Blinking",
+				"Location": map[string]interface{}{
+					"Info":          "the second row",
+					"PostalAddress": map[string]string{"Building": "B1", "Room": "R101"},
+					"Placement":     map[string]interface{}{"Row": "2", "Rack": "A07", "RackOffset": 12, "RackOffsetUnits": "EIA_310"},
+					"PartLocation":  map[string]string{"ServiceLabel": "Slot 3"},
+				},
+			})
+			location, err := bmc.client().GetLocation()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(location).To(Equal(&bmcv1beta1.LocationStatus{
+				Building:        "B1",
+				Room:            "R101",
+				Row:             "2",
+				Rack:            "A07",
+				RackOffset:      ptr.To(int32(12)),
+				RackOffsetUnits: "EIA_310",
+				Slot:            "Slot 3",
+				Info:            "the second row",
+				IndicatorLED:    "Blinking",
+			}))
+		})
+
+		It("reports the LocationIndicatorActive as the state of the indicator", func() {
+			chassis(map[string]interface{}{"LocationIndicatorActive": true})
+			location, err := bmc.client().GetLocation()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(location.IndicatorLED).To(Equal("Lit"))
+
+			chassis(map[string]interface{}{"LocationIndicatorActive": false})
+			location, err = bmc.client().GetLocation()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(location.IndicatorLED).To(Equal("Off"))
+		})
+
+		It("reports no rack offset and no indicator when the chassis does not report them", func() {
+			chassis(map[string]interface{}{})
+			location, err := bmc.client().GetLocation()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(location).To(Equal(&bmcv1beta1.LocationStatus{}))
+		})
+
+		It("fails without a chassis", func() {
+			bmc.set("/redfish/v1/Chassis", map[string]interface{}{"Members": []map[string]string{}})
+			_, err := bmc.client().GetLocation()
+			Expect(err).To(MatchError("failed to get chassis"))
+		})
+	})
+})
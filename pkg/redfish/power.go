@@ -9,8 +9,10 @@ import (
 
 // https://github.com/DMTF/Redfish-Tacklebox/blob/main/scripts/rf_power_reset.py
 // post request to systems
+// it returns the task monitor when the BMC handles the reset asynchronously, only one task could be tracked,
+// so it fails when the BMC creates a task for more than one system
 
-func (c *redfishClient) Power(bootCmd string) error {
+func (c *redfishClient) Power(bootCmd string) (string, error) {
 
 	// Attached the client to service root
 	service := c.client.Service
@@ -18,18 +20,22 @@ func (c *redfishClient) Power(bootCmd string) error {
 	ss, err := service.Systems()
 	if err != nil {
 		c.logger.Errorf("failed to Query the computer systems: %+v", err)
-		return err
+		return "", err
 	}
 	if len(ss) == 0 {
 		c.logger.Errorf("no system found")
-		return fmt.Errorf("no system found")
+		return "", fmt.Errorf("no system found")
 	}
 
+	taskMonitor := ""
+	taskSystem := ""
+
 	for _, system := range ss {
+		monitor := ""
 		bootOptions, err := system.BootOptions()
 		if err != nil {
 			c.logger.Errorf("failed to get boot options: %+v", err)
-			return err
+			return "", err
 		}
 		c.logger.Debugf("system %s, boot options: %+v", system.Name, bootOptions)
 		c.logger.Debugf("system %s, boot : %+v", system.Name, system.Boot)
@@ -48,7 +54,7 @@ func (c *redfishClient) Power(bootCmd string) error {
 			fallthrough
 		case bmcv1beta1.BootCmdGracefulRestart:
 			c.logger.Infof("operation %s on %s for System: %+v \n", bootCmd, c.config.Endpoint, system.Name)
-			err = system.Reset(redfish.ResetType(bootCmd))
+			monitor, err = c.resetSystem(system, redfish.ResetType(bootCmd))
 
 		case bmcv1beta1.BootCmdResetPxeOnce:
 			// https://github.com/stmcginnis/gofish/blob/main/examples/reboot.md
@@ -62,19 +68,37 @@ func (c *redfishClient) Power(bootCmd string) error {
 			c.logger.Infof("pxe reboot %s for System: %+v \n", c.config.Endpoint, system.Name)
 			err = system.SetBoot(bootOverride)
 			if err != nil {
-				return fmt.Errorf("failed to set boot option")
+				return "", fmt.Errorf("failed to set boot option: %w", err)
 			}
-			err = system.Reset(redfish.ForceRestartResetType)
+			monitor, err = c.resetSystem(system, redfish.ForceRestartResetType)
 
 		default:
 			c.logger.Errorf("unknown boot cmd: %+v", bootCmd)
-			return fmt.Errorf("unknown boot cmd: %+v", bootCmd)
+			return "", fmt.Errorf("unknown boot cmd: %+v", bootCmd)
 		}
 		if err != nil {
 			c.logger.Errorf("failed to operate system %+v: %+v \n", system, err)
-			return fmt.Errorf("failed to operate ")
+			return "", fmt.Errorf("failed to operate: %w", err)
+		}
+		if monitor == "" {
+			continue
+		}
+		if taskMonitor != "" {
+			c.logger.Errorf("both system %s and %s handle %s with a task", taskSystem, system.Name, bootCmd)
+			return "", fmt.Errorf("system %s and %s both handle %s with a task, which could not be tracked together: %s, %s",
+				taskSystem, system.Name, bootCmd, taskMonitor, monitor)
 		}
+		taskMonitor, taskSystem = monitor, system.Name
 	}
 
-	return nil
+	return taskMonitor, nil
+}
+
+// resetSystem posts the reset action to the system, and returns the task monitor if the BMC creates a task for it
+func (c *redfishClient) resetSystem(system *redfish.ComputerSystem, resetType redfish.ResetType) (string, error) {
+	target := actionTarget(system.RawData, "#ComputerSystem.Reset")
+	if target == "" {
+		return "", system.Reset(resetType)
+	}
+	return c.postAction(target, map[string]interface{}{"ResetType": resetType})
 }
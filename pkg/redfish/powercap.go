@@ -0,0 +1,94 @@
+package redfish
+
+import (
+	"fmt"
+	"math"
+
+	bmcv1beta1 "github.com/spidernet-io/bmc/pkg/k8s/apis/bmc.spidernet.io/v1beta1"
+	"github.com/stmcginnis/gofish/redfish"
+)
+
+// getPowerResource returns the first chassis Power resource which reports PowerControl
+func (c *redfishClient) getPowerResource() (*redfish.Power, error) {
+	cs, err := c.client.Service.Chassis()
+	if err != nil {
+		c.logger.Errorf("failed to get chassis: %+v", err)
+		return nil, err
+	}
+	for _, chassis := range cs {
+		power, err := chassis.Power()
+		if err != nil {
+			c.logger.Debugf("failed to get power of chassis %s: %+v", chassis.ID, err)
+			continue
+		}
+		if power == nil || len(power.PowerControl) == 0 {
+			continue
+		}
+		return power, nil
+	}
+	return nil, fmt.Errorf("no chassis reports power control")
+}
+
+func toWatts(v float32) int32 {
+	return int32(math.Round(float64(v)))
+}
+
+// GetPower returns the power consumption and power cap of the host
+func (c *redfishClient) GetPower() (*bmcv1beta1.PowerStatus, error) {
+	power, err := c.getPowerResource()
+	if err != nil {
+		return nil, err
+	}
+	pc := power.PowerControl[0]
+	c.logger.Debugf("power control: %+v", pc)
+
+	result := &bmcv1beta1.PowerStatus{
+		ConsumedWatts:        toWatts(pc.PowerConsumedWatts),
+		CapacityWatts:        toWatts(pc.PowerCapacityWatts),
+		AverageConsumedWatts: toWatts(pc.PowerMetrics.AverageConsumedWatts),
+		MinConsumedWatts:     toWatts(pc.PowerMetrics.MinConsumedWatts),
+		MaxConsumedWatts:     toWatts(pc.PowerMetrics.MaxConsumedWatts),
+		IntervalInMin:        toWatts(pc.PowerMetrics.IntervalInMin),
+		LimitException:       string(pc.PowerLimit.LimitException),
+	}
+	// a LimitInWatts of 0 or null means no power cap is set
+	if pc.PowerLimit.LimitInWatts > 0 {
+		t := toWatts(pc.PowerLimit.LimitInWatts)
+		result.LimitInWatts = &t
+	}
+	return result, nil
+}
+
+// SetPowerLimit sets the power cap of the host, a nil limitInWatts clears the power cap
+func (c *redfishClient) SetPowerLimit(limitInWatts *int32, limitException string) error {
+	power, err := c.getPowerResource()
+	if err != nil {
+		return err
+	}
+
+	limit := map[string]interface{}{
+		// a null LimitInWatts removes the power cap
+		"LimitInWatts": limitInWatts,
+	}
+	if limitInWatts != nil && limitException != "" {
+		limit["LimitException"] = limitException
+	}
+	payload := map[string]interface{}{
+		"PowerControl": []map[string]interface{}{
+			{"PowerLimit": limit},
+		},
+	}
+
+	if limitInWatts != nil {
+		c.logger.Infof("set power limit of %s to %d watts", c.config.Endpoint, *limitInWatts)
+	} else {
+		c.logger.Infof("clear power limit of %s", c.config.Endpoint)
+	}
+	resp, err := c.client.Patch(power.ODataID, payload)
+	if err != nil {
+		c.logger.Errorf("failed to patch power limit: %+v", err)
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
@@ -0,0 +1,31 @@
+package redfish
+
+import (
+	"fmt"
+	"time"
+
+	bmcv1beta1 "github.com/spidernet-io/bmc/pkg/k8s/apis/bmc.spidernet.io/v1beta1"
+)
+
+// GetPowerState returns the power state and the boot progress of the system
+func (c *redfishClient) GetPowerState() (*bmcv1beta1.PowerStateRecord, error) {
+	ss, err := c.client.Service.Systems()
+	if err != nil {
+		c.logger.Errorf("failed to Query the computer systems: %+v", err)
+		return nil, err
+	}
+	if len(ss) == 0 {
+		return nil, fmt.Errorf("no system found")
+	}
+
+	system := ss[0]
+	result := &bmcv1beta1.PowerStateRecord{
+		Time:       time.Now().UTC().Format(time.RFC3339),
+		PowerState: string(system.PowerState),
+		// BootProgress is only reported by the BMC supporting Redfish 2020.2 or later
+		BootProgress:     string(system.BootProgress.LastState),
+		BootProgressTime: system.BootProgress.LastStateTime,
+	}
+	c.logger.Debugf("power state of system %s: %+v", system.ID, result)
+	return result, nil
+}
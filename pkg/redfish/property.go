@@ -0,0 +1,59 @@
+package redfish
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// GetProperty returns the value of a property of the Redfish resource.
+// the property is a path separated by dots, and the index of the array is a number, such as Boot.BootSourceOverrideTarget or Members.0.Status.State
+func (c *redfishClient) GetProperty(uri, property string) (string, error) {
+	resp, err := c.client.Get(toRelativeURI(uri))
+	if err != nil {
+		c.logger.Errorf("failed to get %s: %+v", uri, err)
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	var v interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&v); err != nil {
+		return "", fmt.Errorf("failed to decode %s: %v", uri, err)
+	}
+	for _, key := range strings.Split(property, ".") {
+		switch t := v.(type) {
+		case map[string]interface{}:
+			value, ok := t[key]
+			if !ok {
+				return "", fmt.Errorf("property %s is not found in %s", property, uri)
+			}
+			v = value
+		case []interface{}:
+			i, err := strconv.Atoi(key)
+			if err != nil || i < 0 || i >= len(t) {
+				return "", fmt.Errorf("invalid index %q of property %s in %s", key, property, uri)
+			}
+			v = t[i]
+		default:
+			return "", fmt.Errorf("property %s is not found in %s", property, uri)
+		}
+	}
+
+	switch t := v.(type) {
+	case string:
+		return t, nil
+	case nil:
+		return "null", nil
+	case bool:
+		return strconv.FormatBool(t), nil
+	case float64:
+		return strconv.FormatFloat(t, 'f', -1, 64), nil
+	default:
+		b, err := json.Marshal(t)
+		if err != nil {
+			return "", err
+		}
+		return string(b), nil
+	}
+}
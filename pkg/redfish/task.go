@@ -0,0 +1,175 @@
+package redfish
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	bmcv1beta1 "github.com/spidernet-io/bmc/pkg/k8s/apis/bmc.spidernet.io/v1beta1"
+	"github.com/stmcginnis/gofish/common"
+	"github.com/stmcginnis/gofish/redfish"
+)
+
+// https://www.dmtf.org/sites/default/files/standards/documents/DSP0266_1.20.0.html#asynchronous-operations
+// a long-running operation is accepted by the service with 202 and a task monitor in the Location header
+
+// actionTarget returns the target of the action reported in the raw data of a resource
+func actionTarget(raw []byte, action string) string {
+	t := struct {
+		Actions map[string]struct {
+			Target string `json:"target"`
+		}
+	}{}
+	if err := json.Unmarshal(raw, &t); err != nil {
+		return ""
+	}
+	return t.Actions[action].Target
+}
+
+// toRelativeURI strips the scheme and host of the uri, which the client does not expect
+func toRelativeURI(uri string) string {
+	u, err := url.Parse(uri)
+	if err != nil || u.Host == "" {
+		return uri
+	}
+	return u.RequestURI()
+}
+
+// postAction posts the payload to the target of an action,
+// and returns the task monitor when the service handles the action asynchronously
+func (c *redfishClient) postAction(target string, payload interface{}) (string, error) {
+	resp, err := c.client.Post(target, payload)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusAccepted {
+		return "", nil
+	}
+	if monitor := resp.Header.Get("Location"); monitor != "" {
+		c.logger.Infof("action %s of %s is accepted, task monitor: %s", target, c.config.Endpoint, monitor)
+		return toRelativeURI(monitor), nil
+	}
+
+	// some services only return the task resource in the body
+	task := &redfish.Task{}
+	if err := json.NewDecoder(resp.Body).Decode(task); err != nil {
+		return "", fmt.Errorf("action %s is accepted, but no task monitor is returned: %v", target, err)
+	}
+	monitor := task.TaskMonitor
+	if monitor == "" {
+		monitor = task.ODataID
+	}
+	if monitor == "" {
+		return "", fmt.Errorf("action %s is accepted, but no task monitor is returned", target)
+	}
+	c.logger.Infof("action %s of %s is accepted, task monitor: %s", target, c.config.Endpoint, monitor)
+	return toRelativeURI(monitor), nil
+}
+
+// finishedTask returns the result of the task when the task monitor answers with an error status, which the services
+// do once the task finishes: the task monitor is removed after the task completes, or it returns the error response
+// of the failed operation. it returns nil for the other errors, such as the transient ones, which are retried
+func finishedTask(monitor string, err error) *bmcv1beta1.TaskStatus {
+	e := &common.Error{}
+	if !errors.As(err, &e) {
+		return nil
+	}
+	switch {
+	case e.HTTPReturnedStatusCode == http.StatusNotFound || e.HTTPReturnedStatusCode == http.StatusGone:
+		return &bmcv1beta1.TaskStatus{
+			MonitorURI:      monitor,
+			TaskState:       string(redfish.CompletedTaskState),
+			PercentComplete: 100,
+			Messages:        []string{"the task monitor is removed, the task completed with an unknown result"},
+		}
+	case e.HTTPReturnedStatusCode == http.StatusUnauthorized || e.HTTPReturnedStatusCode == http.StatusTooManyRequests ||
+		e.HTTPReturnedStatusCode == http.StatusServiceUnavailable:
+		return nil
+	case e.HTTPReturnedStatusCode >= http.StatusBadRequest && len(e.ExtendedInfos) > 0:
+		result := &bmcv1beta1.TaskStatus{
+			MonitorURI: monitor,
+			TaskState:  string(redfish.ExceptionTaskState),
+			TaskStatus: string(common.CriticalHealth),
+		}
+		for _, m := range e.ExtendedInfos {
+			if m.Message != "" {
+				result.Messages = append(result.Messages, m.Message)
+			} else if m.MessageID != "" {
+				result.Messages = append(result.Messages, m.MessageID)
+			}
+		}
+		return result
+	}
+	return nil
+}
+
+// GetTask polls the task monitor and returns the progress of the task
+func (c *redfishClient) GetTask(monitor string) (*bmcv1beta1.TaskStatus, error) {
+	resp, err := c.client.Get(monitor)
+	if err != nil {
+		if result := finishedTask(monitor, err); result != nil {
+			c.logger.Infof("task monitor %s returns %v, the task is %s", monitor, err, result.TaskState)
+			return result, nil
+		}
+		c.logger.Errorf("failed to get task monitor %s: %+v", monitor, err)
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &bmcv1beta1.TaskStatus{
+		MonitorURI: monitor,
+	}
+	task := &redfish.Task{}
+	if len(body) > 0 && json.Unmarshal(body, task) == nil && task.TaskState != "" {
+		result.TaskState = string(task.TaskState)
+		result.TaskStatus = string(task.TaskStatus)
+		result.PercentComplete = int32(task.PercentComplete)
+		for _, m := range task.Messages {
+			if m.Message != "" {
+				result.Messages = append(result.Messages, m.Message)
+			} else if m.MessageID != "" {
+				result.Messages = append(result.Messages, m.MessageID)
+			}
+		}
+	} else if resp.StatusCode == http.StatusAccepted {
+		// the task is still running, but the service does not return the task resource
+		result.TaskState = string(redfish.RunningTaskState)
+	} else {
+		// once the task completes, the task monitor returns the response of the operation
+		result.TaskState = string(redfish.CompletedTaskState)
+		result.PercentComplete = 100
+	}
+	c.logger.Debugf("task %s: %+v", monitor, result)
+	return result, nil
+}
+
+// CancelTask aborts the task by deleting the task monitor, the BMC may not support it
+func (c *redfishClient) CancelTask(monitor string) error {
+	c.logger.Infof("cancel task %s", monitor)
+	if _, err := c.client.Delete(monitor); err != nil {
+		c.logger.Errorf("failed to cancel task %s: %+v", monitor, err)
+		return err
+	}
+	return nil
+}
+
+// TaskResult returns whether the task has finished, and whether it has succeeded
+func TaskResult(task *bmcv1beta1.TaskStatus) (finished bool, succeeded bool) {
+	switch redfish.TaskState(task.TaskState) {
+	case redfish.CompletedTaskState:
+		return true, task.TaskStatus != "Critical"
+	case redfish.KilledTaskState, redfish.ExceptionTaskState, redfish.CancelledTaskState:
+		return true, false
+	default:
+		return false, false
+	}
+}
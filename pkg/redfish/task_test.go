@@ -0,0 +1,158 @@
+package redfish_test
+
+import (
+	"fmt"
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	bmcv1beta1 "github.com/spidernet-io/bmc/pkg/k8s/apis/bmc.spidernet.io/v1beta1"
+	"github.com/spidernet-io/bmc/pkg/redfish"
+)
+
+var _ = Describe("Task", Label("unitest"), func() {
+	DescribeTable("TaskResult",
+		func(state, status string, finished, succeeded bool) {
+			f, s := redfish.TaskResult(&bmcv1beta1.TaskStatus{TaskState: state, TaskStatus: status})
+			Expect(f).To(Equal(finished))
+			Expect(s).To(Equal(succeeded))
+		},
+		Entry("running", "Running", "OK", false, false),
+		Entry("new", "New", "", false, false),
+		Entry("completed", "Completed", "OK", true, true),
+		Entry("completed with a warning", "Completed", "Warning", true, true),
+		Entry("completed with a critical status", "Completed", "Critical", true, false),
+		Entry("killed", "Killed", "", true, false),
+		Entry("exception", "Exception", "", true, false),
+		Entry("cancelled", "Cancelled", "", true, false),
+	)
+
+	It("strips the scheme and host of the task monitor", func() {
+		Expect(redfish.ToRelativeURI("https://10.0.0.1/redfish/v1/TaskService/Tasks/1/Monitor")).To(Equal("/redfish/v1/TaskService/Tasks/1/Monitor"))
+		Expect(redfish.ToRelativeURI("/redfish/v1/TaskService/Tasks/1?monitor=1")).To(Equal("/redfish/v1/TaskService/Tasks/1?monitor=1"))
+	})
+
+	It("reads the target of an action from the raw resource", func() {
+		raw := []byte(`{"Actions":{"#ComputerSystem.Reset":{"target":"/redfish/v1/Systems/1/Actions/ComputerSystem.Reset"}}}`)
+		Expect(redfish.ActionTarget(raw, "#ComputerSystem.Reset")).To(Equal("/redfish/v1/Systems/1/Actions/ComputerSystem.Reset"))
+		Expect(redfish.ActionTarget(raw, "#Manager.Reset")).To(BeEmpty())
+		Expect(redfish.ActionTarget([]byte("not json"), "#ComputerSystem.Reset")).To(BeEmpty())
+	})
+
+	Describe("GetTask", func() {
+		var bmc *fakeBMC
+		const monitor = "/redfish/v1/TaskService/Tasks/1/Monitor"
+
+		BeforeEach(func() {
+			bmc = newFakeBMC()
+			DeferCleanup(bmc.Close)
+		})
+		respond := func(code int, body string) {
+			bmc.handle(monitor, func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(code)
+				fmt.Fprint(w, body)
+			})
+		}
+
+		It("reads the progress of the task resource", func() {
+			bmc.set(monitor, map[string]interface{}{"TaskState": "Running", "TaskStatus": "OK", "PercentComplete": 40,
+				"Messages": []map[string]string{{"Message": "Task successfully scheduled."}}})
+			task, err := bmc.client().GetTask(monitor)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(task.TaskState).To(Equal("Running"))
+			Expect(task.PercentComplete).To(Equal(int32(40)))
+			Expect(task.Messages).To(Equal([]string{"Task successfully scheduled."}))
+		})
+
+		It("keeps running while the task monitor returns 202 without a body", func() {
+			respond(http.StatusAccepted, "")
+			task, err := bmc.client().GetTask(monitor)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(task.TaskState).To(Equal("Running"))
+		})
+
+		DescribeTable("completes with an unknown result when the task monitor is removed",
+			func(code int) {
+				respond(code, "")
+				task, err := bmc.client().GetTask(monitor)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(task.TaskState).To(Equal("Completed"))
+				Expect(task.Messages).NotTo(BeEmpty())
+				finished, succeeded := redfish.TaskResult(task)
+				Expect(finished).To(BeTrue())
+				Expect(succeeded).To(BeTrue())
+			},
+			Entry("not found", http.StatusNotFound),
+			Entry("gone", http.StatusGone),
+		)
+
+		It("fails the task when the task monitor returns the error of the operation", func() {
+			respond(http.StatusBadRequest, `{"error":{"code":"Base.1.8.GeneralError","message":"failed",`+
+				`"@Message.ExtendedInfo":[{"MessageId":"Base.1.8.PropertyValueNotInList","Message":"The value of ResetType is not supported."}]}}`)
+			task, err := bmc.client().GetTask(monitor)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(task.TaskState).To(Equal("Exception"))
+			Expect(task.Messages).To(Equal([]string{"The value of ResetType is not supported."}))
+			finished, succeeded := redfish.TaskResult(task)
+			Expect(finished).To(BeTrue())
+			Expect(succeeded).To(BeFalse())
+		})
+
+		It("retries the transient errors", func() {
+			respond(http.StatusServiceUnavailable, `{"error":{"@Message.ExtendedInfo":[{"MessageId":"Base.1.8.ServiceTemporarilyUnavailable"}]}}`)
+			_, err := bmc.client().GetTask(monitor)
+			Expect(err).To(HaveOccurred())
+
+			respond(http.StatusInternalServerError, "")
+			_, err = bmc.client().GetTask(monitor)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("Power", func() {
+		var bmc *fakeBMC
+
+		BeforeEach(func() {
+			bmc = newFakeBMC()
+			DeferCleanup(bmc.Close)
+		})
+		addSystem := func(id string, async bool) {
+			target := fmt.Sprintf("/redfish/v1/Systems/%s/Actions/ComputerSystem.Reset", id)
+			bmc.set("/redfish/v1/Systems/"+id, map[string]interface{}{
+				"@odata.id": "/redfish/v1/Systems/" + id, "Id": id, "Name": "system" + id,
+				"Actions": map[string]interface{}{"#ComputerSystem.Reset": map[string]string{"target": target}},
+			})
+			if async {
+				bmc.handle(target, func(w http.ResponseWriter, r *http.Request) {
+					w.Header().Set("Location", fmt.Sprintf("/redfish/v1/TaskService/Tasks/%s/Monitor", id))
+					w.WriteHeader(http.StatusAccepted)
+				})
+			}
+		}
+		systems := func(ids ...string) {
+			members := []map[string]string{}
+			for _, id := range ids {
+				members = append(members, map[string]string{"@odata.id": "/redfish/v1/Systems/" + id})
+			}
+			bmc.set("/redfish/v1/Systems", map[string]interface{}{"Members": members, "Members@odata.count": len(members)})
+		}
+
+		It("returns the task monitor of the system", func() {
+			addSystem("1", true)
+			addSystem("2", false)
+			systems("1", "2")
+			monitor, err := bmc.client().Power(bmcv1beta1.BootCmdForceRestart)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(monitor).To(Equal("/redfish/v1/TaskService/Tasks/1/Monitor"))
+			Expect(bmc.received(http.MethodPost)).To(HaveLen(2))
+		})
+
+		It("fails when more than one system handles the action with a task", func() {
+			addSystem("1", true)
+			addSystem("2", true)
+			systems("1", "2")
+			_, err := bmc.client().Power(bmcv1beta1.BootCmdForceRestart)
+			Expect(err).To(MatchError(ContainSubstring("could not be tracked together")))
+		})
+	})
+})
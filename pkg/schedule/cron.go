@@ -0,0 +1,205 @@
+// Package schedule parses the standard 5-field cron expressions used by the scheduled resources
+package schedule
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	// embed the time zone database, the image of the controller may not ship it
+	_ "time/tzdata"
+)
+
+// Schedule is a parsed cron expression: minute hour day-of-month month day-of-week
+type Schedule struct {
+	minute, hour, dom, month, dow uint64
+	// the day matches when either the day-of-month or the day-of-week matches,
+	// if both of them are restricted, as the standard cron does
+	domStar, dowStar bool
+}
+
+type field struct {
+	min, max int
+	names    map[string]int
+}
+
+var (
+	minuteField = field{min: 0, max: 59}
+	hourField   = field{min: 0, max: 23}
+	domField    = field{min: 1, max: 31}
+	monthField  = field{min: 1, max: 12, names: map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+	dowField = field{min: 0, max: 7, names: map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+)
+
+var macros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// Parse parses a cron expression with 5 fields, or one of the macros such as @daily.
+// each field supports "*", lists "1,3", ranges "1-5", steps "*/15" or "0-30/10",
+// and the names of months and weekdays such as "jan" and "mon"
+func Parse(spec string) (*Schedule, error) {
+	spec = strings.TrimSpace(spec)
+	if m, ok := macros[strings.ToLower(spec)]; ok {
+		spec = m
+	}
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("expected 5 fields in cron expression %q, found %d", spec, len(fields))
+	}
+
+	s := &Schedule{}
+	var err error
+	if s.minute, _, err = parseField(fields[0], minuteField); err != nil {
+		return nil, fmt.Errorf("invalid minute field: %v", err)
+	}
+	if s.hour, _, err = parseField(fields[1], hourField); err != nil {
+		return nil, fmt.Errorf("invalid hour field: %v", err)
+	}
+	if s.dom, s.domStar, err = parseField(fields[2], domField); err != nil {
+		return nil, fmt.Errorf("invalid day-of-month field: %v", err)
+	}
+	if s.month, _, err = parseField(fields[3], monthField); err != nil {
+		return nil, fmt.Errorf("invalid month field: %v", err)
+	}
+	if s.dow, s.dowStar, err = parseField(fields[4], dowField); err != nil {
+		return nil, fmt.Errorf("invalid day-of-week field: %v", err)
+	}
+	// both 0 and 7 stand for sunday
+	if s.dow&(1<<7) != 0 {
+		s.dow |= 1
+	}
+	return s, nil
+}
+
+// parseField returns the bitmap of the values, and whether the field is "*"
+func parseField(expr string, f field) (uint64, bool, error) {
+	var bits uint64
+	for _, part := range strings.Split(expr, ",") {
+		rangeExpr, step := part, 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			n, err := strconv.Atoi(part[i+1:])
+			if err != nil || n <= 0 {
+				return 0, false, fmt.Errorf("invalid step in %q", part)
+			}
+			rangeExpr, step = part[:i], n
+		}
+
+		var start, end int
+		switch {
+		case rangeExpr == "*":
+			start, end = f.min, f.max
+		case strings.Contains(rangeExpr, "-"):
+			bounds := strings.SplitN(rangeExpr, "-", 2)
+			var err error
+			if start, err = parseValue(bounds[0], f); err != nil {
+				return 0, false, err
+			}
+			if end, err = parseValue(bounds[1], f); err != nil {
+				return 0, false, err
+			}
+			if start > end {
+				return 0, false, fmt.Errorf("invalid range %q", rangeExpr)
+			}
+		default:
+			v, err := parseValue(rangeExpr, f)
+			if err != nil {
+				return 0, false, err
+			}
+			start, end = v, v
+			// "5/10" means from 5 to the max with step 10
+			if step > 1 {
+				end = f.max
+			}
+		}
+
+		for v := start; v <= end; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, expr == "*", nil
+}
+
+func parseValue(s string, f field) (int, error) {
+	if v, ok := f.names[strings.ToLower(s)]; ok {
+		return v, nil
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q", s)
+	}
+	if v < f.min || v > f.max {
+		return 0, fmt.Errorf("value %d out of range [%d, %d]", v, f.min, f.max)
+	}
+	return v, nil
+}
+
+func (s *Schedule) dayMatches(t time.Time) bool {
+	domMatch := s.dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) != 0
+	if s.domStar || s.dowStar {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
+
+// Matches returns whether the minute of the time matches the schedule, in the location of the time
+func (s *Schedule) Matches(t time.Time) bool {
+	return s.month&(1<<uint(t.Month())) != 0 && s.dayMatches(t) &&
+		s.hour&(1<<uint(t.Hour())) != 0 && s.minute&(1<<uint(t.Minute())) != 0
+}
+
+// Next returns the first time matching the schedule after t, in the location of t.
+// it returns the zero time if no time matches within 5 years, such as "0 0 30 2 *"
+func (s *Schedule) Next(t time.Time) time.Time {
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+
+	for t.Before(limit) {
+		if s.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if !s.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if s.hour&(1<<uint(t.Hour())) == 0 {
+			// the next hour is built in the location, Truncate rounds by the absolute time and never reaches
+			// minute 0 in the zones with a half-hour offset. the hour skipped by DST is normalized to the one after it,
+			// and the repeated hour may be resolved to the earlier instant, so the time is moved forward at least
+			next := time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+			if !next.After(t) {
+				next = t.Add(time.Hour)
+			}
+			t = next
+			continue
+		}
+		if s.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+// LoadLocation returns the location of the time zone, UTC for an empty name
+func LoadLocation(name string) (*time.Location, error) {
+	if name == "" {
+		return time.UTC, nil
+	}
+	return time.LoadLocation(name)
+}
@@ -0,0 +1,94 @@
+package schedule_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spidernet-io/bmc/pkg/schedule"
+)
+
+var newYork, _ = time.LoadLocation("America/New_York")
+
+var _ = Describe("Cron", Label("unitest"), func() {
+
+	base := time.Date(2024, 3, 15, 10, 30, 20, 0, time.UTC) // Friday
+
+	DescribeTable("next time",
+		func(spec string, expected time.Time) {
+			s, err := schedule.Parse(spec)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(s.Next(base)).To(Equal(expected))
+		},
+		Entry("every minute", "* * * * *", time.Date(2024, 3, 15, 10, 31, 0, 0, time.UTC)),
+		Entry("nightly", "0 22 * * *", time.Date(2024, 3, 15, 22, 0, 0, 0, time.UTC)),
+		Entry("step", "*/20 * * * *", time.Date(2024, 3, 15, 10, 40, 0, 0, time.UTC)),
+		Entry("weekday names", "0 7 * * mon-fri", time.Date(2024, 3, 18, 7, 0, 0, 0, time.UTC)),
+		Entry("sunday as 7", "0 0 * * 7", time.Date(2024, 3, 17, 0, 0, 0, 0, time.UTC)),
+		Entry("monthly macro", "@monthly", time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)),
+		Entry("day-of-month or day-of-week", "0 0 1 * 6", time.Date(2024, 3, 16, 0, 0, 0, 0, time.UTC)),
+		Entry("leap day", "0 0 29 2 *", time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)),
+		Entry("list and month name", "15,45 9 * jun *", time.Date(2024, 6, 1, 9, 15, 0, 0, time.UTC)),
+	)
+
+	It("follows the time zone", func() {
+		loc, err := schedule.LoadLocation("Asia/Shanghai")
+		Expect(err).NotTo(HaveOccurred())
+		s, err := schedule.Parse("0 8 * * *")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(s.Next(base.In(loc)).UTC()).To(Equal(time.Date(2024, 3, 16, 0, 0, 0, 0, time.UTC)))
+	})
+
+	DescribeTable("time zones with a half-hour offset",
+		func(zone string, expected time.Time) {
+			loc, err := schedule.LoadLocation(zone)
+			Expect(err).NotTo(HaveOccurred())
+			s, err := schedule.Parse("0 2 * * *")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(s.Next(base.In(loc)).UTC()).To(Equal(expected))
+		},
+		Entry("India", "Asia/Kolkata", time.Date(2024, 3, 15, 20, 30, 0, 0, time.UTC)),
+		Entry("Nepal", "Asia/Kathmandu", time.Date(2024, 3, 15, 20, 15, 0, 0, time.UTC)),
+	)
+
+	DescribeTable("DST transitions",
+		func(spec string, from, expected time.Time) {
+			s, err := schedule.Parse(spec)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(s.Next(from).UTC()).To(Equal(expected))
+		},
+		// 02:00 jumps to 03:00 on 2024-03-10 in New York
+		Entry("the skipped time does not run", "30 2 * * *",
+			time.Date(2024, 3, 9, 12, 0, 0, 0, newYork), time.Date(2024, 3, 11, 6, 30, 0, 0, time.UTC)),
+		Entry("hourly across the gap", "0 * * * *",
+			time.Date(2024, 3, 10, 1, 10, 0, 0, newYork), time.Date(2024, 3, 10, 7, 0, 0, 0, time.UTC)),
+		// 02:00 falls back to 01:00 on 2024-11-03 in New York
+		Entry("after the repeated hour", "0 3 * * *",
+			time.Date(2024, 11, 2, 12, 0, 0, 0, newYork), time.Date(2024, 11, 3, 8, 0, 0, 0, time.UTC)),
+	)
+
+	It("matches the minute", func() {
+		s, err := schedule.Parse("30 10 * * fri")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(s.Matches(base)).To(BeTrue())
+		Expect(s.Matches(base.Add(time.Minute))).To(BeFalse())
+	})
+
+	It("returns zero time when it never matches", func() {
+		s, err := schedule.Parse("0 0 30 2 *")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(s.Next(base).IsZero()).To(BeTrue())
+	})
+
+	DescribeTable("invalid expressions",
+		func(spec string) {
+			_, err := schedule.Parse(spec)
+			Expect(err).To(HaveOccurred())
+		},
+		Entry("too few fields", "* * * *"),
+		Entry("out of range", "60 * * * *"),
+		Entry("bad step", "*/0 * * * *"),
+		Entry("bad range", "5-1 * * * *"),
+		Entry("bad name", "0 0 * foo *"),
+	)
+})
@@ -0,0 +1,93 @@
+package hostoperation
+
+import (
+	"fmt"
+	"strings"
+
+	bmcv1beta1 "github.com/spidernet-io/bmc/pkg/k8s/apis/bmc.spidernet.io/v1beta1"
+)
+
+// KnownActions is all the actions which the agent is able to run
+var KnownActions = []string{
+	bmcv1beta1.BootCmdOn,
+	bmcv1beta1.BootCmdForceOn,
+	bmcv1beta1.BootCmdForceOff,
+	bmcv1beta1.BootCmdGracefulShutdown,
+	bmcv1beta1.BootCmdForceRestart,
+	bmcv1beta1.BootCmdGracefulRestart,
+	bmcv1beta1.BootCmdResetPxeOnce,
+	bmcv1beta1.ActionSetPowerLimit,
+	bmcv1beta1.ActionClearPowerLimit,
+	bmcv1beta1.ActionBmcGracefulRestart,
+	bmcv1beta1.ActionBmcForceRestart,
+	bmcv1beta1.ActionBmcResetToDefaults,
+	bmcv1beta1.ActionLocateOn,
+	bmcv1beta1.ActionLocateBlink,
+	bmcv1beta1.ActionLocateOff,
+}
+
+// the boot source override target used by the action PxeReboot
+const bootTargetPxe = "Pxe"
+
+func contains(list []string, item string) bool {
+	for _, v := range list {
+		if v == item {
+			return true
+		}
+	}
+	return false
+}
+
+// checkAllowed returns an error when the BMC advertises the allowed values and the value is not one of them,
+// an empty list means the BMC does not advertise them, so the value is allowed
+func checkAllowed(action, hostStatusName, kind string, allowed []string, value string) error {
+	if len(allowed) == 0 || contains(allowed, value) {
+		return nil
+	}
+	return fmt.Errorf("action %s is not supported by the BMC of hostStatus %s, which requires %s %s but the BMC only supports: %s",
+		action, hostStatusName, kind, value, strings.Join(allowed, ", "))
+}
+
+// ValidateActionSupported checks the action against the capabilities advertised by the BMC of the host,
+// the action is allowed when the agent has not collected the capabilities yet
+func ValidateActionSupported(spec *bmcv1beta1.HostOperationActionSpec, hostStatus *bmcv1beta1.HostStatus) error {
+	if !contains(KnownActions, spec.Action) {
+		return fmt.Errorf("unknown action %q, the supported actions: %s", spec.Action, strings.Join(KnownActions, ", "))
+	}
+
+	c := hostStatus.Status.Capabilities
+	if c == nil {
+		return nil
+	}
+	unsupported := func(feature string) error {
+		return fmt.Errorf("action %s is not supported by the BMC of hostStatus %s, which does not support %s", spec.Action, hostStatus.Name, feature)
+	}
+
+	switch spec.Action {
+	case bmcv1beta1.BootCmdOn, bmcv1beta1.BootCmdForceOn, bmcv1beta1.BootCmdForceOff,
+		bmcv1beta1.BootCmdGracefulShutdown, bmcv1beta1.BootCmdForceRestart, bmcv1beta1.BootCmdGracefulRestart:
+		return checkAllowed(spec.Action, hostStatus.Name, "reset type", c.ResetTypes, spec.Action)
+	case bmcv1beta1.BootCmdResetPxeOnce:
+		if err := checkAllowed(spec.Action, hostStatus.Name, "boot target", c.BootSourceOverrideTargets, bootTargetPxe); err != nil {
+			return err
+		}
+		return checkAllowed(spec.Action, hostStatus.Name, "reset type", c.ResetTypes, bmcv1beta1.BootCmdForceRestart)
+	case bmcv1beta1.ActionBmcGracefulRestart:
+		return checkAllowed(spec.Action, hostStatus.Name, "BMC reset type", c.BmcResetTypes, bmcv1beta1.BootCmdGracefulRestart)
+	case bmcv1beta1.ActionBmcForceRestart:
+		return checkAllowed(spec.Action, hostStatus.Name, "BMC reset type", c.BmcResetTypes, bmcv1beta1.BootCmdForceRestart)
+	case bmcv1beta1.ActionBmcResetToDefaults:
+		if !c.BmcResetToDefaults {
+			return unsupported("resetting to factory defaults")
+		}
+	case bmcv1beta1.ActionSetPowerLimit, bmcv1beta1.ActionClearPowerLimit:
+		if !c.PowerLimit {
+			return unsupported("the power limit")
+		}
+	case bmcv1beta1.ActionLocateOn, bmcv1beta1.ActionLocateBlink, bmcv1beta1.ActionLocateOff:
+		if !c.LocateIndicator {
+			return unsupported("the locate indicator")
+		}
+	}
+	return nil
+}
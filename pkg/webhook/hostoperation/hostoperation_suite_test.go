@@ -0,0 +1,13 @@
+package hostoperation_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestHostOperationWebhook(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "HostOperation Webhook Suite")
+}
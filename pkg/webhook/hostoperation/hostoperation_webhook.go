@@ -3,23 +3,33 @@ package hostoperation
 import (
 	"context"
 	"fmt"
-	//"time"
+	"reflect"
+	"strings"
+	"time"
 
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
+	"github.com/spidernet-io/bmc/pkg/hostpolicy"
 	bmcv1beta1 "github.com/spidernet-io/bmc/pkg/k8s/apis/bmc.spidernet.io/v1beta1"
 	"github.com/spidernet-io/bmc/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 type HostOperationWebhook struct {
-	Client client.Client
+	Client       client.Client
+	TrustedUsers TrustedUsers
 }
 
 func (h *HostOperationWebhook) SetupWebhookWithManager(mgr ctrl.Manager) error {
 	h.Client = mgr.GetClient()
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &bmcv1beta1.HostOperation{},
+		bmcv1beta1.HostOperationHostStatusNameIndex, func(obj client.Object) []string {
+			return []string{obj.(*bmcv1beta1.HostOperation).Spec.HostStatusName}
+		}); err != nil {
+		return fmt.Errorf("failed to index HostOperation: %v", err)
+	}
 	log.Logger.Info("Setting up HostOperation webhook")
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(&bmcv1beta1.HostOperation{}).
@@ -40,6 +50,11 @@ func (h *HostOperationWebhook) Default(ctx context.Context, obj runtime.Object)
 
 	log.Logger.Debugf("Processing Default webhook for HostOperation %s", hostOp.Name)
 
+	if err := SetRequester(ctx, h.Client, h.TrustedUsers, hostOp, &hostOp.Spec.Requester); err != nil {
+		log.Logger.Error(err.Error())
+		return err
+	}
+
 	log.Logger.Debugf("Successfully processed Default webhook for HostOperation %s", hostOp.Name)
 	return nil
 }
@@ -56,6 +71,11 @@ func (h *HostOperationWebhook) ValidateCreate(ctx context.Context, obj runtime.O
 
 	log.Logger.Debugf("Processing ValidateCreate webhook for HostOperation %s", hostOp.Name)
 
+	if err := ValidateActionSpec(&hostOp.Spec.HostOperationActionSpec); err != nil {
+		log.Logger.Errorf(err.Error())
+		return nil, err
+	}
+
 	// 验证 hostStatusName 对应的 HostStatus 是否存在且健康
 	var hostStatus bmcv1beta1.HostStatus
 	if err := h.Client.Get(ctx, client.ObjectKey{Name: hostOp.Spec.HostStatusName}, &hostStatus); err != nil {
@@ -70,19 +90,53 @@ func (h *HostOperationWebhook) ValidateCreate(ctx context.Context, obj runtime.O
 		return nil, err
 	}
 
+	if err := ValidateActionSupported(&hostOp.Spec.HostOperationActionSpec, &hostStatus); err != nil {
+		log.Logger.Errorf(err.Error())
+		return nil, err
+	}
+
+	warnings, err := h.checkPolicies(ctx, hostOp, &hostStatus)
+	if err != nil {
+		log.Logger.Errorf(err.Error())
+		return nil, err
+	}
+
+	if hostOp.Spec.QueuePolicy == bmcv1beta1.HostOperationQueuePolicyReject {
+		if err := h.checkHostIdle(ctx, hostOp); err != nil {
+			log.Logger.Errorf(err.Error())
+			return nil, err
+		}
+	}
+
 	log.Logger.Debugf("Successfully validated HostOperation %s creation", hostOp.Name)
-	return nil, nil
+	return warnings, nil
 }
 
 func (h *HostOperationWebhook) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
-	hostOp, ok := oldObj.(*bmcv1beta1.HostOperation)
+	oldOp, ok := oldObj.(*bmcv1beta1.HostOperation)
 	if !ok {
 		err := fmt.Errorf("expected a HostOperation but got a %T", oldObj)
 		log.Logger.Error(err.Error())
 		return nil, err
 	}
-	log.Logger.Debugf("Rejecting update of HostOperation %s: updates are not allowed", hostOp.Name)
-	return nil, fmt.Errorf("updates to HostOperation resources are not allowed")
+	newOp, ok := newObj.(*bmcv1beta1.HostOperation)
+	if !ok {
+		err := fmt.Errorf("expected a HostOperation but got a %T", newObj)
+		log.Logger.Error(err.Error())
+		return nil, err
+	}
+
+	// only the cancellation is allowed, the metadata such as finalizers and labels could be updated freely
+	if oldOp.Spec.Cancel && !newOp.Spec.Cancel {
+		return nil, fmt.Errorf("a cancelled HostOperation could not be resumed")
+	}
+	oldSpec := oldOp.Spec.DeepCopy()
+	oldSpec.Cancel = newOp.Spec.Cancel
+	if !reflect.DeepEqual(*oldSpec, newOp.Spec) {
+		log.Logger.Debugf("Rejecting update of HostOperation %s: only spec.cancel could be updated", oldOp.Name)
+		return nil, fmt.Errorf("updates to the spec of HostOperation are not allowed except spec.cancel")
+	}
+	return nil, nil
 }
 
 func (h *HostOperationWebhook) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
@@ -96,3 +150,61 @@ func (h *HostOperationWebhook) ValidateDelete(ctx context.Context, obj runtime.O
 	log.Logger.Debugf("Processing ValidateDelete webhook for HostOperation %s", hostOp.Name)
 	return nil, nil
 }
+
+// checkPolicies denies the destructive action on the host restricted by the HostPolicies, unless the operation has the override annotation
+func (h *HostOperationWebhook) checkPolicies(ctx context.Context, hostOp *bmcv1beta1.HostOperation, hostStatus *bmcv1beta1.HostStatus) (admission.Warnings, error) {
+	policies := &bmcv1beta1.HostPolicyList{}
+	if err := h.Client.List(ctx, policies); err != nil {
+		return nil, fmt.Errorf("failed to list HostPolicies: %v", err)
+	}
+	reasons, err := hostpolicy.Evaluate(policies.Items, hostStatus, hostOp.Spec.Action, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if len(reasons) == 0 {
+		return nil, nil
+	}
+	if hostpolicy.Overridden(hostOp.Annotations) {
+		msg := fmt.Sprintf("action %s on hostStatus %s overrides the HostPolicy with reason %q: %s", hostOp.Spec.Action,
+			hostStatus.Name, hostOp.Annotations[bmcv1beta1.AnnotationOverridePolicy], strings.Join(reasons, "; "))
+		log.Logger.Warnf("HostOperation %s: %s", hostOp.Name, msg)
+		return admission.Warnings{msg}, nil
+	}
+	return nil, fmt.Errorf("action %s on hostStatus %s is denied: %s. set the annotation %s with the reason to override it",
+		hostOp.Spec.Action, hostStatus.Name, strings.Join(reasons, "; "), bmcv1beta1.AnnotationOverridePolicy)
+}
+
+// checkHostIdle makes sure no other operation of the host is pending or running
+func (h *HostOperationWebhook) checkHostIdle(ctx context.Context, hostOp *bmcv1beta1.HostOperation) error {
+	list := &bmcv1beta1.HostOperationList{}
+	if err := h.Client.List(ctx, list, client.MatchingFields{bmcv1beta1.HostOperationHostStatusNameIndex: hostOp.Spec.HostStatusName}); err != nil {
+		return fmt.Errorf("failed to list HostOperations of %s: %v", hostOp.Spec.HostStatusName, err)
+	}
+	for _, item := range list.Items {
+		if item.DeletionTimestamp != nil || item.Spec.Cancel {
+			continue
+		}
+		switch item.Status.Status {
+		case "", bmcv1beta1.HostOperationStatusPending, bmcv1beta1.HostOperationStatusRunning:
+			return fmt.Errorf("hostStatus %s is busy with HostOperation %s, and the queuePolicy of hostOperation %s is %s",
+				hostOp.Spec.HostStatusName, item.Name, hostOp.Name, bmcv1beta1.HostOperationQueuePolicyReject)
+		}
+	}
+	return nil
+}
+
+// ValidateActionSpec checks the parameters required by the action, it is shared with the HostOperationSet webhook
+func ValidateActionSpec(spec *bmcv1beta1.HostOperationActionSpec) error {
+	if spec.Action == bmcv1beta1.ActionSetPowerLimit && spec.PowerLimit == nil {
+		return fmt.Errorf("spec.powerLimit is required for action %s", spec.Action)
+	}
+	if spec.LocateDurationMinutes != nil && spec.Action != bmcv1beta1.ActionLocateOn && spec.Action != bmcv1beta1.ActionLocateBlink {
+		return fmt.Errorf("spec.locateDurationMinutes is only supported by action %s and %s", bmcv1beta1.ActionLocateOn, bmcv1beta1.ActionLocateBlink)
+	}
+	if spec.DrainPolicy != nil && spec.Action != bmcv1beta1.BootCmdGracefulShutdown && spec.Action != bmcv1beta1.BootCmdForceRestart &&
+		spec.Action != bmcv1beta1.BootCmdResetPxeOnce {
+		return fmt.Errorf("spec.drainPolicy is only supported by action %s, %s and %s",
+			bmcv1beta1.BootCmdGracefulShutdown, bmcv1beta1.BootCmdForceRestart, bmcv1beta1.BootCmdResetPxeOnce)
+	}
+	return nil
+}
@@ -0,0 +1,32 @@
+package hostoperation_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	bmcv1beta1 "github.com/spidernet-io/bmc/pkg/k8s/apis/bmc.spidernet.io/v1beta1"
+	"github.com/spidernet-io/bmc/pkg/webhook/hostoperation"
+)
+
+var _ = Describe("ValidateActionSpec", Label("unitest"), func() {
+	It("allows the drain policy only for the actions taking the node down", func() {
+		for _, action := range []string{bmcv1beta1.BootCmdGracefulShutdown, bmcv1beta1.BootCmdForceRestart, bmcv1beta1.BootCmdResetPxeOnce} {
+			spec := &bmcv1beta1.HostOperationActionSpec{Action: action, DrainPolicy: &bmcv1beta1.DrainPolicy{}}
+			Expect(hostoperation.ValidateActionSpec(spec)).To(Succeed())
+		}
+		spec := &bmcv1beta1.HostOperationActionSpec{Action: bmcv1beta1.BootCmdOn, DrainPolicy: &bmcv1beta1.DrainPolicy{}}
+		Expect(hostoperation.ValidateActionSpec(spec)).To(MatchError(ContainSubstring("spec.drainPolicy")))
+	})
+
+	It("requires the power limit of SetPowerLimit", func() {
+		spec := &bmcv1beta1.HostOperationActionSpec{Action: bmcv1beta1.ActionSetPowerLimit}
+		Expect(hostoperation.ValidateActionSpec(spec)).To(MatchError(ContainSubstring("spec.powerLimit")))
+	})
+
+	It("allows the locate duration only for the locate actions", func() {
+		minutes := int32(10)
+		spec := &bmcv1beta1.HostOperationActionSpec{Action: bmcv1beta1.ActionLocateBlink, LocateDurationMinutes: &minutes}
+		Expect(hostoperation.ValidateActionSpec(spec)).To(Succeed())
+		spec.Action = bmcv1beta1.ActionLocateOff
+		Expect(hostoperation.ValidateActionSpec(spec)).To(MatchError(ContainSubstring("spec.locateDurationMinutes")))
+	})
+})
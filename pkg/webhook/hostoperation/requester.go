@@ -0,0 +1,97 @@
+package hostoperation
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	bmcv1beta1 "github.com/spidernet-io/bmc/pkg/k8s/apis/bmc.spidernet.io/v1beta1"
+)
+
+// ownerRequester returns the requester of the owner which creates the object on behalf of its requester
+func ownerRequester(ctx context.Context, c client.Client, obj metav1.Object) (*bmcv1beta1.Requester, bool) {
+	owner := metav1.GetControllerOf(obj)
+	if owner == nil {
+		return nil, false
+	}
+	key := client.ObjectKey{Name: owner.Name}
+	switch owner.Kind {
+	case bmcv1beta1.KindHostOperationSet:
+		o := &bmcv1beta1.HostOperationSet{}
+		if err := c.Get(ctx, key, o); err != nil || o.UID != owner.UID {
+			return nil, false
+		}
+		return o.Spec.Requester, true
+	case bmcv1beta1.KindHostOperationSchedule:
+		o := &bmcv1beta1.HostOperationSchedule{}
+		if err := c.Get(ctx, key, o); err != nil || o.UID != owner.UID {
+			return nil, false
+		}
+		return o.Spec.Requester, true
+	case bmcv1beta1.KindHostWorkflow:
+		o := &bmcv1beta1.HostWorkflow{}
+		if err := c.Get(ctx, key, o); err != nil || o.UID != owner.UID {
+			return nil, false
+		}
+		return o.Spec.Requester, true
+	}
+	return nil, false
+}
+
+// TrustedUsers are the service accounts of the controller and the agents, which create the objects on behalf of their owners
+type TrustedUsers struct {
+	// Namespace is the namespace of the controller and the agents
+	Namespace string
+	// ServiceAccountName is the service account of the controller
+	ServiceAccountName string
+}
+
+// trusted returns whether the user is the controller, or the agent of an existing ClusterAgent,
+// which runs as the service account agent-<ClusterAgent name> created by the ClusterAgent controller
+func (t TrustedUsers) trusted(ctx context.Context, c client.Client, username string) bool {
+	if t.Namespace == "" {
+		return false
+	}
+	name, ok := strings.CutPrefix(username, "system:serviceaccount:"+t.Namespace+":")
+	if !ok {
+		return false
+	}
+	if t.ServiceAccountName != "" && name == t.ServiceAccountName {
+		return true
+	}
+	agent, ok := strings.CutPrefix(name, "agent-")
+	if !ok {
+		return false
+	}
+	return c.Get(ctx, client.ObjectKey{Name: agent}, &bmcv1beta1.ClusterAgent{}) == nil
+}
+
+// SetRequester records the user of the admission request as the requester when the object is created.
+// the objects created by the controller and the agents on behalf of their owners keep the requester of the owners,
+// any other value from the request is overwritten, so that the requester could not be forged
+func SetRequester(ctx context.Context, c client.Client, trusted TrustedUsers, obj metav1.Object, requester **bmcv1beta1.Requester) error {
+	req, err := admission.RequestFromContext(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get the admission request: %v", err)
+	}
+	if req.Operation != admissionv1.Create {
+		return nil
+	}
+	user := req.UserInfo
+	if *requester != nil && trusted.trusted(ctx, c, user.Username) {
+		if r, ok := ownerRequester(ctx, c, obj); ok && reflect.DeepEqual(r, *requester) {
+			return nil
+		}
+	}
+	*requester = &bmcv1beta1.Requester{
+		Username: user.Username,
+		Groups:   append([]string(nil), user.Groups...),
+	}
+	return nil
+}
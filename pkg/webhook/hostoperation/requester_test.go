@@ -0,0 +1,129 @@
+package hostoperation_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	bmcv1beta1 "github.com/spidernet-io/bmc/pkg/k8s/apis/bmc.spidernet.io/v1beta1"
+	"github.com/spidernet-io/bmc/pkg/webhook/hostoperation"
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+var _ = Describe("SetRequester", Label("unitest"), func() {
+	const (
+		controllerSA = "system:serviceaccount:bmc:bmc-controller"
+		agentSA      = "system:serviceaccount:bmc:agent-agent1"
+	)
+	var (
+		scheme  *runtime.Scheme
+		trusted hostoperation.TrustedUsers
+		alice   *bmcv1beta1.Requester
+		set     *bmcv1beta1.HostOperationSet
+		wf      *bmcv1beta1.HostWorkflow
+	)
+
+	BeforeEach(func() {
+		scheme = runtime.NewScheme()
+		Expect(bmcv1beta1.AddToScheme(scheme)).To(Succeed())
+		trusted = hostoperation.TrustedUsers{Namespace: "bmc", ServiceAccountName: "bmc-controller"}
+		alice = &bmcv1beta1.Requester{Username: "alice", Groups: []string{"system:authenticated"}}
+		set = &bmcv1beta1.HostOperationSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "rack1", UID: "rack1-uid"},
+			Spec:       bmcv1beta1.HostOperationSetSpec{Requester: alice.DeepCopy()},
+		}
+		wf = &bmcv1beta1.HostWorkflow{
+			ObjectMeta: metav1.ObjectMeta{Name: "upgrade", UID: "upgrade-uid"},
+			Spec:       bmcv1beta1.HostWorkflowSpec{Requester: alice.DeepCopy()},
+		}
+	})
+
+	newClient := func(objs ...client.Object) client.Client {
+		return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+	}
+	request := func(operation admissionv1.Operation, username string) context.Context {
+		return admission.NewContextWithRequest(context.Background(), admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+			Operation: operation,
+			UserInfo:  authenticationv1.UserInfo{Username: username, Groups: []string{"system:serviceaccounts"}},
+		}})
+	}
+	ownedBy := func(kind, name string, uid string) *bmcv1beta1.HostOperation {
+		return &bmcv1beta1.HostOperation{
+			ObjectMeta: metav1.ObjectMeta{Name: "op1", OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: bmcv1beta1.SchemeGroupVersion.String(), Kind: kind, Name: name, UID: types.UID(uid), Controller: ptr.To(true),
+			}}},
+			Spec: bmcv1beta1.HostOperationSpec{Requester: alice.DeepCopy()},
+		}
+	}
+	setRequester := func(ctx context.Context, c client.Client, op *bmcv1beta1.HostOperation) *bmcv1beta1.Requester {
+		Expect(hostoperation.SetRequester(ctx, c, trusted, op, &op.Spec.Requester)).To(Succeed())
+		return op.Spec.Requester
+	}
+
+	It("stamps the user of the request on create and overwrites a forged requester", func() {
+		op := &bmcv1beta1.HostOperation{Spec: bmcv1beta1.HostOperationSpec{Requester: &bmcv1beta1.Requester{Username: "admin"}}}
+		ctx := admission.NewContextWithRequest(context.Background(), admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+			Operation: admissionv1.Create,
+			UserInfo:  authenticationv1.UserInfo{Username: "bob", Groups: []string{"dev"}},
+		}})
+		Expect(setRequester(ctx, newClient(), op)).To(Equal(&bmcv1beta1.Requester{Username: "bob", Groups: []string{"dev"}}))
+	})
+
+	It("leaves the requester as it is on update", func() {
+		op := &bmcv1beta1.HostOperation{Spec: bmcv1beta1.HostOperationSpec{Requester: alice.DeepCopy()}}
+		Expect(setRequester(request(admissionv1.Update, "bob"), newClient(), op)).To(Equal(alice))
+	})
+
+	It("fails without an admission request", func() {
+		op := &bmcv1beta1.HostOperation{}
+		Expect(hostoperation.SetRequester(context.Background(), newClient(), trusted, op, &op.Spec.Requester)).NotTo(Succeed())
+	})
+
+	It("keeps the requester of the owner copied by the controller", func() {
+		op := ownedBy(bmcv1beta1.KindHostOperationSet, set.Name, string(set.UID))
+		Expect(setRequester(request(admissionv1.Create, controllerSA), newClient(set), op)).To(Equal(alice))
+	})
+
+	It("keeps the requester of the owner copied by the agent of an existing ClusterAgent", func() {
+		agent := &bmcv1beta1.ClusterAgent{ObjectMeta: metav1.ObjectMeta{Name: "agent1"}}
+		op := ownedBy(bmcv1beta1.KindHostWorkflow, wf.Name, string(wf.UID))
+		Expect(setRequester(request(admissionv1.Create, agentSA), newClient(wf, agent), op)).To(Equal(alice))
+
+		// the service account of a removed ClusterAgent is not trusted
+		op = ownedBy(bmcv1beta1.KindHostWorkflow, wf.Name, string(wf.UID))
+		Expect(setRequester(request(admissionv1.Create, agentSA), newClient(wf), op).Username).To(Equal(agentSA))
+	})
+
+	It("does not let an untrusted service account impersonate the requester of an owner", func() {
+		op := ownedBy(bmcv1beta1.KindHostOperationSet, set.Name, string(set.UID))
+		Expect(setRequester(request(admissionv1.Create, "system:serviceaccount:default:robot"), newClient(set), op).Username).
+			To(Equal("system:serviceaccount:default:robot"))
+
+		// a service account of the same name in another namespace is not the controller
+		op = ownedBy(bmcv1beta1.KindHostOperationSet, set.Name, string(set.UID))
+		Expect(setRequester(request(admissionv1.Create, "system:serviceaccount:default:bmc-controller"), newClient(set), op).Username).
+			To(Equal("system:serviceaccount:default:bmc-controller"))
+	})
+
+	It("does not let a trusted user set a requester other than the one of the owner", func() {
+		op := ownedBy(bmcv1beta1.KindHostOperationSet, set.Name, string(set.UID))
+		op.Spec.Requester = &bmcv1beta1.Requester{Username: "admin"}
+		Expect(setRequester(request(admissionv1.Create, controllerSA), newClient(set), op).Username).To(Equal(controllerSA))
+
+		// the owner is replaced by another object of the same name
+		op = ownedBy(bmcv1beta1.KindHostOperationSet, set.Name, "another-uid")
+		Expect(setRequester(request(admissionv1.Create, controllerSA), newClient(set), op).Username).To(Equal(controllerSA))
+
+		// the object without an owner
+		op = &bmcv1beta1.HostOperation{Spec: bmcv1beta1.HostOperationSpec{Requester: alice.DeepCopy()}}
+		Expect(setRequester(request(admissionv1.Create, controllerSA), newClient(set), op).Username).To(Equal(controllerSA))
+	})
+})
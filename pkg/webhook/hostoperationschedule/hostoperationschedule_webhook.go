@@ -0,0 +1,135 @@
+package hostoperationschedule
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	bmcv1beta1 "github.com/spidernet-io/bmc/pkg/k8s/apis/bmc.spidernet.io/v1beta1"
+	"github.com/spidernet-io/bmc/pkg/log"
+	"github.com/spidernet-io/bmc/pkg/schedule"
+	hostoperationwebhook "github.com/spidernet-io/bmc/pkg/webhook/hostoperation"
+)
+
+type HostOperationScheduleWebhook struct {
+	Client       client.Client
+	TrustedUsers hostoperationwebhook.TrustedUsers
+}
+
+func (h *HostOperationScheduleWebhook) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	h.Client = mgr.GetClient()
+	log.Logger.Info("Setting up HostOperationSchedule webhook")
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&bmcv1beta1.HostOperationSchedule{}).
+		WithValidator(h).
+		WithDefaulter(h).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-bmc-spidernet-io-v1beta1-hostoperationschedule,mutating=true,failurePolicy=fail,sideEffects=None,groups=bmc.spidernet.io,resources=hostoperationschedules,verbs=create;update,versions=v1beta1,name=mhostoperationschedule.kb.io,admissionReviewVersions=v1
+
+func (h *HostOperationScheduleWebhook) Default(ctx context.Context, obj runtime.Object) error {
+	sched, ok := obj.(*bmcv1beta1.HostOperationSchedule)
+	if !ok {
+		err := fmt.Errorf("expected a HostOperationSchedule but got a %T", obj)
+		log.Logger.Error(err.Error())
+		return err
+	}
+
+	if sched.Spec.ConcurrencyPolicy == "" {
+		sched.Spec.ConcurrencyPolicy = bmcv1beta1.ConcurrencyPolicyAllow
+	}
+	if sched.Spec.MaxConcurrent == 0 {
+		sched.Spec.MaxConcurrent = 1
+	}
+	if err := hostoperationwebhook.SetRequester(ctx, h.Client, h.TrustedUsers, sched, &sched.Spec.Requester); err != nil {
+		log.Logger.Error(err.Error())
+		return err
+	}
+	return nil
+}
+
+// +kubebuilder:webhook:path=/validate-bmc-spidernet-io-v1beta1-hostoperationschedule,mutating=false,failurePolicy=fail,sideEffects=None,groups=bmc.spidernet.io,resources=hostoperationschedules,verbs=create;update,versions=v1beta1,name=vhostoperationschedule.kb.io,admissionReviewVersions=v1
+
+func (h *HostOperationScheduleWebhook) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	sched, ok := obj.(*bmcv1beta1.HostOperationSchedule)
+	if !ok {
+		err := fmt.Errorf("expected a HostOperationSchedule but got a %T", obj)
+		log.Logger.Error(err.Error())
+		return nil, err
+	}
+
+	log.Logger.Debugf("Processing ValidateCreate webhook for HostOperationSchedule %s", sched.Name)
+	if err := validate(sched); err != nil {
+		log.Logger.Errorf(err.Error())
+		return nil, err
+	}
+	return nil, nil
+}
+
+func (h *HostOperationScheduleWebhook) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	sched, ok := newObj.(*bmcv1beta1.HostOperationSchedule)
+	if !ok {
+		err := fmt.Errorf("expected a HostOperationSchedule but got a %T", newObj)
+		log.Logger.Error(err.Error())
+		return nil, err
+	}
+
+	log.Logger.Debugf("Processing ValidateUpdate webhook for HostOperationSchedule %s", sched.Name)
+	if err := validate(sched); err != nil {
+		log.Logger.Errorf(err.Error())
+		return nil, err
+	}
+	oldSched, ok := oldObj.(*bmcv1beta1.HostOperationSchedule)
+	if !ok {
+		err := fmt.Errorf("expected a HostOperationSchedule but got a %T", oldObj)
+		log.Logger.Error(err.Error())
+		return nil, err
+	}
+	// the later runs are recorded under the requester, so the hosts and the action can not be changed
+	// once the schedule is created, while the timing and the rollout parameters can be tuned at any time
+	if !reflect.DeepEqual(oldSched.Spec.Selector, sched.Spec.Selector) ||
+		!reflect.DeepEqual(oldSched.Spec.HostStatusNames, sched.Spec.HostStatusNames) ||
+		!reflect.DeepEqual(oldSched.Spec.Template, sched.Spec.Template) ||
+		!reflect.DeepEqual(oldSched.Spec.Requester, sched.Spec.Requester) || oldSched.Spec.Reason != sched.Spec.Reason {
+		err := fmt.Errorf("spec.selector, spec.hostStatusNames, spec.template, spec.requester and spec.reason of HostOperationSchedule %s can not be modified", sched.Name)
+		log.Logger.Errorf(err.Error())
+		return nil, err
+	}
+	return nil, nil
+}
+
+func (h *HostOperationScheduleWebhook) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func validate(sched *bmcv1beta1.HostOperationSchedule) error {
+	if _, err := schedule.Parse(sched.Spec.Schedule); err != nil {
+		return fmt.Errorf("invalid spec.schedule: %v", err)
+	}
+	if _, err := schedule.LoadLocation(sched.Spec.TimeZone); err != nil {
+		return fmt.Errorf("invalid spec.timeZone: %v", err)
+	}
+	if sched.Spec.Selector == nil && len(sched.Spec.HostStatusNames) == 0 {
+		return fmt.Errorf("either spec.selector or spec.hostStatusNames must be specified")
+	}
+	if sched.Spec.Selector != nil {
+		// an empty selector selects all the HostStatuses of the cluster
+		if len(sched.Spec.Selector.MatchLabels) == 0 && len(sched.Spec.Selector.MatchExpressions) == 0 {
+			return fmt.Errorf("spec.selector must not be empty")
+		}
+		if _, err := metav1.LabelSelectorAsSelector(sched.Spec.Selector); err != nil {
+			return fmt.Errorf("invalid spec.selector: %v", err)
+		}
+	}
+	if err := hostoperationwebhook.ValidateActionSpec(&sched.Spec.Template); err != nil {
+		return fmt.Errorf("invalid spec.template: %v", err)
+	}
+	return nil
+}
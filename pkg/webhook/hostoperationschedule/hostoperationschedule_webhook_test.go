@@ -0,0 +1,76 @@
+package hostoperationschedule_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	bmcv1beta1 "github.com/spidernet-io/bmc/pkg/k8s/apis/bmc.spidernet.io/v1beta1"
+	"github.com/spidernet-io/bmc/pkg/webhook/hostoperationschedule"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("HostOperationSchedule webhook", Label("unitest"), func() {
+	h := &hostoperationschedule.HostOperationScheduleWebhook{}
+	ctx := context.Background()
+
+	sched := func() *bmcv1beta1.HostOperationSchedule {
+		return &bmcv1beta1.HostOperationSchedule{
+			ObjectMeta: metav1.ObjectMeta{Name: "lab-gpu-power-off"},
+			Spec: bmcv1beta1.HostOperationScheduleSpec{
+				Schedule: "0 22 * * *",
+				TimeZone: "Asia/Shanghai",
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"rack": "lab-gpu"}},
+				Template: bmcv1beta1.HostOperationActionSpec{Action: bmcv1beta1.BootCmdGracefulShutdown},
+			},
+		}
+	}
+
+	It("accepts a valid schedule", func() {
+		_, err := h.ValidateCreate(ctx, sched())
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("rejects the invalid schedule and time zone", func() {
+		s := sched()
+		s.Spec.Schedule = "0 25 * * *"
+		_, err := h.ValidateCreate(ctx, s)
+		Expect(err).To(MatchError(ContainSubstring("invalid spec.schedule")))
+
+		s = sched()
+		s.Spec.TimeZone = "Mars/Olympus"
+		_, err = h.ValidateCreate(ctx, s)
+		Expect(err).To(MatchError(ContainSubstring("invalid spec.timeZone")))
+	})
+
+	It("rejects the schedule without any host or with an empty selector", func() {
+		s := sched()
+		s.Spec.Selector = nil
+		_, err := h.ValidateCreate(ctx, s)
+		Expect(err).To(HaveOccurred())
+
+		s.Spec.Selector = &metav1.LabelSelector{}
+		s.Spec.HostStatusNames = []string{"host1"}
+		_, err = h.ValidateCreate(ctx, s)
+		Expect(err).To(MatchError(ContainSubstring("must not be empty")))
+	})
+
+	It("only allows the timing and the rollout parameters to be updated", func() {
+		old := sched()
+		updated := old.DeepCopy()
+		updated.Spec.Schedule = "30 23 * * *"
+		updated.Spec.Suspend = true
+		updated.Spec.MaxConcurrent = 3
+		_, err := h.ValidateUpdate(ctx, old, updated)
+		Expect(err).NotTo(HaveOccurred())
+
+		updated.Spec.Template.Action = bmcv1beta1.BootCmdForceOff
+		_, err = h.ValidateUpdate(ctx, old, updated)
+		Expect(err).To(HaveOccurred())
+
+		updated = old.DeepCopy()
+		updated.Spec.Requester = &bmcv1beta1.Requester{Username: "mallory"}
+		_, err = h.ValidateUpdate(ctx, old, updated)
+		Expect(err).To(HaveOccurred())
+	})
+})
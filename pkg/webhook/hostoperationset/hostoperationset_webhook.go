@@ -0,0 +1,124 @@
+package hostoperationset
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	bmcv1beta1 "github.com/spidernet-io/bmc/pkg/k8s/apis/bmc.spidernet.io/v1beta1"
+	"github.com/spidernet-io/bmc/pkg/log"
+	hostoperationwebhook "github.com/spidernet-io/bmc/pkg/webhook/hostoperation"
+)
+
+type HostOperationSetWebhook struct {
+	Client       client.Client
+	TrustedUsers hostoperationwebhook.TrustedUsers
+}
+
+func (h *HostOperationSetWebhook) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	h.Client = mgr.GetClient()
+	log.Logger.Info("Setting up HostOperationSet webhook")
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&bmcv1beta1.HostOperationSet{}).
+		WithValidator(h).
+		WithDefaulter(h).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-bmc-spidernet-io-v1beta1-hostoperationset,mutating=true,failurePolicy=fail,sideEffects=None,groups=bmc.spidernet.io,resources=hostoperationsets,verbs=create;update,versions=v1beta1,name=mhostoperationset.kb.io,admissionReviewVersions=v1
+
+func (h *HostOperationSetWebhook) Default(ctx context.Context, obj runtime.Object) error {
+	set, ok := obj.(*bmcv1beta1.HostOperationSet)
+	if !ok {
+		err := fmt.Errorf("expected a HostOperationSet but got a %T", obj)
+		log.Logger.Error(err.Error())
+		return err
+	}
+
+	if set.Spec.MaxConcurrent == 0 {
+		set.Spec.MaxConcurrent = 1
+		log.Logger.Debugf("Setting default maxConcurrent to 1 for HostOperationSet %s", set.Name)
+	}
+	if err := hostoperationwebhook.SetRequester(ctx, h.Client, h.TrustedUsers, set, &set.Spec.Requester); err != nil {
+		log.Logger.Error(err.Error())
+		return err
+	}
+	return nil
+}
+
+// +kubebuilder:webhook:path=/validate-bmc-spidernet-io-v1beta1-hostoperationset,mutating=false,failurePolicy=fail,sideEffects=None,groups=bmc.spidernet.io,resources=hostoperationsets,verbs=create;update,versions=v1beta1,name=vhostoperationset.kb.io,admissionReviewVersions=v1
+
+func (h *HostOperationSetWebhook) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	set, ok := obj.(*bmcv1beta1.HostOperationSet)
+	if !ok {
+		err := fmt.Errorf("expected a HostOperationSet but got a %T", obj)
+		log.Logger.Error(err.Error())
+		return nil, err
+	}
+
+	log.Logger.Debugf("Processing ValidateCreate webhook for HostOperationSet %s", set.Name)
+
+	if set.Spec.Selector == nil && len(set.Spec.HostStatusNames) == 0 {
+		err := fmt.Errorf("either spec.selector or spec.hostStatusNames must be specified")
+		log.Logger.Errorf(err.Error())
+		return nil, err
+	}
+	if set.Spec.Selector != nil {
+		// an empty selector selects all the HostStatuses of the cluster
+		if len(set.Spec.Selector.MatchLabels) == 0 && len(set.Spec.Selector.MatchExpressions) == 0 {
+			err := fmt.Errorf("spec.selector must not be empty")
+			log.Logger.Errorf(err.Error())
+			return nil, err
+		}
+		if _, err := metav1.LabelSelectorAsSelector(set.Spec.Selector); err != nil {
+			err = fmt.Errorf("invalid spec.selector: %v", err)
+			log.Logger.Errorf(err.Error())
+			return nil, err
+		}
+	}
+	if err := hostoperationwebhook.ValidateActionSpec(&set.Spec.Template); err != nil {
+		err = fmt.Errorf("invalid spec.template: %v", err)
+		log.Logger.Errorf(err.Error())
+		return nil, err
+	}
+
+	log.Logger.Debugf("Successfully validated HostOperationSet %s creation", set.Name)
+	return nil, nil
+}
+
+func (h *HostOperationSetWebhook) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	oldSet, ok := oldObj.(*bmcv1beta1.HostOperationSet)
+	if !ok {
+		err := fmt.Errorf("expected a HostOperationSet but got a %T", oldObj)
+		log.Logger.Error(err.Error())
+		return nil, err
+	}
+	newSet, ok := newObj.(*bmcv1beta1.HostOperationSet)
+	if !ok {
+		err := fmt.Errorf("expected a HostOperationSet but got a %T", newObj)
+		log.Logger.Error(err.Error())
+		return nil, err
+	}
+
+	// the hosts and the action can not be changed once the set is created,
+	// while the rollout parameters can be tuned at any time
+	if !reflect.DeepEqual(oldSet.Spec.Selector, newSet.Spec.Selector) ||
+		!reflect.DeepEqual(oldSet.Spec.HostStatusNames, newSet.Spec.HostStatusNames) ||
+		!reflect.DeepEqual(oldSet.Spec.Template, newSet.Spec.Template) ||
+		!reflect.DeepEqual(oldSet.Spec.Requester, newSet.Spec.Requester) || oldSet.Spec.Reason != newSet.Spec.Reason {
+		err := fmt.Errorf("spec.selector, spec.hostStatusNames, spec.template, spec.requester and spec.reason of HostOperationSet %s can not be modified", newSet.Name)
+		log.Logger.Errorf(err.Error())
+		return nil, err
+	}
+	return nil, nil
+}
+
+func (h *HostOperationSetWebhook) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
@@ -0,0 +1,71 @@
+package hostoperationset_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	bmcv1beta1 "github.com/spidernet-io/bmc/pkg/k8s/apis/bmc.spidernet.io/v1beta1"
+	"github.com/spidernet-io/bmc/pkg/webhook/hostoperationset"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("HostOperationSet webhook", Label("unitest"), func() {
+	h := &hostoperationset.HostOperationSetWebhook{}
+	ctx := context.Background()
+
+	set := func(selector *metav1.LabelSelector, names ...string) *bmcv1beta1.HostOperationSet {
+		return &bmcv1beta1.HostOperationSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "rack1-restart"},
+			Spec: bmcv1beta1.HostOperationSetSpec{
+				Selector:        selector,
+				HostStatusNames: names,
+				Template:        bmcv1beta1.HostOperationActionSpec{Action: bmcv1beta1.BootCmdGracefulRestart},
+			},
+		}
+	}
+
+	It("accepts the hosts selected by labels or names", func() {
+		_, err := h.ValidateCreate(ctx, set(&metav1.LabelSelector{MatchLabels: map[string]string{"rack": "rack1"}}))
+		Expect(err).NotTo(HaveOccurred())
+		_, err = h.ValidateCreate(ctx, set(nil, "host1"))
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("rejects the set without any host", func() {
+		_, err := h.ValidateCreate(ctx, set(nil))
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("rejects the empty selector which selects all the hosts", func() {
+		_, err := h.ValidateCreate(ctx, set(&metav1.LabelSelector{}))
+		Expect(err).To(MatchError(ContainSubstring("must not be empty")))
+		_, err = h.ValidateCreate(ctx, set(&metav1.LabelSelector{}, "host1"))
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("rejects the invalid selector and template", func() {
+		_, err := h.ValidateCreate(ctx, set(&metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+			{Key: "rack", Operator: "Unknown"},
+		}}))
+		Expect(err).To(MatchError(ContainSubstring("invalid spec.selector")))
+
+		s := set(nil, "host1")
+		s.Spec.Template.Action = bmcv1beta1.ActionSetPowerLimit
+		_, err = h.ValidateCreate(ctx, s)
+		Expect(err).To(MatchError(ContainSubstring("invalid spec.template")))
+	})
+
+	It("only allows the rollout parameters to be updated", func() {
+		old := set(nil, "host1")
+		updated := old.DeepCopy()
+		updated.Spec.MaxConcurrent = 5
+		updated.Spec.Paused = true
+		_, err := h.ValidateUpdate(ctx, old, updated)
+		Expect(err).NotTo(HaveOccurred())
+
+		updated.Spec.HostStatusNames = []string{"host2"}
+		_, err = h.ValidateUpdate(ctx, old, updated)
+		Expect(err).To(HaveOccurred())
+	})
+})
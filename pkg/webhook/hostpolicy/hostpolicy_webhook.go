@@ -0,0 +1,105 @@
+package hostpolicy
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/spidernet-io/bmc/pkg/hostpolicy"
+	bmcv1beta1 "github.com/spidernet-io/bmc/pkg/k8s/apis/bmc.spidernet.io/v1beta1"
+	"github.com/spidernet-io/bmc/pkg/log"
+	"github.com/spidernet-io/bmc/pkg/schedule"
+)
+
+type HostPolicyWebhook struct {
+	Client client.Client
+}
+
+func (h *HostPolicyWebhook) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	h.Client = mgr.GetClient()
+	log.Logger.Info("Setting up HostPolicy webhook")
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&bmcv1beta1.HostPolicy{}).
+		WithValidator(h).
+		WithDefaulter(h).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-bmc-spidernet-io-v1beta1-hostpolicy,mutating=true,failurePolicy=fail,sideEffects=None,groups=bmc.spidernet.io,resources=hostpolicies,verbs=create;update,versions=v1beta1,name=mhostpolicy.kb.io,admissionReviewVersions=v1
+
+func (h *HostPolicyWebhook) Default(ctx context.Context, obj runtime.Object) error {
+	policy, ok := obj.(*bmcv1beta1.HostPolicy)
+	if !ok {
+		err := fmt.Errorf("expected a HostPolicy but got a %T", obj)
+		log.Logger.Error(err.Error())
+		return err
+	}
+
+	if len(policy.Spec.DestructiveActions) == 0 {
+		policy.Spec.DestructiveActions = append([]string(nil), hostpolicy.DefaultDestructiveActions...)
+	}
+	return nil
+}
+
+// +kubebuilder:webhook:path=/validate-bmc-spidernet-io-v1beta1-hostpolicy,mutating=false,failurePolicy=fail,sideEffects=None,groups=bmc.spidernet.io,resources=hostpolicies,verbs=create;update,versions=v1beta1,name=vhostpolicy.kb.io,admissionReviewVersions=v1
+
+func (h *HostPolicyWebhook) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	policy, ok := obj.(*bmcv1beta1.HostPolicy)
+	if !ok {
+		err := fmt.Errorf("expected a HostPolicy but got a %T", obj)
+		log.Logger.Error(err.Error())
+		return nil, err
+	}
+
+	log.Logger.Debugf("Processing ValidateCreate webhook for HostPolicy %s", policy.Name)
+	if err := validate(policy); err != nil {
+		log.Logger.Errorf(err.Error())
+		return nil, err
+	}
+	return nil, nil
+}
+
+func (h *HostPolicyWebhook) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	policy, ok := newObj.(*bmcv1beta1.HostPolicy)
+	if !ok {
+		err := fmt.Errorf("expected a HostPolicy but got a %T", newObj)
+		log.Logger.Error(err.Error())
+		return nil, err
+	}
+
+	log.Logger.Debugf("Processing ValidateUpdate webhook for HostPolicy %s", policy.Name)
+	if err := validate(policy); err != nil {
+		log.Logger.Errorf(err.Error())
+		return nil, err
+	}
+	return nil, nil
+}
+
+func (h *HostPolicyWebhook) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func validate(policy *bmcv1beta1.HostPolicy) error {
+	if policy.Spec.Selector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(policy.Spec.Selector); err != nil {
+			return fmt.Errorf("invalid spec.selector: %v", err)
+		}
+	}
+	for i, w := range policy.Spec.MaintenanceWindows {
+		if _, err := schedule.Parse(w.Schedule); err != nil {
+			return fmt.Errorf("invalid spec.maintenanceWindows[%d].schedule: %v", i, err)
+		}
+		if _, err := schedule.LoadLocation(w.TimeZone); err != nil {
+			return fmt.Errorf("invalid spec.maintenanceWindows[%d].timeZone: %v", i, err)
+		}
+		if w.DurationMinutes <= 0 {
+			return fmt.Errorf("spec.maintenanceWindows[%d].durationMinutes must be positive", i)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package hostpolicy_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spidernet-io/bmc/pkg/hostpolicy"
+	bmcv1beta1 "github.com/spidernet-io/bmc/pkg/k8s/apis/bmc.spidernet.io/v1beta1"
+	hostpolicywebhook "github.com/spidernet-io/bmc/pkg/webhook/hostpolicy"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("HostPolicyWebhook", Label("unitest"), func() {
+	var (
+		ctx     context.Context
+		webhook *hostpolicywebhook.HostPolicyWebhook
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		webhook = &hostpolicywebhook.HostPolicyWebhook{}
+	})
+
+	policy := func(windows ...bmcv1beta1.MaintenanceWindow) *bmcv1beta1.HostPolicy {
+		return &bmcv1beta1.HostPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "policy1"},
+			Spec: bmcv1beta1.HostPolicySpec{
+				Selector:           &metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}},
+				MaintenanceWindows: windows,
+			},
+		}
+	}
+
+	It("defaults the destructive actions", func() {
+		p := policy()
+		Expect(webhook.Default(ctx, p)).To(Succeed())
+		Expect(p.Spec.DestructiveActions).To(Equal(hostpolicy.DefaultDestructiveActions))
+
+		p.Spec.DestructiveActions = []string{bmcv1beta1.BootCmdForceOff}
+		Expect(webhook.Default(ctx, p)).To(Succeed())
+		Expect(p.Spec.DestructiveActions).To(Equal([]string{bmcv1beta1.BootCmdForceOff}))
+	})
+
+	It("accepts valid maintenance windows", func() {
+		_, err := webhook.ValidateCreate(ctx, policy(
+			bmcv1beta1.MaintenanceWindow{Schedule: "0 2 * * 6", DurationMinutes: 120},
+			bmcv1beta1.MaintenanceWindow{Schedule: "30 22 * * *", DurationMinutes: 60, TimeZone: "Asia/Kolkata"},
+		))
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	DescribeTable("rejects an invalid maintenance window",
+		func(window bmcv1beta1.MaintenanceWindow, message string) {
+			_, err := webhook.ValidateCreate(ctx, policy(window))
+			Expect(err).To(MatchError(ContainSubstring(message)))
+			_, err = webhook.ValidateUpdate(ctx, policy(), policy(window))
+			Expect(err).To(MatchError(ContainSubstring(message)))
+		},
+		Entry("with an invalid schedule", bmcv1beta1.MaintenanceWindow{Schedule: "0 2 * *", DurationMinutes: 60},
+			"invalid spec.maintenanceWindows[0].schedule"),
+		Entry("with an unknown time zone", bmcv1beta1.MaintenanceWindow{Schedule: "0 2 * * *", DurationMinutes: 60, TimeZone: "Mars/Olympus"},
+			"invalid spec.maintenanceWindows[0].timeZone"),
+		Entry("without a duration", bmcv1beta1.MaintenanceWindow{Schedule: "0 2 * * *"},
+			"spec.maintenanceWindows[0].durationMinutes must be positive"),
+	)
+
+	It("rejects an invalid selector", func() {
+		p := policy()
+		p.Spec.Selector = &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+			{Key: "env", Operator: "Unknown", Values: []string{"prod"}},
+		}}
+		_, err := webhook.ValidateCreate(ctx, p)
+		Expect(err).To(MatchError(ContainSubstring("invalid spec.selector")))
+	})
+})
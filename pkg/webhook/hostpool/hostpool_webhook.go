@@ -0,0 +1,77 @@
+package hostpool
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	bmcv1beta1 "github.com/spidernet-io/bmc/pkg/k8s/apis/bmc.spidernet.io/v1beta1"
+	"github.com/spidernet-io/bmc/pkg/log"
+)
+
+type HostPoolWebhook struct {
+	Client client.Client
+}
+
+func (h *HostPoolWebhook) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	h.Client = mgr.GetClient()
+	log.Logger.Info("Setting up HostPool webhook")
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&bmcv1beta1.HostPool{}).
+		WithValidator(h).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/validate-bmc-spidernet-io-v1beta1-hostpool,mutating=false,failurePolicy=fail,sideEffects=None,groups=bmc.spidernet.io,resources=hostpools,verbs=create;update,versions=v1beta1,name=vhostpool.kb.io,admissionReviewVersions=v1
+
+func (h *HostPoolWebhook) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	pool, ok := obj.(*bmcv1beta1.HostPool)
+	if !ok {
+		err := fmt.Errorf("expected a HostPool but got a %T", obj)
+		log.Logger.Error(err.Error())
+		return nil, err
+	}
+
+	log.Logger.Debugf("Processing ValidateCreate webhook for HostPool %s", pool.Name)
+	if err := validate(pool); err != nil {
+		log.Logger.Errorf(err.Error())
+		return nil, err
+	}
+	return nil, nil
+}
+
+func (h *HostPoolWebhook) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	pool, ok := newObj.(*bmcv1beta1.HostPool)
+	if !ok {
+		err := fmt.Errorf("expected a HostPool but got a %T", newObj)
+		log.Logger.Error(err.Error())
+		return nil, err
+	}
+
+	log.Logger.Debugf("Processing ValidateUpdate webhook for HostPool %s", pool.Name)
+	if err := validate(pool); err != nil {
+		log.Logger.Errorf(err.Error())
+		return nil, err
+	}
+	return nil, nil
+}
+
+func (h *HostPoolWebhook) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func validate(pool *bmcv1beta1.HostPool) error {
+	// an empty selector selects all the HostStatuses of the cluster
+	if pool.Spec.Selector == nil || (len(pool.Spec.Selector.MatchLabels) == 0 && len(pool.Spec.Selector.MatchExpressions) == 0) {
+		return fmt.Errorf("spec.selector must not be empty")
+	}
+	if _, err := metav1.LabelSelectorAsSelector(pool.Spec.Selector); err != nil {
+		return fmt.Errorf("invalid spec.selector: %v", err)
+	}
+	return nil
+}
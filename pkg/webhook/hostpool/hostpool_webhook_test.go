@@ -0,0 +1,50 @@
+package hostpool_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	bmcv1beta1 "github.com/spidernet-io/bmc/pkg/k8s/apis/bmc.spidernet.io/v1beta1"
+	"github.com/spidernet-io/bmc/pkg/webhook/hostpool"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("HostPoolWebhook", Label("unitest"), func() {
+	var (
+		ctx     context.Context
+		webhook *hostpool.HostPoolWebhook
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		webhook = &hostpool.HostPoolWebhook{}
+	})
+
+	pool := func(selector *metav1.LabelSelector) *bmcv1beta1.HostPool {
+		return &bmcv1beta1.HostPool{
+			ObjectMeta: metav1.ObjectMeta{Name: "pool1"},
+			Spec:       bmcv1beta1.HostPoolSpec{Selector: selector},
+		}
+	}
+
+	It("accepts a valid selector", func() {
+		_, err := webhook.ValidateCreate(ctx, pool(&metav1.LabelSelector{MatchLabels: map[string]string{"pool": "a"}}))
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("rejects the missing or empty selector", func() {
+		_, err := webhook.ValidateCreate(ctx, pool(nil))
+		Expect(err).To(MatchError(ContainSubstring("spec.selector must not be empty")))
+		_, err = webhook.ValidateUpdate(ctx, pool(nil), pool(&metav1.LabelSelector{}))
+		Expect(err).To(MatchError(ContainSubstring("spec.selector must not be empty")))
+	})
+
+	It("rejects an invalid selector", func() {
+		selector := &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+			{Key: "pool", Operator: "Unknown", Values: []string{"a"}},
+		}}
+		_, err := webhook.ValidateCreate(ctx, pool(selector))
+		Expect(err).To(MatchError(ContainSubstring("invalid spec.selector")))
+	})
+})
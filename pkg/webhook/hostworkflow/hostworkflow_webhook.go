@@ -0,0 +1,169 @@
+package hostworkflow
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	bmcv1beta1 "github.com/spidernet-io/bmc/pkg/k8s/apis/bmc.spidernet.io/v1beta1"
+	"github.com/spidernet-io/bmc/pkg/log"
+	hostoperationwebhook "github.com/spidernet-io/bmc/pkg/webhook/hostoperation"
+)
+
+// the default timeout of the steps waiting for the power state or a property
+const defaultWaitTimeoutSeconds = 600
+
+type HostWorkflowWebhook struct {
+	Client       client.Client
+	TrustedUsers hostoperationwebhook.TrustedUsers
+}
+
+func (h *HostWorkflowWebhook) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	h.Client = mgr.GetClient()
+	log.Logger.Info("Setting up HostWorkflow webhook")
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&bmcv1beta1.HostWorkflow{}).
+		WithValidator(h).
+		WithDefaulter(h).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-bmc-spidernet-io-v1beta1-hostworkflow,mutating=true,failurePolicy=fail,sideEffects=None,groups=bmc.spidernet.io,resources=hostworkflows,verbs=create;update,versions=v1beta1,name=mhostworkflow.kb.io,admissionReviewVersions=v1
+
+func (h *HostWorkflowWebhook) Default(ctx context.Context, obj runtime.Object) error {
+	wf, ok := obj.(*bmcv1beta1.HostWorkflow)
+	if !ok {
+		err := fmt.Errorf("expected a HostWorkflow but got a %T", obj)
+		log.Logger.Error(err.Error())
+		return err
+	}
+
+	if err := hostoperationwebhook.SetRequester(ctx, h.Client, h.TrustedUsers, wf, &wf.Spec.Requester); err != nil {
+		log.Logger.Error(err.Error())
+		return err
+	}
+	req, err := admission.RequestFromContext(ctx)
+	if err != nil || req.Operation != admissionv1.Create {
+		return nil
+	}
+	for i := range wf.Spec.Steps {
+		step := &wf.Spec.Steps[i]
+		if step.OnFailure == "" {
+			step.OnFailure = bmcv1beta1.StepOnFailureAbort
+		}
+		if step.TimeoutSeconds == nil && (step.WaitPowerState != "" || step.WaitProperty != nil) {
+			t := int32(defaultWaitTimeoutSeconds)
+			step.TimeoutSeconds = &t
+		}
+	}
+	return nil
+}
+
+// +kubebuilder:webhook:path=/validate-bmc-spidernet-io-v1beta1-hostworkflow,mutating=false,failurePolicy=fail,sideEffects=None,groups=bmc.spidernet.io,resources=hostworkflows,verbs=create;update,versions=v1beta1,name=vhostworkflow.kb.io,admissionReviewVersions=v1
+
+func (h *HostWorkflowWebhook) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	wf, ok := obj.(*bmcv1beta1.HostWorkflow)
+	if !ok {
+		err := fmt.Errorf("expected a HostWorkflow but got a %T", obj)
+		log.Logger.Error(err.Error())
+		return nil, err
+	}
+
+	log.Logger.Debugf("Processing ValidateCreate webhook for HostWorkflow %s", wf.Name)
+	if err := validate(wf); err != nil {
+		log.Logger.Errorf(err.Error())
+		return nil, err
+	}
+
+	var hostStatus bmcv1beta1.HostStatus
+	if err := h.Client.Get(ctx, client.ObjectKey{Name: wf.Spec.HostStatusName}, &hostStatus); err != nil {
+		err = fmt.Errorf("hostStatus %s not found: %v", wf.Spec.HostStatusName, err)
+		log.Logger.Errorf(err.Error())
+		return nil, err
+	}
+	return nil, nil
+}
+
+func (h *HostWorkflowWebhook) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	oldWf, ok := oldObj.(*bmcv1beta1.HostWorkflow)
+	if !ok {
+		err := fmt.Errorf("expected a HostWorkflow but got a %T", oldObj)
+		log.Logger.Error(err.Error())
+		return nil, err
+	}
+	newWf, ok := newObj.(*bmcv1beta1.HostWorkflow)
+	if !ok {
+		err := fmt.Errorf("expected a HostWorkflow but got a %T", newObj)
+		log.Logger.Error(err.Error())
+		return nil, err
+	}
+
+	if !reflect.DeepEqual(oldWf.Spec, newWf.Spec) {
+		err := fmt.Errorf("the spec of HostWorkflow %s can not be modified", newWf.Name)
+		log.Logger.Errorf(err.Error())
+		return nil, err
+	}
+	return nil, nil
+}
+
+func (h *HostWorkflowWebhook) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func validate(wf *bmcv1beta1.HostWorkflow) error {
+	if len(wf.Spec.Steps) == 0 {
+		return fmt.Errorf("spec.steps must not be empty")
+	}
+	names := map[string]bool{}
+	for i := range wf.Spec.Steps {
+		step := &wf.Spec.Steps[i]
+		if step.Name == "" {
+			return fmt.Errorf("spec.steps[%d].name must not be empty", i)
+		}
+		if names[step.Name] {
+			return fmt.Errorf("spec.steps[%d].name %s is duplicated", i, step.Name)
+		}
+		names[step.Name] = true
+
+		kinds := 0
+		if step.Operation != nil {
+			kinds++
+			if err := hostoperationwebhook.ValidateActionSpec(step.Operation); err != nil {
+				return fmt.Errorf("invalid spec.steps[%d].operation: %v", i, err)
+			}
+		}
+		if step.WaitPowerState != "" {
+			kinds++
+		}
+		if step.DelaySeconds != nil {
+			kinds++
+		}
+		if step.WaitProperty != nil {
+			kinds++
+			if step.WaitProperty.URI == "" || step.WaitProperty.Property == "" {
+				return fmt.Errorf("spec.steps[%d].waitProperty requires uri and property", i)
+			}
+		}
+		if kinds != 1 {
+			return fmt.Errorf("spec.steps[%d] must have exactly one of operation, waitPowerState, delaySeconds and waitProperty", i)
+		}
+
+		if step.OnFailure == bmcv1beta1.StepOnFailureRollback {
+			if step.Rollback == nil {
+				return fmt.Errorf("spec.steps[%d].rollback is required by onFailure %s", i, bmcv1beta1.StepOnFailureRollback)
+			}
+			if err := hostoperationwebhook.ValidateActionSpec(step.Rollback); err != nil {
+				return fmt.Errorf("invalid spec.steps[%d].rollback: %v", i, err)
+			}
+		} else if step.Rollback != nil {
+			return fmt.Errorf("spec.steps[%d].rollback only works with onFailure %s", i, bmcv1beta1.StepOnFailureRollback)
+		}
+	}
+	return nil
+}
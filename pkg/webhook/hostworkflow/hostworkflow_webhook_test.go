@@ -0,0 +1,122 @@
+package hostworkflow_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	bmcv1beta1 "github.com/spidernet-io/bmc/pkg/k8s/apis/bmc.spidernet.io/v1beta1"
+	"github.com/spidernet-io/bmc/pkg/webhook/hostworkflow"
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+var _ = Describe("HostWorkflowWebhook", Label("unitest"), func() {
+	var (
+		ctx     context.Context
+		webhook *hostworkflow.HostWorkflowWebhook
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		scheme := runtime.NewScheme()
+		Expect(bmcv1beta1.AddToScheme(scheme)).To(Succeed())
+		c := fake.NewClientBuilder().WithScheme(scheme).
+			WithObjects(&bmcv1beta1.HostStatus{ObjectMeta: metav1.ObjectMeta{Name: "host1"}}).Build()
+		webhook = &hostworkflow.HostWorkflowWebhook{Client: c}
+	})
+
+	workflow := func(steps ...bmcv1beta1.HostWorkflowStep) *bmcv1beta1.HostWorkflow {
+		return &bmcv1beta1.HostWorkflow{
+			ObjectMeta: metav1.ObjectMeta{Name: "wf1"},
+			Spec:       bmcv1beta1.HostWorkflowSpec{HostStatusName: "host1", Steps: steps},
+		}
+	}
+	operation := func(action string) *bmcv1beta1.HostOperationActionSpec {
+		return &bmcv1beta1.HostOperationActionSpec{Action: action}
+	}
+
+	Context("Default", func() {
+		It("defaults onFailure and the timeout of the waits on create", func() {
+			wf := workflow(
+				bmcv1beta1.HostWorkflowStep{Name: "off", Operation: operation(bmcv1beta1.BootCmdGracefulShutdown)},
+				bmcv1beta1.HostWorkflowStep{Name: "wait", WaitPowerState: "Off", OnFailure: bmcv1beta1.StepOnFailureContinue},
+			)
+			req := admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+				Operation: admissionv1.Create,
+				UserInfo:  authenticationv1.UserInfo{Username: "alice"},
+			}}
+			Expect(webhook.Default(admission.NewContextWithRequest(ctx, req), wf)).To(Succeed())
+
+			Expect(wf.Spec.Requester).NotTo(BeNil())
+			Expect(wf.Spec.Requester.Username).To(Equal("alice"))
+			Expect(wf.Spec.Steps[0].OnFailure).To(Equal(bmcv1beta1.StepOnFailureAbort))
+			Expect(wf.Spec.Steps[0].TimeoutSeconds).To(BeNil())
+			Expect(wf.Spec.Steps[1].OnFailure).To(Equal(bmcv1beta1.StepOnFailureContinue))
+			Expect(wf.Spec.Steps[1].TimeoutSeconds).To(Equal(ptr.To[int32](600)))
+		})
+	})
+
+	Context("ValidateCreate", func() {
+		It("accepts a valid workflow", func() {
+			wf := workflow(
+				bmcv1beta1.HostWorkflowStep{Name: "off", Operation: operation(bmcv1beta1.BootCmdGracefulShutdown)},
+				bmcv1beta1.HostWorkflowStep{Name: "delay", DelaySeconds: ptr.To[int32](10)},
+				bmcv1beta1.HostWorkflowStep{Name: "on", Operation: operation(bmcv1beta1.BootCmdOn),
+					OnFailure: bmcv1beta1.StepOnFailureRollback, Rollback: operation(bmcv1beta1.BootCmdForceOff)},
+			)
+			_, err := webhook.ValidateCreate(ctx, wf)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		DescribeTable("rejects an invalid workflow",
+			func(message string, steps ...bmcv1beta1.HostWorkflowStep) {
+				_, err := webhook.ValidateCreate(ctx, workflow(steps...))
+				Expect(err).To(MatchError(ContainSubstring(message)))
+			},
+			Entry("without steps", "spec.steps must not be empty"),
+			Entry("with a step without a name", "spec.steps[0].name must not be empty",
+				bmcv1beta1.HostWorkflowStep{DelaySeconds: ptr.To[int32](10)}),
+			Entry("with duplicated step names", "spec.steps[1].name a is duplicated",
+				bmcv1beta1.HostWorkflowStep{Name: "a", DelaySeconds: ptr.To[int32](10)},
+				bmcv1beta1.HostWorkflowStep{Name: "a", DelaySeconds: ptr.To[int32](10)}),
+			Entry("with a step of no kind", "must have exactly one of",
+				bmcv1beta1.HostWorkflowStep{Name: "a"}),
+			Entry("with a step of two kinds", "must have exactly one of",
+				bmcv1beta1.HostWorkflowStep{Name: "a", WaitPowerState: "On", DelaySeconds: ptr.To[int32](10)}),
+			Entry("with an invalid operation", "invalid spec.steps[0].operation",
+				bmcv1beta1.HostWorkflowStep{Name: "a", Operation: operation(bmcv1beta1.ActionSetPowerLimit)}),
+			Entry("with an incomplete waitProperty", "spec.steps[0].waitProperty requires uri and property",
+				bmcv1beta1.HostWorkflowStep{Name: "a", WaitProperty: &bmcv1beta1.WaitPropertySpec{URI: "/redfish/v1/Systems/1"}}),
+			Entry("with the Rollback strategy but no rollback", "spec.steps[0].rollback is required",
+				bmcv1beta1.HostWorkflowStep{Name: "a", Operation: operation(bmcv1beta1.BootCmdOn), OnFailure: bmcv1beta1.StepOnFailureRollback}),
+			Entry("with a rollback of another strategy", "spec.steps[0].rollback only works with onFailure Rollback",
+				bmcv1beta1.HostWorkflowStep{Name: "a", Operation: operation(bmcv1beta1.BootCmdOn), Rollback: operation(bmcv1beta1.BootCmdForceOff)}),
+		)
+
+		It("rejects the workflow of an unknown HostStatus", func() {
+			wf := workflow(bmcv1beta1.HostWorkflowStep{Name: "a", DelaySeconds: ptr.To[int32](10)})
+			wf.Spec.HostStatusName = "host2"
+			_, err := webhook.ValidateCreate(ctx, wf)
+			Expect(err).To(MatchError(ContainSubstring("hostStatus host2 not found")))
+		})
+	})
+
+	Context("ValidateUpdate", func() {
+		It("rejects the modification of the spec", func() {
+			oldWf := workflow(bmcv1beta1.HostWorkflowStep{Name: "a", DelaySeconds: ptr.To[int32](10)})
+			newWf := oldWf.DeepCopy()
+			_, err := webhook.ValidateUpdate(ctx, oldWf, newWf)
+			Expect(err).NotTo(HaveOccurred())
+
+			newWf.Spec.Steps[0].DelaySeconds = ptr.To[int32](20)
+			_, err = webhook.ValidateUpdate(ctx, oldWf, newWf)
+			Expect(err).To(MatchError(ContainSubstring("can not be modified")))
+		})
+	})
+})